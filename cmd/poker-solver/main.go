@@ -1,13 +1,21 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"os/signal"
+	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/behrlich/poker-solver/pkg/abstraction"
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/equity"
 	"github.com/behrlich/poker-solver/pkg/notation"
 	"github.com/behrlich/poker-solver/pkg/solver"
 	"github.com/behrlich/poker-solver/pkg/tree"
@@ -17,8 +25,11 @@ func main() {
 	// Define flags
 	iterations := flag.Int("iterations", 10000, "Number of CFR iterations to run")
 	verbose := flag.Bool("verbose", false, "Show detailed output")
-	saveFile := flag.String("save", "", "Save strategy profile to JSON file")
-	loadFile := flag.String("load", "", "Load strategy profile from JSON file (skips solving)")
+	saveFile := flag.String("save", "", "Save strategy profile to JSON file (.ndjson streams one info set per line)")
+	loadFile := flag.String("load", "", "Load strategy profile from JSON or .ndjson file (skips solving)")
+	equityOnly := flag.Bool("equity", false, "Print the acting player's equity against the opponent's range (skips solving)")
+	dumpTree := flag.String("dump-tree", "", "Write the built game tree to a Graphviz DOT file")
+	threads := flag.Int("threads", runtime.NumCPU(), "Goroutines for tree building and range solving (1 = serial)")
 
 	// Geometric bet sizing flags
 	useGeometric := flag.Bool("geometric", false, "Use geometric bet sizing")
@@ -27,6 +38,8 @@ func main() {
 
 	// Card abstraction flags
 	numBuckets := flag.Int("buckets", 0, "Number of buckets for card abstraction (0 = disabled)")
+	saveBuckets := flag.String("save-buckets", "", "Save bucket assignments to JSON file (requires --buckets)")
+	loadBuckets := flag.String("load-buckets", "", "Load bucket assignments from JSON file (instead of --buckets)")
 
 	flag.Parse()
 
@@ -68,6 +81,12 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  # Flop with geometric sizing and bucketing\n")
 		fmt.Fprintf(os.Stderr, "  poker-solver --geometric --target-pot=30 --buckets=100 \\\n")
 		fmt.Fprintf(os.Stderr, "    \"BTN:AA,KK:S97.5/BB:QQ,JJ:S97.5|P5.5|Th9h2c|>BTN\"\n\n")
+		fmt.Fprintf(os.Stderr, "  # Equity only (no solve)\n")
+		fmt.Fprintf(os.Stderr, "  poker-solver --equity \"BTN:AsKd:S100/BB:QQ,JJ:S100|P10|Kh9s4c7d2s|>BTN\"\n\n")
+		fmt.Fprintf(os.Stderr, "  # Dump the game tree for Graphviz (dot -Tsvg tree.dot -o tree.svg)\n")
+		fmt.Fprintf(os.Stderr, "  poker-solver --dump-tree=tree.dot \"BTN:AA:S100/BB:QQ:S100|P10|Kh9s4c7d2s|>BTN\"\n\n")
+		fmt.Fprintf(os.Stderr, "  # Range solve on 4 threads\n")
+		fmt.Fprintf(os.Stderr, "  poker-solver --threads=4 \"BTN:AA,KK,AKs:S100/BB:QQ,JJ,AQs:S100|P10|Kh9s4c7d2s|>BTN\"\n\n")
 		fmt.Fprintf(os.Stderr, "  # Save/load strategies\n")
 		fmt.Fprintf(os.Stderr, "  poker-solver --save=strategy.json \"BTN:AA:S100/BB:QQ:S100|P10|Kh9s4c7d2s|>BTN\"\n")
 		fmt.Fprintf(os.Stderr, "  poker-solver --load=strategy.json\n")
@@ -83,7 +102,8 @@ func main() {
 		fmt.Printf("Parsing position: %s\n", positionStr)
 	}
 
-	gs, err := notation.ParsePosition(positionStr)
+	// Strip board-blocked combos up front so reported combo counts are accurate
+	gs, err := notation.ParsePositionWithOptions(positionStr, notation.ParseOptions{RemoveBlockers: true, StrictHistory: true, LooseBoard: true})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing position: %v\n", err)
 		os.Exit(1)
@@ -95,6 +115,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *equityOnly {
+		if err := printEquity(os.Stdout, gs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Determine if we have specific cards or ranges
 	isRangeVsRange := len(gs.Players[0].Range) > 1 || len(gs.Players[1].Range) > 1
 
@@ -113,53 +141,29 @@ func main() {
 			fmt.Printf("%s", card.String())
 		}
 		fmt.Printf(" (%s)\n", gs.Street.String())
+		if len(gs.Board) > 0 {
+			fmt.Printf("  Texture: %s\n", notation.ClassifyBoard(gs.Board))
+		}
 		fmt.Printf("  To act: %s\n\n", gs.Players[gs.ToAct].Position)
 	}
 
-	// Determine street and configuration
-	numBoardCards := len(gs.Board)
-	isRiver := numBoardCards == 5
-	isTurn := numBoardCards == 4
-	isFlop := numBoardCards == 3
-
-	// Build action config
-	var config tree.ActionConfig
+	if *threads <= 0 {
+		*threads = runtime.NumCPU()
+	}
+	solveOpts := solver.SolveOptions{
+		Iterations: *iterations,
+		NumSizes:   *numSizes,
+		Threads:    *threads,
+		Seed:       42, // Fixed seed for reproducibility
+	}
 	if *useGeometric {
-		// Use geometric bet sizing
-		effectiveStack := gs.Players[0].Stack
-		if gs.Players[1].Stack < effectiveStack {
-			effectiveStack = gs.Players[1].Stack
-		}
-
-		// Calculate number of streets remaining
-		numStreets := 0
-		if isFlop {
-			numStreets = 3 // flop, turn, river
-		} else if isTurn {
-			numStreets = 2 // turn, river
-		} else if isRiver {
-			numStreets = 1 // river only
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: Unsupported board size %d (must be 3, 4, or 5 cards)\n", numBoardCards)
-			os.Exit(1)
-		}
-
-		geoSizing := tree.NewGeometricSizing(*targetPot, numStreets, effectiveStack)
-		config = tree.ActionConfig{
-			GeometricSizing:   geoSizing,
-			NumGeometricSizes: *numSizes,
-			AllowCheck:        true,
-			AllowCall:         true,
-			AllowFold:         true,
-		}
-
+		// Bets are sized to reach the target pot by the river, capped by the
+		// effective stack of both players
+		solveOpts.TargetPot = *targetPot
 		if *verbose {
 			fmt.Printf("Using geometric sizing: target %.1fbb pot, %d streets, %d sizes\n",
-				*targetPot, numStreets, *numSizes)
+				*targetPot, 6-len(gs.Board), *numSizes)
 		}
-	} else {
-		// Use default pot-relative bet sizes
-		config = tree.DefaultRiverConfig()
 	}
 
 	// Build game tree
@@ -171,10 +175,25 @@ func main() {
 		}
 	}
 
-	builder := tree.NewBuilder(config)
+	// Add bucketing if requested; the CLI keeps the bucketer to save it
+	var bucketer *abstraction.Bucketer
+	if *loadBuckets != "" {
+		data, err := os.ReadFile(*loadBuckets)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading buckets: %v\n", err)
+			os.Exit(1)
+		}
+		bucketer, err = abstraction.ImportBucketer(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading buckets: %v\n", err)
+			os.Exit(1)
+		}
+		solveOpts.Bucketer = bucketer
 
-	// Add bucketing if requested
-	if *numBuckets > 0 {
+		if *verbose {
+			fmt.Printf("Using card abstraction: %d buckets loaded from %s\n", bucketer.NumBuckets(), *loadBuckets)
+		}
+	} else if *numBuckets > 0 {
 		// Create bucketer from acting player's perspective
 		// Note: This creates a single bucketer with opponent's range.
 		// For full range-vs-range, ideally we'd have two bucketers (one per player).
@@ -182,8 +201,8 @@ func main() {
 		oppIdx := 1 - gs.ToAct
 		oppRange := gs.Players[oppIdx].Range
 
-		bucketer := abstraction.NewBucketer(gs.Board, oppRange, *numBuckets)
-		builder.SetBucketer(bucketer)
+		bucketer = abstraction.NewBucketer(gs.Board, oppRange, *numBuckets)
+		solveOpts.Bucketer = bucketer
 
 		if *verbose {
 			fmt.Printf("Using card abstraction: %d buckets, opponent range = %d combos\n",
@@ -191,51 +210,63 @@ func main() {
 		}
 	}
 
-	var root *tree.TreeNode
-	if isRangeVsRange {
-		root, err = builder.BuildRange(gs, gs.Players[0].Range, gs.Players[1].Range)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error building range tree: %v\n", err)
+	root, err := solver.BuildPositionTree(gs, solveOpts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *verbose {
+		fmt.Printf("Tree built successfully: %s\n\n", root.Stats())
+	}
+
+	if *dumpTree != "" {
+		if err := writeTreeDOT(*dumpTree, root); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing tree: %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		combo0 := gs.Players[0].Range[0]
-		combo1 := gs.Players[1].Range[0]
-		root, err = builder.Build(gs, combo0, combo1)
+		fmt.Printf("Tree written to %s\n\n", *dumpTree)
+	}
+
+	// Save bucket assignments (every hand in the tree has been bucketed by now)
+	if *saveBuckets != "" && bucketer != nil {
+		data, err := bucketer.Export()
+		if err == nil {
+			err = os.WriteFile(*saveBuckets, data, 0644)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error building tree: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error saving buckets: %v\n", err)
 			os.Exit(1)
 		}
+		fmt.Printf("Buckets saved to %s\n\n", *saveBuckets)
 	}
 
+	// Ctrl-C stops training early and keeps the partial strategy
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	solveOpts.Train = solver.TrainOptions{
+		Context: ctx,
+		Logf: func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		},
+	}
 	if *verbose {
-		fmt.Printf("Tree built successfully\n\n")
+		solveOpts.Train.Progress = func(done, total int) {
+			fmt.Printf("  %d/%d iterations\n", done, total)
+		}
 	}
 
-	// Determine which solver to use based on street
-	// MCCFR: Required for turn/flop (needs rollout for future cards)
-	// Vanilla CFR: Efficient for river (no future cards to sample)
-	var profile *solver.StrategyProfile
-
-	if isFlop || isTurn {
-		// Use MCCFR for multi-street positions (flop or turn)
-		streetName := "turn"
-		if isFlop {
-			streetName = "flop"
-		}
-		fmt.Printf("Solving %s position with MCCFR (%d iterations)...\n", streetName, *iterations)
-		mccfr := solver.NewMCCFR(42) // Fixed seed for reproducibility
-		profile = mccfr.Train(root, *iterations)
-	} else if isRiver {
-		// Use vanilla CFR for river positions (more efficient, no rollout needed)
-		fmt.Printf("Solving river position with CFR (%d iterations)...\n", *iterations)
-		cfr := solver.NewCFR()
-		profile = cfr.Train(root, *iterations)
-	} else {
-		fmt.Fprintf(os.Stderr, "Error: Unsupported street (board has %d cards)\n", numBoardCards)
+	// The solver is picked by street (see solveTree)
+	profile, err := solveTree(os.Stdout, gs, root, solveOpts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
+	if ctx.Err() != nil {
+		fmt.Printf("Interrupted, showing partial strategy\n")
+	}
 	fmt.Printf("Solved! Found %d information sets\n\n", profile.NumInfoSets())
 
 	// Save strategy if requested
@@ -264,13 +295,9 @@ func printStrategies(profile *solver.StrategyProfile, gs *notation.GameState, is
 func printComboStrategies(profile *solver.StrategyProfile, gs *notation.GameState, verbose bool) {
 	fmt.Printf("=== STRATEGIES ===\n\n")
 
-	// Get all infosets and sort them for consistent output
+	// Sorted for consistent output
 	allStrats := profile.All()
-	infoSets := make([]string, 0, len(allStrats))
-	for infoSet := range allStrats {
-		infoSets = append(infoSets, infoSet)
-	}
-	sort.Strings(infoSets)
+	infoSets := profile.SortedInfoSets()
 
 	// Print each strategy
 	for _, infoSet := range infoSets {
@@ -280,6 +307,14 @@ func printComboStrategies(profile *solver.StrategyProfile, gs *notation.GameStat
 		// Parse infoset to show player position
 		// InfoSet format: "board|history|>player|cards"
 		fmt.Printf("InfoSet: %s\n", infoSet)
+		if parts := parseInfoSet(infoSet); parts != nil {
+			if hand, ok := describeHand(parts.cards, gs.Board); ok {
+				fmt.Printf("  (%s)\n", hand)
+			}
+			if mdf, ok := facingBetMDF(gs, parts.history); ok {
+				fmt.Printf("  (facing a bet, MDF %.1f%%)\n", mdf*100)
+			}
+		}
 
 		// Print action probabilities
 		for i, action := range strat.Actions {
@@ -300,6 +335,17 @@ func printComboStrategies(profile *solver.StrategyProfile, gs *notation.GameStat
 			}
 			fmt.Printf("\n")
 		}
+		if verbose && strat.ActionEV != nil {
+			// Latest per-action values for the acting player
+			fmt.Printf("  EVs: ")
+			for i, ev := range strat.ActionEV {
+				if i > 0 {
+					fmt.Printf(", ")
+				}
+				fmt.Printf("%s=%.2f", strat.Actions[i].String(), ev)
+			}
+			fmt.Printf("\n")
+		}
 
 		fmt.Printf("\n")
 	}
@@ -310,51 +356,10 @@ func printRangeStrategies(profile *solver.StrategyProfile, gs *notation.GameStat
 	fmt.Printf("=== RANGE-VS-RANGE STRATEGIES ===\n\n")
 
 	// Aggregate strategies by hand type and game situation
-	// Map: "position|history|handtype" -> aggregated strategy
-	aggregated := make(map[string]*AggregatedStrategy)
-
-	allStrats := profile.All()
-	for infoSet, strat := range allStrats {
-		// Parse infoset: "board|history|>player|cards"
-		parts := parseInfoSet(infoSet)
-		if parts == nil {
-			continue
-		}
-
-		// Extract hand type from specific cards (e.g., "AsAh" -> "AA")
-		handType := getHandType(parts.cards)
-
-		// Create aggregation key
-		aggKey := fmt.Sprintf("%s|%s|%s", parts.player, parts.history, handType)
-
-		if _, exists := aggregated[aggKey]; !exists {
-			aggregated[aggKey] = &AggregatedStrategy{
-				Player:   parts.player,
-				History:  parts.history,
-				HandType: handType,
-				Actions:  strat.Actions,
-				Probs:    make([]float64, len(strat.Actions)),
-				Count:    0,
-			}
-		}
-
-		// Add this combo's strategy to the aggregate
-		avgStrat := strat.GetAverageStrategy()
-		for i := range avgStrat {
-			aggregated[aggKey].Probs[i] += avgStrat[i]
-		}
-		aggregated[aggKey].Count++
-	}
-
-	// Average the probabilities
-	for _, agg := range aggregated {
-		for i := range agg.Probs {
-			agg.Probs[i] /= float64(agg.Count)
-		}
-	}
+	aggregated := profile.AggregateByHandType()
 
 	// Group by player and sort
-	playerStrats := make(map[string][]*AggregatedStrategy)
+	playerStrats := make(map[string][]solver.AggregatedStrategy)
 	for _, agg := range aggregated {
 		playerStrats[agg.Player] = append(playerStrats[agg.Player], agg)
 	}
@@ -386,6 +391,12 @@ func printRangeStrategies(profile *solver.StrategyProfile, gs *notation.GameStat
 			situation := "acts first"
 			if len(agg.History) > 0 {
 				situation = fmt.Sprintf("facing %s", agg.History)
+				if line := describeLine(agg.History, len(gs.Board) == 0); line != "" {
+					situation = fmt.Sprintf("after %s: %s", line, agg.History)
+				}
+				if mdf, ok := facingBetMDF(gs, agg.History); ok {
+					situation += fmt.Sprintf(", MDF %.1f%%", mdf*100)
+				}
 			}
 
 			fmt.Printf("  %s (%s):\n", agg.HandType, situation)
@@ -406,6 +417,194 @@ func printRangeStrategies(profile *solver.StrategyProfile, gs *notation.GameStat
 	}
 }
 
+// solveTree trains root with solver.NewPositionSolver's pick for gs's street and
+// reports the speed. opts.Threads CFR workers share the combo pairs at the root
+// of a range tree, and as many MCCFR workers each sample a trajectory per
+// iteration. Either way a run repeats exactly for a given thread count.
+func solveTree(w io.Writer, gs *notation.GameState, root *tree.TreeNode, opts solver.SolveOptions) (*solver.StrategyProfile, error) {
+	s, err := solver.NewPositionSolver(gs, opts)
+	if err != nil {
+		return nil, err
+	}
+	method := "CFR"
+	if _, ok := s.(*solver.MCCFR); ok {
+		method = "MCCFR"
+	}
+	iterations := opts.Iterations
+	if iterations <= 0 {
+		iterations = solver.DefaultSolveIterations
+	}
+
+	// The final progress call reports how many iterations ran before any interrupt
+	done := 0
+	train := opts.Train
+	train.Progress = func(d, total int) {
+		done = d
+		if opts.Train.Progress != nil {
+			opts.Train.Progress(d, total)
+		}
+	}
+
+	start := time.Now()
+	fmt.Fprintf(w, "Solving %s position with %s (%d iterations, %d threads)...\n", gs.Street, method, iterations, opts.Threads)
+	profile := s.Train(root, iterations, train)
+
+	if elapsed := time.Since(start).Seconds(); elapsed > 0 {
+		fmt.Fprintf(w, "%d iterations in %.2fs (%.0f iter/sec)\n", done, elapsed, float64(done)/elapsed)
+	}
+	return profile, nil
+}
+
+// writeTreeDOT writes the game tree to path as a Graphviz DOT file
+func writeTreeDOT(path string, root *tree.TreeNode) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := root.WriteDOT(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printEquity writes the acting player's equity against the opponent's range
+// Opponent combos blocked by the hero's cards are left out. A hero range is
+// averaged over every live combo matchup, as RangeVsRange does; no tree is built.
+func printEquity(w io.Writer, gs *notation.GameState) error {
+	hero := gs.Players[gs.ToAct]
+	opponent := gs.Players[1-gs.ToAct]
+	if len(hero.Range) == 0 || len(opponent.Range) == 0 {
+		return fmt.Errorf("both players need cards or a range")
+	}
+
+	// Opponent combos that share a card with the board or the hero combo can't
+	// be dealt against it; each hero combo counts once per live matchup
+	calc := equity.NewCalculator()
+	oppRange := notation.RemoveBlockers(opponent.Range, gs.Board)
+	var win, tie, eq float64
+	count, pairs, live := 0, 0, 0
+	heroDesc := ""
+	for _, combo := range hero.Range {
+		heroCards := combo.Cards()
+		opponents := notation.RemoveBlockers(oppRange, heroCards)
+		if len(opponents) == 0 {
+			continue
+		}
+		result := calc.CalculateEquity(heroCards, gs.Board, opponents)
+		n := float64(len(opponents))
+		win += result.WinPct * n
+		tie += result.TiePct * n
+		eq += result.Equity * n
+		count++
+		pairs += len(opponents)
+		live = len(opponents)
+		heroDesc = combo.String()
+	}
+	if pairs == 0 {
+		return fmt.Errorf("no %s combos left after removing cards blocked by %s and the board", opponent.Position, hero.Position)
+	}
+	win /= float64(pairs)
+	tie /= float64(pairs)
+	eq /= float64(pairs)
+
+	if count == 1 {
+		fmt.Fprintf(w, "%s (%s) vs %s (%d combos)\n", hero.Position, heroDesc, opponent.Position, live)
+	} else {
+		fmt.Fprintf(w, "%s (%d combos) vs %s (%d combos, %d matchups)\n", hero.Position, count, opponent.Position, len(oppRange), pairs)
+	}
+	fmt.Fprintf(w, "  Win:    %.1f%%\n", win*100)
+	fmt.Fprintf(w, "  Tie:    %.1f%%\n", tie*100)
+	fmt.Fprintf(w, "  Equity: %.1f%%\n", eq*100)
+	return nil
+}
+
+// facingBetMDF returns the minimum defense frequency for an info set history ending in a bet or raise
+// The pot is gs.Pot plus the chips committed after the position's own action history.
+func facingBetMDF(gs *notation.GameState, history string) (float64, bool) {
+	actions, err := notation.ParseHistory(history)
+	if err != nil || len(actions) < len(gs.ActionHistory) {
+		return 0, false
+	}
+	last := tree.GetLastAction(actions)
+	if last == nil || (last.Type != notation.Bet && last.Type != notation.Raise) {
+		return 0, false
+	}
+
+	committed := tree.StreetCommitments(actions)
+	before := tree.StreetCommitments(gs.ActionHistory)
+	potNow := gs.Pot + committed[0] + committed[1] - before[0] - before[1]
+	call := math.Abs(committed[0] - committed[1])
+
+	return tree.MinDefenseFrequency(potNow-call, call), true
+}
+
+// describeLine names the actions of an info set history in poker terms
+// Streets are separated by " / " and actions within a street by ", ", e.g.
+// "xb5.0r15.0" is "check, bet, check-raise". An opening bet is a "lead"; raises
+// are a "check-raise" from a player who checked earlier in the street, else a
+// "raise", "3-bet", "4-bet", ... by the street's bet level. Preflop the big
+// blind is the first bet, so an open is a "raise" and a call of the blind a
+// "limp". Returns "" for histories that don't parse.
+func describeLine(history string, preflop bool) string {
+	streets := strings.Split(history, "/")
+	labels := make([]string, 0, len(streets))
+	for i, street := range streets {
+		actions, err := notation.ParseHistory(street)
+		if err != nil {
+			return ""
+		}
+		if len(actions) > 0 {
+			labels = append(labels, describeStreet(actions, preflop && i == 0))
+		}
+	}
+	return strings.Join(labels, " / ")
+}
+
+// describeStreet labels one street's actions (see describeLine)
+// Players alternate, so action i belongs to player i%2.
+func describeStreet(actions []notation.Action, preflop bool) string {
+	level := 0 // bets and raises so far; the big blind counts preflop
+	if preflop {
+		level = 1
+	}
+	var checked [2]bool
+
+	names := make([]string, len(actions))
+	for i, action := range actions {
+		p := i % 2
+		switch action.Type {
+		case notation.Check:
+			checked[p] = true
+			names[i] = "check"
+		case notation.Bet:
+			level++
+			names[i] = "bet"
+			if i == 0 {
+				names[i] = "lead"
+			}
+		case notation.Raise:
+			level++
+			switch {
+			case checked[p]:
+				names[i] = "check-raise"
+			case level <= 2:
+				names[i] = "raise"
+			default:
+				names[i] = fmt.Sprintf("%d-bet", level)
+			}
+		case notation.Call:
+			names[i] = "call"
+			if preflop && level == 1 {
+				names[i] = "limp"
+			}
+		case notation.Fold:
+			names[i] = "fold"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
 // InfoSetParts holds parsed components of an information set key
 type InfoSetParts struct {
 	board   string
@@ -414,6 +613,15 @@ type InfoSetParts struct {
 	cards   string
 }
 
+// describeHand names the made hand of hole cards on a river board
+func describeHand(hole string, board []cards.Card) (string, bool) {
+	holeCards, err := cards.ParseCards(hole)
+	if err != nil || len(holeCards) != 2 || len(board) != 5 {
+		return "", false
+	}
+	return cards.Evaluate(append(holeCards, board...)).Describe(), true
+}
+
 // parseInfoSet parses an information set key into its components
 // Format: "board|history|>player|cards"
 func parseInfoSet(infoSet string) *InfoSetParts {
@@ -445,76 +653,12 @@ func parseInfoSet(infoSet string) *InfoSetParts {
 	}
 }
 
-// getHandType extracts hand type from specific cards
-// e.g., "AsAh" -> "AA", "KsKd" -> "KK"
-// For bucketed hands, returns the bucket ID as-is
-func getHandType(cards string) string {
-	// Handle bucketed hands - return bucket ID as-is
-	if strings.HasPrefix(cards, "BUCKET_") {
-		return cards
-	}
-
-	if len(cards) < 4 {
-		return cards
-	}
-
-	// Extract ranks (first and third characters)
-	rank1 := cards[0]
-	rank2 := cards[2]
-
-	if rank1 == rank2 {
-		// Pair
-		return string([]byte{rank1, rank2})
-	}
-
-	// Non-pair - return in canonical order (higher rank first)
-	suit1 := cards[1]
-	suit2 := cards[3]
-
-	suited := "o"
-	if suit1 == suit2 {
-		suited = "s"
-	}
-
-	// Determine canonical order
-	ranks := "AKQJT98765432"
-	idx1 := -1
-	idx2 := -1
-	for i, r := range ranks {
-		if byte(r) == rank1 {
-			idx1 = i
-		}
-		if byte(r) == rank2 {
-			idx2 = i
-		}
-	}
-
-	if idx1 < idx2 {
-		return string([]byte{rank1, rank2}) + suited
-	}
-	return string([]byte{rank2, rank1}) + suited
-}
-
-// AggregatedStrategy holds averaged strategy for a hand type in a situation
-type AggregatedStrategy struct {
-	Player   string
-	History  string
-	HandType string
-	Actions  []notation.Action
-	Probs    []float64
-	Count    int
-}
-
 // printAllStrategies prints all strategies in the profile (for load mode without position)
 func printAllStrategies(profile *solver.StrategyProfile, verbose bool) {
 	fmt.Printf("=== ALL STRATEGIES ===\n\n")
 
 	allStrats := profile.All()
-	infoSets := make([]string, 0, len(allStrats))
-	for infoSet := range allStrats {
-		infoSets = append(infoSets, infoSet)
-	}
-	sort.Strings(infoSets)
+	infoSets := profile.SortedInfoSets()
 
 	for _, infoSet := range infoSets {
 		strat := allStrats[infoSet]
@@ -538,6 +682,17 @@ func printAllStrategies(profile *solver.StrategyProfile, verbose bool) {
 			}
 			fmt.Printf("\n")
 		}
+		if verbose && strat.ActionEV != nil {
+			// Latest per-action values for the acting player
+			fmt.Printf("  EVs: ")
+			for i, ev := range strat.ActionEV {
+				if i > 0 {
+					fmt.Printf(", ")
+				}
+				fmt.Printf("%s=%.2f", strat.Actions[i].String(), ev)
+			}
+			fmt.Printf("\n")
+		}
 
 		fmt.Printf("\n")
 	}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/solver"
+)
+
+// TestPrintEquity_River checks the --equity path on a river where AA beats QQ
+func TestPrintEquity_River(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AdAc:S100/BB:QdQh:S100|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("Failed to parse position: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printEquity(&buf, gs); err != nil {
+		t.Fatalf("printEquity failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Equity: 100.0%") {
+		t.Errorf("Expected 100%% equity for AA vs QQ, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Tie:    0.0%") {
+		t.Errorf("Expected no ties, got:\n%s", out)
+	}
+}
+
+// TestPrintEquity_Blockers checks that opponent combos sharing a hero card are dropped
+func TestPrintEquity_Blockers(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AsKs:S100/BB:AK,QQ:S100|P10|2c3d7h9sJc|>BTN")
+	if err != nil {
+		t.Fatalf("Failed to parse position: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printEquity(&buf, gs); err != nil {
+		t.Fatalf("printEquity failed: %v", err)
+	}
+
+	// AsKs leaves 9 of 16 AK combos (all ties) and all 6 QQ (all losses)
+	out := buf.String()
+	for _, want := range []string{"vs BB (15 combos)", "Win:    0.0%", "Tie:    60.0%", "Equity: 30.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// TestPrintEquity_Preflop checks that preflop positions get sampled runout equity
+func TestPrintEquity_Preflop(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AdAc:S100/BB:QdQh:S100|P3||>BTN")
+	if err != nil {
+		t.Fatalf("Failed to parse position: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printEquity(&buf, gs); err != nil {
+		t.Fatalf("printEquity failed: %v", err)
+	}
+
+	// AA vs QQ is about 82%
+	out := buf.String()
+	if !strings.Contains(out, "Equity: 8") {
+		t.Errorf("Expected about 82%% equity for AA vs QQ, got:\n%s", out)
+	}
+}
+
+// TestDescribeHand checks the river hand names shown next to combo strategies
+func TestDescribeHand(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	if got, ok := describeHand("QhQd", board); !ok || got != "Pair of Queens" {
+		t.Errorf("describeHand(QhQd) = %q, %v; want Pair of Queens", got, ok)
+	}
+	if _, ok := describeHand("QhQd", board[:4]); ok {
+		t.Error("expected no description before the river")
+	}
+}
+
+func TestDescribeLine(t *testing.T) {
+	tests := []struct {
+		history string
+		preflop bool
+		want    string
+	}{
+		{"xb5.0r15.0", false, "check, bet, check-raise"},
+		{"xb5.0r15.0c", false, "check, bet, check-raise, call"},
+		{"b5.0", false, "lead"},
+		{"b5.0r15.0r45.0", false, "lead, raise, 3-bet"},
+		{"xx/b5.0", false, "check, check / lead"},
+		{"r3.0r9.0", true, "raise, 3-bet"},
+		{"c", true, "limp"},
+		{"cr4.0", true, "limp, raise"},
+		{"", false, ""},
+		{"z", false, ""},
+	}
+	for _, tt := range tests {
+		if got := describeLine(tt.history, tt.preflop); got != tt.want {
+			t.Errorf("describeLine(%q, %v) = %q, want %q", tt.history, tt.preflop, got, tt.want)
+		}
+	}
+}
+
+// TestSolveTree_Threads runs a small range solve on two threads: strategies must
+// be distributions and repeat exactly from run to run
+func TestSolveTree_Threads(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AA,KK,AKs:S100/BB:QQ,JJ,AQs:S100|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("Failed to parse position: %v", err)
+	}
+
+	solve := func() (*solver.StrategyProfile, string) {
+		opts := solver.SolveOptions{Iterations: 50, Threads: 2}
+		root, err := solver.BuildPositionTree(gs, opts)
+		if err != nil {
+			t.Fatalf("BuildPositionTree failed: %v", err)
+		}
+		var buf bytes.Buffer
+		profile, err := solveTree(&buf, gs, root, opts)
+		if err != nil {
+			t.Fatalf("solveTree failed: %v", err)
+		}
+		return profile, buf.String()
+	}
+
+	first, out := solve()
+	if !strings.Contains(out, "2 threads") || !strings.Contains(out, "50 iterations in") || !strings.Contains(out, "iter/sec") {
+		t.Errorf("Expected thread count and iteration speed in output, got:\n%s", out)
+	}
+
+	second, _ := solve()
+	if first.NumInfoSets() == 0 || first.NumInfoSets() != second.NumInfoSets() {
+		t.Fatalf("Runs found %d and %d info sets", first.NumInfoSets(), second.NumInfoSets())
+	}
+	for _, infoSet := range first.SortedInfoSets() {
+		a := first.All()[infoSet].GetAverageStrategy()
+		b := second.All()[infoSet].GetAverageStrategy()
+		sum := 0.0
+		for i := range a {
+			sum += a[i]
+			if a[i] != b[i] {
+				t.Errorf("%s: strategy %v on the first run, %v on the second", infoSet, a, b)
+				break
+			}
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s: strategy %v sums to %v", infoSet, a, sum)
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"syscall/js"
 	"time"
@@ -13,8 +14,11 @@ import (
 	"github.com/behrlich/poker-solver/pkg/tree"
 )
 
+// maxTreeNodes is the largest tree the browser build will try to solve
+const maxTreeNodes = 2000000
+
 // Global state for cancellation
-var cancelSolve chan bool
+var cancelSolve context.CancelFunc
 
 func main() {
 	// Register JavaScript functions
@@ -91,50 +95,30 @@ func runSolver(positionStr string, iterations int, progressCallback js.Value) (m
 		return nil, fmt.Errorf("parse error: %w", err)
 	}
 
-	// Determine range vs range
-	isRangeVsRange := len(gs.Players[0].Range) > 1 || len(gs.Players[1].Range) > 1
-
-	// Build tree using lightweight action config for web
-	config := tree.ActionConfig{
-		BetSizes:   []float64{0.75}, // single mid-size bet for low branching
-		AllowCheck: true,
-		AllowCall:  true,
-		AllowFold:  true,
-	}
-	builder := tree.NewBuilder(config)
-
-	var root *tree.TreeNode
-	if isRangeVsRange {
-		root, err = builder.BuildRange(gs, gs.Players[0].Range, gs.Players[1].Range)
-	} else {
-		combo0 := gs.Players[0].Range[0]
-		combo1 := gs.Players[1].Range[0]
-		root, err = builder.Build(gs, combo0, combo1)
+	// Lightweight action config for web; range rivers are sampled with MCCFR
+	// rather than enumerated, and trees that would exhaust browser memory are
+	// refused before training starts
+	opts := solver.SolveOptions{
+		Actions: &tree.ActionConfig{
+			BetSizes:   []float64{0.75}, // single mid-size bet for low branching
+			AllowCheck: true,
+			AllowCall:  true,
+			AllowFold:  true,
+		},
+		Seed:              42,
+		SampleRiverRanges: true,
+		MaxTreeNodes:      maxTreeNodes,
 	}
-
+	root, err := solver.BuildPositionTree(gs, opts)
 	if err != nil {
-		return nil, fmt.Errorf("tree build error: %w", err)
+		return nil, err
 	}
 
-	// Create cancel channel
-	cancelSolve = make(chan bool, 1)
-
-	// Determine solver type
-	numBoardCards := len(gs.Board)
-	isFlop := numBoardCards == 3
-	isTurn := numBoardCards == 4
-	var profile *solver.StrategyProfile
-
-	switch {
-	case isFlop || isTurn || isRangeVsRange:
-		// MCCFR for flop/turn and range river (chance sampling)
-		mccfr := solver.NewMCCFR(42)
-		profile = trainWithProgress(mccfr, root, iterations, progressCallback, false)
-	default:
-		// Vanilla CFR only for single-combo river
-		cfr := solver.NewCFR()
-		profile = trainWithProgress(cfr, root, iterations, progressCallback, true)
+	trainer, err := solver.NewPositionSolver(gs, opts)
+	if err != nil {
+		return nil, err
 	}
+	profile := trainWithProgress(trainer, root, iterations, progressCallback)
 
 	// Convert to JSON
 	strategyJSON, err := profile.ToJSON()
@@ -150,7 +134,8 @@ func runSolver(positionStr string, iterations int, progressCallback js.Value) (m
 }
 
 // trainWithProgress runs CFR/MCCFR with progress callbacks
-func trainWithProgress(trainer interface{}, root *tree.TreeNode, iterations int, progressCallback js.Value, isCFR bool) *solver.StrategyProfile {
+// Training stops early on cancelWrapper or after maxSolveDuration.
+func trainWithProgress(trainer solver.Solver, root *tree.TreeNode, iterations int, progressCallback js.Value) *solver.StrategyProfile {
 	// Safety limit
 	const maxIterations = 100000
 	const maxSolveDuration = 5 * time.Second
@@ -158,75 +143,41 @@ func trainWithProgress(trainer interface{}, root *tree.TreeNode, iterations int,
 		iterations = maxIterations
 	}
 
-	// Report progress every N iterations
-	reportInterval := iterations / 20 // Report 20 times during solve
-	if reportInterval < 100 {
-		reportInterval = 100
-	}
-
-	start := time.Now()
-
-	if isCFR {
-		cfr := trainer.(*solver.CFR)
-
-		for i := 0; i < iterations; i++ {
-			// Check for cancellation
-			select {
-			case <-cancelSolve:
-				return cfr.GetProfile()
-			default:
-			}
-
-			// Run iteration
-			cfr.Iterate(root)
+	ctx, cancel := context.WithTimeout(context.Background(), maxSolveDuration)
+	defer cancel()
+	cancelSolve = cancel
 
-			// Report progress
-			if !progressCallback.IsUndefined() && !progressCallback.IsNull() && (i%reportInterval == 0 || i == iterations-1) {
-				progress := map[string]interface{}{
-					"iteration": i + 1,
-					"total":     iterations,
-					"percent":   float64(i+1) / float64(iterations) * 100,
-				}
-				progressCallback.Invoke(js.ValueOf(progress))
-			}
+	opts := solver.TrainOptions{Context: ctx}
 
-			if time.Since(start) >= maxSolveDuration {
-				return cfr.GetProfile()
-			}
+	// Report progress 20 times during solve
+	if !progressCallback.IsUndefined() && !progressCallback.IsNull() {
+		reportInterval := iterations / 20
+		if reportInterval < 100 {
+			reportInterval = 100
 		}
+		opts.ProgressEvery = reportInterval
 
-		return cfr.GetProfile()
-	} else {
-		mccfr := trainer.(*solver.MCCFR)
-
-		for i := 0; i < iterations; i++ {
-			// Check for cancellation
-			select {
-			case <-cancelSolve:
-				return mccfr.GetProfile()
-			default:
-			}
-
-			// Run iteration
-			mccfr.Iterate(root)
+		// Sample exploitability at the same interval so the UI can chart convergence
+		var latest *solver.ConvergencePoint
+		opts.ConvergenceEvery = reportInterval
+		opts.OnConvergence = func(point solver.ConvergencePoint) {
+			latest = &point
+		}
 
-			// Report progress
-			if !progressCallback.IsUndefined() && !progressCallback.IsNull() && (i%reportInterval == 0 || i == iterations-1) {
-				progress := map[string]interface{}{
-					"iteration": i + 1,
-					"total":     iterations,
-					"percent":   float64(i+1) / float64(iterations) * 100,
-				}
-				progressCallback.Invoke(js.ValueOf(progress))
+		opts.Progress = func(done, total int) {
+			progress := map[string]interface{}{
+				"iteration": done,
+				"total":     total,
+				"percent":   float64(done) / float64(total) * 100,
 			}
-
-			if time.Since(start) >= maxSolveDuration {
-				return mccfr.GetProfile()
+			if latest != nil && latest.Iteration == done {
+				progress["exploitability"] = latest.Exploitability
 			}
+			progressCallback.Invoke(js.ValueOf(progress))
 		}
-
-		return mccfr.GetProfile()
 	}
+
+	return trainer.Train(root, iterations, opts)
 }
 
 // parsePositionWrapper wraps the position parser for JavaScript
@@ -271,10 +222,7 @@ func parsePositionWrapper(this js.Value, args []js.Value) interface{} {
 // cancelWrapper lets JS request solver cancellation
 func cancelWrapper(this js.Value, args []js.Value) interface{} {
 	if cancelSolve != nil {
-		select {
-		case cancelSolve <- true:
-		default:
-		}
+		cancelSolve()
 	}
 	return js.ValueOf(map[string]interface{}{
 		"status": "cancelled",
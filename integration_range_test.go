@@ -264,3 +264,43 @@ func TestIntegration_BucketedRangeOutput(t *testing.T) {
 
 	t.Logf("Successfully validated bucketed range-vs-range output format")
 }
+
+// TestIntegration_UnknownOpponentRange solves a hero combo against "??" (any two cards)
+func TestIntegration_UnknownOpponentRange(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AsKd:S100/BB:??:S100|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+
+	// 52 - 5 board - 2 hero cards leaves C(45,2) opponent combos
+	if got := len(gs.Players[1].Range); got != 990 {
+		t.Fatalf("unknown range has %d combos, want 990", got)
+	}
+
+	root, err := tree.NewBuilder(tree.DefaultRiverConfig()).BuildRange(gs, gs.Players[0].Range, gs.Players[1].Range)
+	if err != nil {
+		t.Fatalf("BuildRange failed: %v", err)
+	}
+	if len(root.Children) != 990 {
+		t.Errorf("root has %d combo pairs, want 990", len(root.Children))
+	}
+
+	profile := solver.NewCFR().Train(root, 100)
+
+	strat, ok := profile.Get("Kh9s4c7d2s||>BTN|AsKd")
+	if !ok {
+		t.Fatal("expected a strategy for the hero's root decision")
+	}
+	sum := 0.0
+	for _, prob := range strat.GetAverageStrategy() {
+		sum += prob
+	}
+	if math.Abs(sum-1.0) > 0.001 {
+		t.Errorf("hero strategy sums to %.3f", sum)
+	}
+
+	// The opponent gets an info set for each of its combos facing the hero's check
+	if _, ok := profile.Get("Kh9s4c7d2s|x|>BB|AhAc"); !ok {
+		t.Error("expected a strategy for an opponent combo after a check")
+	}
+}
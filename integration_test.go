@@ -2,6 +2,7 @@ package poker_test
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -362,3 +363,50 @@ func TestIntegration_TurnSolver(t *testing.T) {
 		}
 	}
 }
+
+// TestIntegration_ShoveOnlyRiver solves a push/fold river where BTN only has value:
+// BB's bluff-catcher should never prefer calling the shove
+func TestIntegration_ShoveOnlyRiver(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AA:S20/BB:QQ:S20|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("Failed to parse position: %v", err)
+	}
+
+	builder := tree.NewBuilder(tree.ActionConfig{ShoveOnly: true})
+	root, err := builder.BuildRange(gs, gs.Players[0].Range, gs.Players[1].Range)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	profile := solver.NewCFR().Train(root, 2000)
+
+	// Info sets BTN never shoves into keep a uniform strategy, so require that
+	// calling is never preferred and that reached spots fold almost always
+	facingShove, folding := 0, 0
+	for infoSet, strat := range profile.All() {
+		if !strings.Contains(infoSet, "|b20.0|>BB|") {
+			continue
+		}
+		facingShove++
+
+		avg := strat.GetAverageStrategy()
+		for i, action := range strat.Actions {
+			if action.Type != notation.Call {
+				continue
+			}
+			if avg[i] > 0.5+1e-9 {
+				t.Errorf("%s: QQ calls a value-only shove %.1f%%", infoSet, avg[i]*100)
+			}
+			if avg[i] < 0.01 {
+				folding++
+			}
+		}
+	}
+
+	if facingShove != 6 {
+		t.Errorf("expected 6 BB info sets facing the shove, got %d", facingShove)
+	}
+	if folding == 0 {
+		t.Error("expected QQ to fold to the shove in reached info sets")
+	}
+}
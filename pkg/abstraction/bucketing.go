@@ -6,18 +6,36 @@ import (
 	"math/rand"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
 	"github.com/behrlich/poker-solver/pkg/equity"
 	"github.com/behrlich/poker-solver/pkg/notation"
 )
 
+// HandBucketer maps hole cards to bucket IDs for card abstraction
+// Implemented by the grid-based Bucketer and the clustering KMeansBucketer and
+// HistogramBucketer.
+type HandBucketer interface {
+	BucketHand(hero []cards.Card) int
+	BucketCombo(combo notation.Combo) int
+	NumBuckets() int
+}
+
+var (
+	_ HandBucketer = (*Bucketer)(nil)
+	_ HandBucketer = (*KMeansBucketer)(nil)
+	_ HandBucketer = (*HistogramBucketer)(nil)
+)
+
 // Bucketer assigns hands to buckets based on equity and potential
+// Safe for concurrent use (e.g., from parallel tree building)
 type Bucketer struct {
 	board         []cards.Card
 	opponentRange []notation.Combo
 	numBuckets    int
 	calculator    *equity.Calculator
+	evaluator     cards.Evaluator
 
 	// Grid dimensions for 2D histogram
 	equityBins    int // Number of bins along equity axis
@@ -29,6 +47,13 @@ type Bucketer struct {
 	useSampling bool
 	samples     int
 	eqCache     map[string]eqPot
+
+	// Optional sample-stream seed mixed into each hand's deterministic seed (see SetSeed)
+	seed   int64
+	seeded bool
+
+	// Guards the caches so hands can be bucketed from multiple goroutines
+	mu sync.Mutex
 }
 
 type eqPot struct {
@@ -49,6 +74,7 @@ func NewBucketer(board []cards.Card, opponentRange []notation.Combo, numBuckets
 		opponentRange: opponentRange,
 		numBuckets:    numBuckets,
 		calculator:    equity.NewCalculator(),
+		evaluator:     cards.DefaultEvaluator,
 		equityBins:    gridSize,
 		potentialBins: gridSize,
 		cache:         make(map[string]int),
@@ -72,8 +98,41 @@ func NewBucketerSampled(board []cards.Card, opponentRange []notation.Combo, numB
 	return b
 }
 
+// SetSeed selects an independent sample stream for a sampled bucketer
+// Each hand is still sampled from its own repeatable seed, so buckets stay stable
+// and order-independent; the seed is mixed in so bucketers on the same board with
+// different seeds draw different samples. Without SetSeed the default stream is used.
+// Cached buckets are discarded.
+func (b *Bucketer) SetSeed(seed int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seed = seed
+	b.seeded = true
+	b.cache = make(map[string]int)
+	b.eqCache = make(map[string]eqPot)
+}
+
+// SetEvaluator sets the hand evaluator behind every equity (nil = cards.DefaultEvaluator)
+// Cached buckets are discarded.
+func (b *Bucketer) SetEvaluator(evaluator cards.Evaluator) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if evaluator == nil {
+		evaluator = cards.DefaultEvaluator
+	}
+	b.evaluator = evaluator
+	b.calculator.SetEvaluator(evaluator)
+	b.cache = make(map[string]int)
+	b.eqCache = make(map[string]eqPot)
+}
+
 // BucketHand assigns a hand to a bucket ID (0 to numBuckets-1)
 func (b *Bucketer) BucketHand(hero []cards.Card) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	// Check cache
 	cacheKey := fmt.Sprintf("%s%s", hero[0].String(), hero[1].String())
 	if bucket, exists := b.cache[cacheKey]; exists {
@@ -123,7 +182,7 @@ func (b *Bucketer) BucketHand(hero []cards.Card) int {
 
 // BucketCombo is a convenience wrapper for notation.Combo
 func (b *Bucketer) BucketCombo(combo notation.Combo) int {
-	hero := []cards.Card{combo.Card1, combo.Card2}
+	hero := combo.Cards()
 	return b.BucketHand(hero)
 }
 
@@ -135,11 +194,16 @@ func (b *Bucketer) sampleEquityPotential(hero []cards.Card) (float64, float64) {
 	}
 
 	seed := deterministicSeed(hero, b.board, b.oppHash)
+	if b.seeded {
+		seed = seed*31 + b.seed
+	}
 	rng := rand.New(rand.NewSource(seed))
 
 	// Build deck of remaining cards
-	remaining := remainingDeck(hero, b.board)
-	if len(remaining) < 2 {
+	remaining := cards.NewDeck()
+	remaining.Remove(hero...)
+	remaining.Remove(b.board...)
+	if remaining.Len() < 2 {
 		// Nothing to sample, fall back to deterministic evaluation
 		e := b.calculator.CalculateEquity(hero, b.board, b.opponentRange)
 		p := b.calculator.CalculatePotential(hero, b.board, b.opponentRange)
@@ -151,6 +215,9 @@ func (b *Bucketer) sampleEquityPotential(hero []cards.Card) (float64, float64) {
 	if samples <= 0 {
 		samples = 200
 	}
+	if len(b.board) == 5 {
+		samples = 1 // river runouts are all identical
+	}
 
 	var eqSamples []float64
 
@@ -159,34 +226,16 @@ func (b *Bucketer) sampleEquityPotential(hero []cards.Card) (float64, float64) {
 		switch len(b.board) {
 		case 5:
 			boardRunout = b.board
-		case 4:
-			// sample river
-			river := remaining[rng.Intn(len(remaining))]
+		case 3, 4:
+			// sample turn and/or river from a fresh copy of the deck
 			boardRunout = append([]cards.Card{}, b.board...)
-			boardRunout = append(boardRunout, river)
-		case 3:
-			if len(remaining) < 2 {
-				continue
-			}
-			turnIdx := rng.Intn(len(remaining))
-			turn := remaining[turnIdx]
-
-			// pick river from remaining without turn
-			riverPool := append([]cards.Card{}, remaining[:turnIdx]...)
-			riverPool = append(riverPool, remaining[turnIdx+1:]...)
-			if len(riverPool) == 0 {
-				continue
-			}
-			river := riverPool[rng.Intn(len(riverPool))]
-
-			boardRunout = append([]cards.Card{}, b.board...)
-			boardRunout = append(boardRunout, turn, river)
+			boardRunout = append(boardRunout, remaining.Clone().Deal(rng, 5-len(b.board))...)
 		default:
 			// unsupported board size
 			continue
 		}
 
-		heroHand := cards.Evaluate(append(hero, boardRunout...))
+		heroHand := b.evaluator.Evaluate(hero, boardRunout)
 
 		wins := 0.0
 		ties := 0.0
@@ -195,7 +244,7 @@ func (b *Bucketer) sampleEquityPotential(hero []cards.Card) (float64, float64) {
 		for _, oppCombo := range b.opponentRange {
 			// skip conflicts with runout
 			conflict := false
-			for _, card := range []cards.Card{oppCombo.Card1, oppCombo.Card2} {
+			for _, card := range oppCombo.Cards() {
 				if card == hero[0] || card == hero[1] {
 					conflict = true
 					break
@@ -214,7 +263,7 @@ func (b *Bucketer) sampleEquityPotential(hero []cards.Card) (float64, float64) {
 				continue
 			}
 
-			oppHand := cards.Evaluate([]cards.Card{oppCombo.Card1, oppCombo.Card2, boardRunout[0], boardRunout[1], boardRunout[2], boardRunout[3], boardRunout[4]})
+			oppHand := b.evaluator.Evaluate(oppCombo.Cards(), boardRunout)
 			cmp := heroHand.Compare(oppHand)
 			if cmp > 0 {
 				wins++
@@ -266,28 +315,6 @@ func (b *Bucketer) sampleEquityPotential(hero []cards.Card) (float64, float64) {
 	return mean, normalizedVar
 }
 
-// remainingDeck returns all cards excluding hero and board.
-func remainingDeck(hero []cards.Card, board []cards.Card) []cards.Card {
-	used := make(map[cards.Card]bool)
-	for _, c := range board {
-		used[c] = true
-	}
-	for _, c := range hero {
-		used[c] = true
-	}
-
-	remaining := make([]cards.Card, 0, 52-len(used))
-	for rank := cards.Two; rank <= cards.Ace; rank++ {
-		for suit := cards.Spades; suit <= cards.Clubs; suit++ {
-			card := cards.Card{Rank: rank, Suit: suit}
-			if !used[card] {
-				remaining = append(remaining, card)
-			}
-		}
-	}
-	return remaining
-}
-
 // deterministicSeed builds a repeatable seed from hero, board, and opponent range hash.
 func deterministicSeed(hero []cards.Card, board []cards.Card, oppHash string) int64 {
 	builder := make([]byte, 0, 64)
@@ -337,6 +364,9 @@ func (b *Bucketer) NumBuckets() int {
 
 // ClearCache clears the bucket cache (useful if board or opponent range changes)
 func (b *Bucketer) ClearCache() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	b.cache = make(map[string]int)
 	b.eqCache = make(map[string]eqPot)
 }
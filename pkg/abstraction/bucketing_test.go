@@ -1,6 +1,7 @@
 package abstraction
 
 import (
+	"math"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -281,3 +282,35 @@ func BenchmarkBucketHand_NoCache(b *testing.B) {
 		bucketer.BucketHand(hero)
 	}
 }
+
+func TestBucketerSampled_SetSeed(t *testing.T) {
+	board, _ := cards.ParseCards("Th9h2c")
+	oppRange, _ := notation.ParseRange("AA,KK,QQ,JJ,AKs")
+	hero, _ := cards.ParseCards("AhKh")
+
+	// Default stream is unchanged by the seed option
+	defaultEq, defaultPot := NewBucketerSampled(board, oppRange, 100, 100).sampleEquityPotential(hero)
+	if math.Abs(defaultEq-0.50061607142857134) > 1e-12 || math.Abs(defaultPot-0.75798187538974049) > 1e-12 {
+		t.Errorf("default sampled equity/potential = %v/%v, want 0.50061607142857134/0.75798187538974049", defaultEq, defaultPot)
+	}
+
+	sampled := func(seed int64) float64 {
+		b := NewBucketerSampled(board, oppRange, 100, 100)
+		b.SetSeed(seed)
+		eq, _ := b.sampleEquityPotential(hero)
+		return eq
+	}
+
+	eq1, eq2 := sampled(1), sampled(2)
+	if eq1 == eq2 {
+		t.Errorf("seeds 1 and 2 produced identical equity %v", eq1)
+	}
+	if again := sampled(1); again != eq1 {
+		t.Errorf("seed 1 equity not stable: %v then %v", eq1, again)
+	}
+	for _, eq := range []float64{eq1, eq2} {
+		if eq < 0.4 || eq > 0.6 {
+			t.Errorf("seeded equity %v far from default estimate %v", eq, defaultEq)
+		}
+	}
+}
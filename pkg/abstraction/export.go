@@ -0,0 +1,112 @@
+package abstraction
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// bucketerFormatVersion is bumped when the export format changes
+const bucketerFormatVersion = "1.0"
+
+// serializableBucketer is the JSON form of a Bucketer's bucket assignments
+type serializableBucketer struct {
+	Version       string         `json:"version"`
+	Board         string         `json:"board"`
+	OpponentRange []string       `json:"opponent_range"`
+	EquityBins    int            `json:"equity_bins"`
+	PotentialBins int            `json:"potential_bins"`
+	Sampling      bool           `json:"sampling,omitempty"`
+	Samples       int            `json:"samples,omitempty"`
+	Buckets       map[string]int `json:"buckets"`
+}
+
+// Export serializes the hand→bucket assignments computed so far, plus the grid
+// dimensions and the board/opponent range they were computed against
+func (b *Bucketer) Export() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var board strings.Builder
+	for _, card := range b.board {
+		board.WriteString(card.String())
+	}
+
+	oppRange := make([]string, len(b.opponentRange))
+	for i, combo := range b.opponentRange {
+		oppRange[i] = combo.String()
+	}
+
+	buckets := make(map[string]int, len(b.cache))
+	for hand, bucket := range b.cache {
+		buckets[hand] = bucket
+	}
+
+	return json.MarshalIndent(serializableBucketer{
+		Version:       bucketerFormatVersion,
+		Board:         board.String(),
+		OpponentRange: oppRange,
+		EquityBins:    b.equityBins,
+		PotentialBins: b.potentialBins,
+		Sampling:      b.useSampling,
+		Samples:       b.samples,
+		Buckets:       buckets,
+	}, "", "  ")
+}
+
+// ImportBucketer restores a bucketer from Export output
+// Imported assignments are never recomputed, so bucket IDs stay identical across
+// versions. Hands missing from the export are computed against the stored board
+// and opponent range, as the original bucketer would.
+func ImportBucketer(data []byte) (*Bucketer, error) {
+	var sb serializableBucketer
+	if err := json.Unmarshal(data, &sb); err != nil {
+		return nil, fmt.Errorf("error decoding bucketer: %w", err)
+	}
+
+	if sb.Version != bucketerFormatVersion {
+		return nil, fmt.Errorf("unsupported bucketer version %q", sb.Version)
+	}
+	if sb.EquityBins <= 0 || sb.PotentialBins <= 0 {
+		return nil, fmt.Errorf("invalid grid dimensions %dx%d", sb.EquityBins, sb.PotentialBins)
+	}
+
+	var board []cards.Card
+	if sb.Board != "" {
+		var err error
+		board, err = cards.ParseCards(sb.Board)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing board: %w", err)
+		}
+	}
+
+	oppRange := make([]notation.Combo, len(sb.OpponentRange))
+	for i, comboStr := range sb.OpponentRange {
+		holeCards, err := cards.ParseCards(comboStr)
+		if err != nil || len(holeCards) != 2 {
+			return nil, fmt.Errorf("invalid opponent combo %q", comboStr)
+		}
+		oppRange[i] = notation.Combo{Card1: holeCards[0], Card2: holeCards[1]}
+	}
+
+	numBuckets := sb.EquityBins * sb.PotentialBins
+	for hand, bucket := range sb.Buckets {
+		if bucket < 0 || bucket >= numBuckets {
+			return nil, fmt.Errorf("hand %s: bucket %d out of range", hand, bucket)
+		}
+	}
+
+	b := NewBucketer(board, oppRange, numBuckets)
+	b.equityBins = sb.EquityBins
+	b.potentialBins = sb.PotentialBins
+	b.useSampling = sb.Sampling
+	b.samples = sb.Samples
+	if sb.Buckets != nil {
+		b.cache = sb.Buckets
+	}
+
+	return b, nil
+}
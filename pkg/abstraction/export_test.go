@@ -0,0 +1,71 @@
+package abstraction
+
+import (
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestBucketer_ExportImport(t *testing.T) {
+	board, _ := cards.ParseCards("Th9h2c")
+	oppRange, _ := notation.ParseRange("QQ,JJ")
+
+	original := NewBucketerSampled(board, oppRange, 50, 50)
+
+	testHands := []string{"AdAc", "KdKc", "AhKh", "7d2c", "Jh8h", "9d9c", "Ts9s"}
+	want := make(map[string]int)
+	for _, handStr := range testHands {
+		hand, _ := cards.ParseCards(handStr)
+		want[handStr] = original.BucketHand(hand)
+	}
+
+	data, err := original.Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	imported, err := ImportBucketer(data)
+	if err != nil {
+		t.Fatalf("ImportBucketer failed: %v", err)
+	}
+
+	if imported.NumBuckets() != original.NumBuckets() {
+		t.Errorf("NumBuckets: imported %d, original %d", imported.NumBuckets(), original.NumBuckets())
+	}
+
+	for _, handStr := range testHands {
+		hand, _ := cards.ParseCards(handStr)
+		if got := imported.BucketHand(hand); got != want[handStr] {
+			t.Errorf("%s: imported bucket %d, original %d", handStr, got, want[handStr])
+		}
+	}
+
+	// Hands that weren't exported are computed the same way as the original
+	hand, _ := cards.ParseCards("QhJh")
+	if got, exp := imported.BucketHand(hand), original.BucketHand(hand); got != exp {
+		t.Errorf("QhJh: imported bucket %d, original %d", got, exp)
+	}
+}
+
+func TestImportBucketer_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", "buckets"},
+		{"bad version", `{"version":"0.1","equity_bins":2,"potential_bins":2}`},
+		{"bad grid", `{"version":"1.0","equity_bins":0,"potential_bins":2}`},
+		{"bad board", `{"version":"1.0","board":"Xx","equity_bins":2,"potential_bins":2}`},
+		{"bad combo", `{"version":"1.0","opponent_range":["As"],"equity_bins":2,"potential_bins":2}`},
+		{"bucket out of range", `{"version":"1.0","equity_bins":2,"potential_bins":2,"buckets":{"AsAd":4}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportBucketer([]byte(tt.data)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
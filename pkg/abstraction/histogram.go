@@ -0,0 +1,212 @@
+package abstraction
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// HistogramBucketer assigns turn hands to buckets by their river equity distribution
+// Each hand's features are a histogram of its equity against the opponent range
+// over every river card, clustered by k-means under earth mover's distance. Hands
+// whose equity evolves alike (a flush draw that either gets there or misses)
+// share buckets even when a made hand has the same average equity.
+// Safe for concurrent use.
+type HistogramBucketer struct {
+	board         []cards.Card
+	opponentRange []notation.Combo
+	riverBins     int
+	evaluator     cards.Evaluator
+
+	// rivers are the undealt cards; oppValues[r][i] is opponent combo i's hand
+	// on river r, and oppLive[r][i] whether the combo is possible there
+	rivers    []cards.Card
+	oppValues [][]cards.HandValue
+	oppLive   [][]bool
+
+	centroids [][]float64
+	cache     map[string]int
+
+	mu sync.Mutex
+}
+
+// NewHistogramBucketer clusters every hero combo that doesn't conflict with the
+// turn board into numBuckets buckets. Equity on each river is binned into
+// riverBins equal-width bins (10 if riverBins <= 0), with hands scored by
+// evaluator (nil = cards.DefaultEvaluator). Clustering is deterministic for a
+// given board and opponent range.
+func NewHistogramBucketer(board []cards.Card, opponentRange []notation.Combo, numBuckets int, riverBins int, evaluator cards.Evaluator) (*HistogramBucketer, error) {
+	if len(board) != 4 {
+		return nil, fmt.Errorf("histogram bucketing needs a turn board, got %d cards", len(board))
+	}
+	if riverBins <= 0 {
+		riverBins = 10
+	}
+	if evaluator == nil {
+		evaluator = cards.DefaultEvaluator
+	}
+
+	hb := &HistogramBucketer{
+		board:         board,
+		opponentRange: opponentRange,
+		riverBins:     riverBins,
+		evaluator:     evaluator,
+		cache:         make(map[string]int),
+	}
+
+	// Opponent hands depend only on the river, so evaluate each once
+	deck := cards.NewDeck()
+	deck.Remove(board...)
+	hb.rivers = deck.Cards()
+	for _, river := range hb.rivers {
+		runout := append(append([]cards.Card{}, board...), river)
+		values := make([]cards.HandValue, len(opponentRange))
+		live := make([]bool, len(opponentRange))
+		for i, combo := range opponentRange {
+			if conflictsWithBoard(combo.Cards(), runout) {
+				continue
+			}
+			live[i] = true
+			values[i] = evaluator.Evaluate(combo.Cards(), runout)
+		}
+		hb.oppValues = append(hb.oppValues, values)
+		hb.oppLive = append(hb.oppLive, live)
+	}
+
+	var keys []string
+	var points [][]float64
+	for _, combo := range allCombos() {
+		hero := combo.Cards()
+		if conflictsWithBoard(hero, board) {
+			continue
+		}
+		keys = append(keys, handKey(hero))
+		points = append(points, hb.equityHistogram(hero))
+	}
+
+	seed := deterministicSeed(nil, board, hashRange(opponentRange))
+	var assignments []int
+	hb.centroids, assignments = histogramKMeans(points, numBuckets, rand.New(rand.NewSource(seed)))
+
+	for i, key := range keys {
+		hb.cache[key] = assignments[i]
+	}
+
+	return hb, nil
+}
+
+// BucketHand assigns a hand to a bucket ID (0 to NumBuckets()-1)
+// Hands outside the clustered set are assigned to the nearest centroid.
+func (hb *HistogramBucketer) BucketHand(hero []cards.Card) int {
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+
+	key := handKey(hero)
+	if bucket, exists := hb.cache[key]; exists {
+		return bucket
+	}
+
+	bucket := nearest(hb.equityHistogram(hero), hb.centroids, emd)
+	hb.cache[key] = bucket
+	return bucket
+}
+
+// BucketCombo is a convenience wrapper for notation.Combo
+func (hb *HistogramBucketer) BucketCombo(combo notation.Combo) int {
+	return hb.BucketHand(combo.Cards())
+}
+
+// NumBuckets returns the number of clusters
+// This can be less than requested when there are fewer distinct hands than buckets.
+func (hb *HistogramBucketer) NumBuckets() int {
+	return len(hb.centroids)
+}
+
+// Centroid returns the mean river equity histogram of a bucket
+func (hb *HistogramBucketer) Centroid(bucketID int) []float64 {
+	return append([]float64(nil), hb.centroids[bucketID]...)
+}
+
+// equityHistogram returns the fraction of rivers on which hero's equity against
+// the opponent range falls in each bin
+func (hb *HistogramBucketer) equityHistogram(hero []cards.Card) []float64 {
+	hist := make([]float64, hb.riverBins)
+	n := 0
+	for r, river := range hb.rivers {
+		if river == hero[0] || river == hero[1] {
+			continue
+		}
+
+		runout := append(append([]cards.Card{}, hb.board...), river)
+		heroValue := hb.evaluator.Evaluate(hero, runout)
+		wins, ties, total := 0.0, 0.0, 0.0
+		for i, combo := range hb.opponentRange {
+			if !hb.oppLive[r][i] || combo.Card1 == hero[0] || combo.Card1 == hero[1] ||
+				combo.Card2 == hero[0] || combo.Card2 == hero[1] {
+				continue
+			}
+			switch heroValue.Compare(hb.oppValues[r][i]) {
+			case 1:
+				wins++
+			case 0:
+				ties++
+			}
+			total++
+		}
+		if total == 0 {
+			continue
+		}
+
+		eq := (wins + ties/2) / total
+		bin := int(eq * float64(hb.riverBins))
+		if bin >= hb.riverBins {
+			bin = hb.riverBins - 1
+		}
+		hist[bin]++
+		n++
+	}
+
+	if n > 0 {
+		for i := range hist {
+			hist[i] /= float64(n)
+		}
+	}
+	return hist
+}
+
+// emd is the earth mover's distance between two histograms over the same bins
+// In one dimension it is the L1 distance between the cumulative distributions,
+// scaled by the bin width so it lies in [0, 1].
+func emd(a, b []float64) float64 {
+	dist, carry := 0.0, 0.0
+	for i := range a {
+		carry += a[i] - b[i]
+		dist += math.Abs(carry)
+	}
+	return dist / float64(len(a))
+}
+
+// histogramKMeans clusters histograms into at most k clusters under EMD, with
+// bin-wise mean centroids
+// Returns the centroids and each point's cluster index
+func histogramKMeans(points [][]float64, k int, rng *rand.Rand) ([][]float64, []int) {
+	return lloydKMeans(points, k, rng, emd, meanHistogram)
+}
+
+// meanHistogram returns the bin-wise mean of histograms over the same bins
+func meanHistogram(points [][]float64) []float64 {
+	mean := make([]float64, len(points[0]))
+	for _, p := range points {
+		for bin, v := range p {
+			mean[bin] += v
+		}
+	}
+	for bin := range mean {
+		mean[bin] /= float64(len(points))
+	}
+	return mean
+}
@@ -0,0 +1,111 @@
+package abstraction
+
+import (
+	"math"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestEMD(t *testing.T) {
+	a := []float64{1, 0, 0, 0}
+	if d := emd(a, a); d != 0 {
+		t.Errorf("emd(a, a) = %v, want 0", d)
+	}
+
+	// Moving all mass across every bin boundary costs 3 of 4 bin widths
+	if d := emd(a, []float64{0, 0, 0, 1}); math.Abs(d-0.75) > 1e-12 {
+		t.Errorf("emd to the far bin = %v, want 0.75", d)
+	}
+
+	// Mass one bin away is closer than mass two bins away
+	near := emd(a, []float64{0, 1, 0, 0})
+	far := emd(a, []float64{0, 0, 1, 0})
+	if near >= far {
+		t.Errorf("emd one bin away %v should be less than two bins away %v", near, far)
+	}
+}
+
+func TestHistogramBucketer(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9h4c2d")
+	oppRange, _ := notation.ParseRange("KQo,KJo")
+
+	bucketer, err := NewHistogramBucketer(board, oppRange, 8, 10, nil)
+	if err != nil {
+		t.Fatalf("NewHistogramBucketer() failed: %v", err)
+	}
+	if bucketer.NumBuckets() != 8 {
+		t.Fatalf("NumBuckets() = %d, want 8", bucketer.NumBuckets())
+	}
+
+	bucketOf := func(s string) int {
+		hand, _ := cards.ParseCards(s)
+		return bucketer.BucketHand(hand)
+	}
+
+	// Flush draws either get there (a win) or miss (a loss) on the river
+	if bucketOf("7h6h") != bucketOf("8h6h") {
+		t.Errorf("7h6h and 8h6h should share a bucket: %d vs %d", bucketOf("7h6h"), bucketOf("8h6h"))
+	}
+
+	// An overpair wins on almost every river
+	if bucketOf("AsAd") == bucketOf("7h6h") {
+		t.Error("overpair and flush draw should not share a bucket")
+	}
+
+	// Card order doesn't matter
+	if bucketOf("7h6h") != bucketOf("6h7h") {
+		t.Error("bucket depends on card order")
+	}
+
+	// Histograms are distributions over rivers
+	hand, _ := cards.ParseCards("7h6h")
+	sum := 0.0
+	for _, v := range bucketer.equityHistogram(hand) {
+		sum += v
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("histogram sums to %v, want 1", sum)
+	}
+
+	flop, _ := cards.ParseCards("Kh9h4c")
+	if _, err := NewHistogramBucketer(flop, oppRange, 8, 10, nil); err == nil {
+		t.Error("expected an error for a flop board")
+	}
+}
+
+// lowCardEvaluator is a stub where the lowest first hole card wins
+type lowCardEvaluator struct{}
+
+func (lowCardEvaluator) Evaluate(hole []cards.Card, board []cards.Card) cards.HandValue {
+	return cards.HandValue{Rank: cards.HighCard, Values: [5]cards.Rank{cards.Ace - hole[0].Rank}}
+}
+
+func TestBucketers_Evaluator(t *testing.T) {
+	// Aces beat the opponent's kings, but lose every showdown under the stub
+	oppRange, _ := notation.ParseRange("KQo")
+	aces, _ := cards.ParseCards("AsAd")
+
+	river, _ := cards.ParseCards("Th9h4c2d7s")
+	for _, sampled := range []bool{false, true} {
+		b := NewBucketer(river, oppRange, 100)
+		if sampled {
+			b = NewBucketerSampled(river, oppRange, 100, 10)
+		}
+		top := b.BucketHand(aces)
+		b.SetEvaluator(lowCardEvaluator{})
+		if bottom := b.BucketHand(aces); bottom >= top || bottom >= b.potentialBins {
+			t.Errorf("sampled=%v: aces bucket %d under the stub, want the lowest equity bin (default evaluator gave %d)", sampled, bottom, top)
+		}
+	}
+
+	turn, _ := cards.ParseCards("Th9h4c2d")
+	hb, err := NewHistogramBucketer(turn, oppRange, 4, 10, lowCardEvaluator{})
+	if err != nil {
+		t.Fatalf("NewHistogramBucketer() failed: %v", err)
+	}
+	if hist := hb.equityHistogram(aces); hist[0] != 1 {
+		t.Errorf("aces histogram under the stub = %v, want every river in the lowest bin", hist)
+	}
+}
@@ -0,0 +1,288 @@
+package abstraction
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// kmeansMaxIterations bounds Lloyd's algorithm if assignments keep changing
+const kmeansMaxIterations = 100
+
+// KMeansBucketer assigns hands to buckets by k-means clustering in equity/potential space
+// Unlike the fixed grid in Bucketer, bucket boundaries follow the actual distribution
+// of hands on the board, so no buckets are wasted on empty regions.
+// Safe for concurrent use.
+type KMeansBucketer struct {
+	board         []cards.Card
+	opponentRange []notation.Combo
+
+	// features computes sampled equity/potential for hands
+	features *Bucketer
+
+	centroids []eqPot
+	cache     map[string]int
+
+	mu sync.Mutex
+}
+
+// NewKMeansBucketer clusters every hero combo that doesn't conflict with the board
+// into numBuckets buckets. Equity and potential are estimated with `samples`
+// Monte Carlo runouts per hand (200 if samples <= 0). Clustering is deterministic
+// for a given board and opponent range.
+func NewKMeansBucketer(board []cards.Card, opponentRange []notation.Combo, numBuckets int, samples int) *KMeansBucketer {
+	kb := &KMeansBucketer{
+		board:         board,
+		opponentRange: opponentRange,
+		features:      NewBucketerSampled(board, opponentRange, numBuckets, samples),
+		cache:         make(map[string]int),
+	}
+
+	// Compute features for every hero combo of interest
+	var keys []string
+	var points []eqPot
+	for _, combo := range allCombos() {
+		hero := combo.Cards()
+		if conflictsWithBoard(hero, board) {
+			continue
+		}
+		keys = append(keys, handKey(hero))
+		points = append(points, kb.featuresFor(hero))
+	}
+
+	seed := deterministicSeed(nil, board, kb.features.oppHash)
+	var assignments []int
+	kb.centroids, assignments = kmeans(points, numBuckets, rand.New(rand.NewSource(seed)))
+
+	for i, key := range keys {
+		kb.cache[key] = assignments[i]
+	}
+
+	return kb
+}
+
+// BucketHand assigns a hand to a bucket ID (0 to NumBuckets()-1)
+// Hands outside the clustered set are assigned to the nearest centroid.
+func (kb *KMeansBucketer) BucketHand(hero []cards.Card) int {
+	kb.mu.Lock()
+	defer kb.mu.Unlock()
+
+	key := handKey(hero)
+	if bucket, exists := kb.cache[key]; exists {
+		return bucket
+	}
+
+	bucket := nearestCentroid(kb.featuresFor(hero), kb.centroids)
+	kb.cache[key] = bucket
+	return bucket
+}
+
+// BucketCombo is a convenience wrapper for notation.Combo
+func (kb *KMeansBucketer) BucketCombo(combo notation.Combo) int {
+	return kb.BucketHand(combo.Cards())
+}
+
+// NumBuckets returns the number of clusters
+// This can be less than requested when there are fewer distinct hands than buckets.
+func (kb *KMeansBucketer) NumBuckets() int {
+	return len(kb.centroids)
+}
+
+// Centroid returns the equity and potential at the center of a bucket
+func (kb *KMeansBucketer) Centroid(bucketID int) (equity, potential float64) {
+	c := kb.centroids[bucketID]
+	return c.equity, c.potential
+}
+
+// featuresFor returns sampled equity/potential for a hand in canonical card order
+// so both orderings of the same hole cards get identical features
+func (kb *KMeansBucketer) featuresFor(hero []cards.Card) eqPot {
+	ordered := orderedHand(hero)
+	eq, pot := kb.features.sampleEquityPotential(ordered)
+	return eqPot{equity: eq, potential: pot}
+}
+
+// kmeans clusters equity/potential points into at most k clusters by Euclidean distance
+// Returns the centroids and each point's cluster index
+func kmeans(points []eqPot, k int, rng *rand.Rand) ([]eqPot, []int) {
+	return lloydKMeans(points, k, rng, eqPotDist, meanEqPot)
+}
+
+// nearestCentroid returns the index of the centroid closest to p
+func nearestCentroid(p eqPot, centroids []eqPot) int {
+	return nearest(p, centroids, eqPotDist)
+}
+
+// eqPotDist is the Euclidean distance in equity/potential space
+func eqPotDist(a, b eqPot) float64 {
+	return math.Sqrt(sqDist(a, b))
+}
+
+// meanEqPot returns the mean equity and potential of points
+func meanEqPot(points []eqPot) eqPot {
+	var sum eqPot
+	for _, p := range points {
+		sum.equity += p.equity
+		sum.potential += p.potential
+	}
+	n := float64(len(points))
+	return eqPot{equity: sum.equity / n, potential: sum.potential / n}
+}
+
+// sqDist is the squared Euclidean distance in equity/potential space
+func sqDist(a, b eqPot) float64 {
+	de := a.equity - b.equity
+	dp := a.potential - b.potential
+	return de*de + dp*dp
+}
+
+// lloydKMeans clusters points into at most k clusters using k-means++ seeding
+// and Lloyd's algorithm under dist, with each centroid the mean of its points
+// Returns the centroids and each point's cluster index
+func lloydKMeans[P any](points []P, k int, rng *rand.Rand, dist func(a, b P) float64, mean func(points []P) P) ([]P, []int) {
+	if k > len(points) {
+		k = len(points)
+	}
+	if k <= 0 {
+		return nil, make([]int, len(points))
+	}
+
+	centroids := seedCentroids(points, k, rng, dist)
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = -1
+	}
+
+	for iter := 0; iter < kmeansMaxIterations; iter++ {
+		// Assignment step
+		changed := false
+		for i, p := range points {
+			c := nearest(p, centroids, dist)
+			if c != assignments[i] {
+				assignments[i] = c
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		// Update step
+		members := make([][]P, k)
+		for i, p := range points {
+			members[assignments[i]] = append(members[assignments[i]], p)
+		}
+		for c := range centroids {
+			if len(members[c]) == 0 {
+				// Re-seed an empty cluster at the point farthest from its centroid
+				centroids[c] = farthestPoint(points, assignments, centroids, dist)
+				continue
+			}
+			centroids[c] = mean(members[c])
+		}
+	}
+
+	return centroids, assignments
+}
+
+// seedCentroids picks k initial centroids with k-means++ (D² weighting)
+func seedCentroids[P any](points []P, k int, rng *rand.Rand, dist func(a, b P) float64) []P {
+	centroids := []P{points[rng.Intn(len(points))]}
+
+	weights := make([]float64, len(points))
+	for len(centroids) < k {
+		total := 0.0
+		for i, p := range points {
+			d := dist(p, centroids[nearest(p, centroids, dist)])
+			weights[i] = d * d
+			total += weights[i]
+		}
+
+		// All remaining points coincide with a centroid; duplicate to fill k
+		if total == 0 {
+			centroids = append(centroids, points[rng.Intn(len(points))])
+			continue
+		}
+
+		r := rng.Float64() * total
+		next := len(points) - 1
+		for i, w := range weights {
+			r -= w
+			if r <= 0 {
+				next = i
+				break
+			}
+		}
+		centroids = append(centroids, points[next])
+	}
+
+	return centroids
+}
+
+// nearest returns the index of the centroid closest to p
+func nearest[P any](p P, centroids []P, dist func(a, b P) float64) int {
+	best := 0
+	bestDist := math.Inf(1)
+	for c, centroid := range centroids {
+		if d := dist(p, centroid); d < bestDist {
+			best = c
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// farthestPoint returns the point farthest from its assigned centroid
+func farthestPoint[P any](points []P, assignments []int, centroids []P, dist func(a, b P) float64) P {
+	best := points[0]
+	bestDist := -1.0
+	for i, p := range points {
+		if d := dist(p, centroids[assignments[i]]); d > bestDist {
+			best = p
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// allCombos enumerates all 1326 two-card combos
+func allCombos() []notation.Combo {
+	deck := cards.NewDeck().Cards()
+	combos := make([]notation.Combo, 0, 1326)
+	for i := 0; i < len(deck); i++ {
+		for j := i + 1; j < len(deck); j++ {
+			combos = append(combos, notation.Combo{Card1: deck[i], Card2: deck[j]})
+		}
+	}
+	return combos
+}
+
+// conflictsWithBoard reports whether any hole card is on the board
+func conflictsWithBoard(hero []cards.Card, board []cards.Card) bool {
+	for _, h := range hero {
+		for _, b := range board {
+			if h == b {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orderedHand returns the two hole cards with the higher card first
+func orderedHand(hero []cards.Card) []cards.Card {
+	a, b := hero[0], hero[1]
+	if b.Rank > a.Rank || (b.Rank == a.Rank && b.Suit < a.Suit) {
+		a, b = b, a
+	}
+	return []cards.Card{a, b}
+}
+
+// handKey is an order-independent cache key for hole cards
+func handKey(hero []cards.Card) string {
+	ordered := orderedHand(hero)
+	return ordered[0].String() + ordered[1].String()
+}
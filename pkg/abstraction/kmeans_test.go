@@ -0,0 +1,122 @@
+package abstraction
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestKMeans_SeparatesClusters(t *testing.T) {
+	// Three well-separated blobs
+	rng := rand.New(rand.NewSource(1))
+	centers := []eqPot{{0.1, 0.1}, {0.5, 0.8}, {0.9, 0.2}}
+	var points []eqPot
+	for _, c := range centers {
+		for i := 0; i < 50; i++ {
+			points = append(points, eqPot{
+				equity:    c.equity + rng.Float64()*0.04 - 0.02,
+				potential: c.potential + rng.Float64()*0.04 - 0.02,
+			})
+		}
+	}
+
+	centroids, assignments := kmeans(points, 3, rand.New(rand.NewSource(7)))
+	if len(centroids) != 3 {
+		t.Fatalf("got %d centroids, want 3", len(centroids))
+	}
+
+	// Each blob maps to exactly one cluster
+	for blob := 0; blob < 3; blob++ {
+		first := assignments[blob*50]
+		for i := blob * 50; i < (blob+1)*50; i++ {
+			if assignments[i] != first {
+				t.Fatalf("blob %d split across clusters %d and %d", blob, first, assignments[i])
+			}
+		}
+	}
+
+	for _, c := range centers {
+		nearest := centroids[nearestCentroid(c, centroids)]
+		if math.Sqrt(sqDist(c, nearest)) > 0.02 {
+			t.Errorf("no centroid near %+v (nearest %+v)", c, nearest)
+		}
+	}
+}
+
+func TestKMeans_MoreClustersThanPoints(t *testing.T) {
+	points := []eqPot{{0.2, 0.2}, {0.8, 0.8}}
+	centroids, assignments := kmeans(points, 10, rand.New(rand.NewSource(1)))
+
+	if len(centroids) != 2 {
+		t.Errorf("got %d centroids, want 2", len(centroids))
+	}
+	if assignments[0] == assignments[1] {
+		t.Error("distinct points should land in different clusters")
+	}
+}
+
+func TestKMeansBucketer(t *testing.T) {
+	board, _ := cards.ParseCards("Th9h2c")
+	oppRange, _ := notation.ParseRange("QQ,AKo")
+
+	const numBuckets = 10
+	bucketer := NewKMeansBucketer(board, oppRange, numBuckets, 20)
+
+	if bucketer.NumBuckets() != numBuckets {
+		t.Fatalf("NumBuckets() = %d, want %d", bucketer.NumBuckets(), numBuckets)
+	}
+
+	// Count non-empty clusters over all clustered hands
+	used := make(map[int]bool)
+	for _, bucket := range bucketer.cache {
+		if bucket < 0 || bucket >= numBuckets {
+			t.Fatalf("bucket %d out of range", bucket)
+		}
+		used[bucket] = true
+	}
+	if len(used) < numBuckets-1 {
+		t.Errorf("only %d of %d clusters are non-empty", len(used), numBuckets)
+	}
+
+	bucketOf := func(s string) int {
+		hand, _ := cards.ParseCards(s)
+		return bucketer.BucketHand(hand)
+	}
+
+	// Suit-isomorphic overpairs have near-identical equity/potential
+	if bucketOf("AsAc") != bucketOf("AdAc") {
+		t.Errorf("AsAc and AdAc should share a bucket: %d vs %d", bucketOf("AsAc"), bucketOf("AdAc"))
+	}
+
+	// Card order doesn't matter
+	if bucketOf("AsAc") != bucketOf("AcAs") {
+		t.Error("bucket depends on card order")
+	}
+
+	// Sets and air are far apart
+	if bucketOf("TdTc") == bucketOf("4d3c") {
+		t.Error("set and air should not share a bucket")
+	}
+
+	combo := notation.Combo{Card1: cards.NewCard(cards.King, cards.Spades), Card2: cards.NewCard(cards.King, cards.Diamonds)}
+	if bucketer.BucketCombo(combo) != bucketOf("KsKd") {
+		t.Error("BucketCombo disagrees with BucketHand")
+	}
+}
+
+func TestKMeansBucketer_Deterministic(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	oppRange, _ := notation.ParseRange("AA,KK,QQ")
+
+	b1 := NewKMeansBucketer(board, oppRange, 8, 20)
+	b2 := NewKMeansBucketer(board, oppRange, 8, 20)
+
+	for key, bucket := range b1.cache {
+		if b2.cache[key] != bucket {
+			t.Fatalf("hand %s: bucket %d vs %d", key, bucket, b2.cache[key])
+		}
+	}
+}
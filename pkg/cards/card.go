@@ -3,6 +3,7 @@ package cards
 import (
 	"fmt"
 	"strings"
+	"unicode"
 )
 
 // Rank represents a card rank (2-A)
@@ -171,6 +172,7 @@ func (s Suit) String() string {
 }
 
 // ParseCards parses multiple cards from a string (e.g., "AsKhQd")
+// Spaces are ignored and repeated cards are allowed; use ParseCardsStrict for user input.
 func ParseCards(s string) ([]Card, error) {
 	s = strings.ReplaceAll(s, " ", "")
 	if len(s)%2 != 0 {
@@ -188,3 +190,25 @@ func ParseCards(s string) ([]Card, error) {
 
 	return cards, nil
 }
+
+// ParseCardsStrict parses cards like ParseCards but rejects whitespace and
+// repeated cards, e.g. "AsKhQd" is accepted while "As Kh" and "AsAs" are errors
+func ParseCardsStrict(s string) ([]Card, error) {
+	if i := strings.IndexFunc(s, unicode.IsSpace); i >= 0 {
+		return nil, fmt.Errorf("invalid cards string: %q (whitespace at position %d)", s, i)
+	}
+
+	parsed, err := ParseCards(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var seen CardMask
+	for i, card := range parsed {
+		if seen.Has(card) {
+			return nil, fmt.Errorf("duplicate card %v at position %d", card, 2*i)
+		}
+		seen = seen.With(card)
+	}
+	return parsed, nil
+}
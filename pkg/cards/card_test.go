@@ -118,6 +118,38 @@ func TestParseCards(t *testing.T) {
 	}
 }
 
+func TestParseCardsStrict(t *testing.T) {
+	got, err := ParseCardsStrict("AhKdQcJsTs2h3c")
+	if err != nil {
+		t.Fatalf("ParseCardsStrict() error = %v", err)
+	}
+	if len(got) != 7 {
+		t.Errorf("got %d cards, want 7", len(got))
+	}
+	if got[6] != (Card{Three, Clubs}) {
+		t.Errorf("last card = %v, want 3c", got[6])
+	}
+
+	errors := []string{
+		"AsKhAs",     // duplicate
+		"AhKdQcJsAh", // duplicate after others
+		"As Kh",      // space
+		"AsKh\t",     // tab
+		"AsK",        // odd length
+		"AsXx",       // invalid card
+	}
+	for _, input := range errors {
+		if _, err := ParseCardsStrict(input); err == nil {
+			t.Errorf("ParseCardsStrict(%q) expected error", input)
+		}
+	}
+
+	// The lenient parser still accepts both
+	if _, err := ParseCards("As Kh As"); err != nil {
+		t.Errorf("ParseCards() should stay lenient, got %v", err)
+	}
+}
+
 func TestRoundTrip(t *testing.T) {
 	// Test that parsing a card and converting back to string gives the same result
 	inputs := []string{"As", "Kh", "Qd", "Jc", "Ts", "9h", "2c"}
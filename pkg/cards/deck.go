@@ -0,0 +1,86 @@
+package cards
+
+import "math/rand"
+
+// Deck is a set of cards that can be dealt at random
+// Used for rollouts and Monte Carlo simulation
+type Deck struct {
+	cards []Card
+}
+
+// NewDeck returns a full 52-card deck
+func NewDeck() *Deck {
+	deck := &Deck{cards: make([]Card, 0, 52)}
+	for rank := Two; rank <= Ace; rank++ {
+		for suit := Spades; suit <= Clubs; suit++ {
+			deck.cards = append(deck.cards, Card{Rank: rank, Suit: suit})
+		}
+	}
+	return deck
+}
+
+// NewShortDeck returns a 36-card short deck (Six through Ace)
+func NewShortDeck() *Deck {
+	deck := &Deck{cards: make([]Card, 0, 36)}
+	for rank := Six; rank <= Ace; rank++ {
+		for suit := Spades; suit <= Clubs; suit++ {
+			deck.cards = append(deck.cards, Card{Rank: rank, Suit: suit})
+		}
+	}
+	return deck
+}
+
+// Remove takes the given cards out of the deck (e.g., hole cards and board)
+// Cards not in the deck are ignored
+func (d *Deck) Remove(cards ...Card) {
+	if len(cards) == 0 {
+		return
+	}
+
+	remove := NewCardMask(cards...)
+
+	kept := d.cards[:0]
+	for _, c := range d.cards {
+		if !remove.Has(c) {
+			kept = append(kept, c)
+		}
+	}
+	d.cards = kept
+}
+
+// Deal draws n distinct cards at random and removes them from the deck
+// Panics if the deck has fewer than n cards
+func (d *Deck) Deal(rng *rand.Rand, n int) []Card {
+	if n > len(d.cards) {
+		panic("Deal requires at least n cards in the deck")
+	}
+
+	// Partial Fisher-Yates: move each drawn card to the end of the deck
+	last := len(d.cards) - 1
+	for i := 0; i < n; i++ {
+		j := rng.Intn(last - i + 1)
+		d.cards[j], d.cards[last-i] = d.cards[last-i], d.cards[j]
+	}
+
+	dealt := make([]Card, n)
+	copy(dealt, d.cards[len(d.cards)-n:])
+	d.cards = d.cards[:len(d.cards)-n]
+
+	return dealt
+}
+
+// Len returns the number of cards left in the deck
+func (d *Deck) Len() int {
+	return len(d.cards)
+}
+
+// Cards returns a copy of the cards left in the deck
+func (d *Deck) Cards() []Card {
+	return append([]Card{}, d.cards...)
+}
+
+// Clone returns an independent copy of the deck
+// Useful for dealing many runouts from the same starting deck
+func (d *Deck) Clone() *Deck {
+	return &Deck{cards: d.Cards()}
+}
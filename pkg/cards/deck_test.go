@@ -0,0 +1,126 @@
+package cards
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewDeck(t *testing.T) {
+	deck := NewDeck()
+
+	if deck.Len() != 52 {
+		t.Fatalf("expected 52 cards, got %d", deck.Len())
+	}
+
+	seen := make(map[Card]bool)
+	for _, c := range deck.Cards() {
+		if seen[c] {
+			t.Errorf("duplicate card %v", c)
+		}
+		if c.Rank > Ace || c.Suit > Clubs {
+			t.Errorf("invalid card %v", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestNewShortDeck(t *testing.T) {
+	deck := NewShortDeck()
+
+	if deck.Len() != 36 {
+		t.Fatalf("expected 36 cards, got %d", deck.Len())
+	}
+	for _, c := range deck.Cards() {
+		if c.Rank < Six {
+			t.Errorf("short deck has %v", c)
+		}
+	}
+}
+
+func TestDeck_Remove(t *testing.T) {
+	deck := NewDeck()
+	removed, _ := ParseCards("AsKhTd2c")
+
+	deck.Remove(removed...)
+	if deck.Len() != 48 {
+		t.Errorf("expected 48 cards after removing 4, got %d", deck.Len())
+	}
+
+	for _, c := range deck.Cards() {
+		for _, r := range removed {
+			if c == r {
+				t.Errorf("removed card %v still in deck", c)
+			}
+		}
+	}
+
+	// Removing cards that are already gone is a no-op
+	deck.Remove(removed[0])
+	if deck.Len() != 48 {
+		t.Errorf("expected 48 cards after re-removing, got %d", deck.Len())
+	}
+}
+
+func TestDeck_Deal(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	removed, _ := ParseCards("AsKhTd2c9s")
+
+	for trial := 0; trial < 100; trial++ {
+		deck := NewDeck()
+		deck.Remove(removed...)
+
+		dealt := deck.Deal(rng, 5)
+		if len(dealt) != 5 {
+			t.Fatalf("expected 5 cards, got %d", len(dealt))
+		}
+		if deck.Len() != 42 {
+			t.Fatalf("expected 42 cards left after dealing, got %d", deck.Len())
+		}
+
+		seen := make(map[Card]bool)
+		for _, c := range dealt {
+			if seen[c] {
+				t.Errorf("dealt duplicate card %v", c)
+			}
+			seen[c] = true
+			for _, r := range removed {
+				if c == r {
+					t.Errorf("dealt removed card %v", c)
+				}
+			}
+		}
+
+		// Dealt cards are no longer in the deck
+		for _, c := range deck.Cards() {
+			if seen[c] {
+				t.Errorf("dealt card %v still in deck", c)
+			}
+		}
+	}
+}
+
+func TestDeck_DealAll(t *testing.T) {
+	deck := NewDeck()
+	dealt := deck.Deal(rand.New(rand.NewSource(1)), 52)
+
+	seen := make(map[Card]bool)
+	for _, c := range dealt {
+		seen[c] = true
+	}
+	if len(seen) != 52 || deck.Len() != 0 {
+		t.Errorf("expected all 52 distinct cards dealt, got %d (deck left %d)", len(seen), deck.Len())
+	}
+}
+
+func TestDeck_Clone(t *testing.T) {
+	deck := NewDeck()
+	clone := deck.Clone()
+	clone.Deal(rand.New(rand.NewSource(1)), 10)
+
+	if deck.Len() != 52 {
+		t.Errorf("dealing from clone changed original: %d cards", deck.Len())
+	}
+	if clone.Len() != 42 {
+		t.Errorf("expected clone to have 42 cards, got %d", clone.Len())
+	}
+}
@@ -0,0 +1,67 @@
+package cards
+
+// Evaluator scores a player's hand from their hole cards and the board
+// Implementations encode the game's hand-forming rules, so tree building and
+// equity code can support variants without changing their showdown logic.
+// Hole cards come from the player's combo, so a four-card range (e.g. "AsAhKsKh")
+// with OmahaEvaluator solves PLO; an evaluator that also implements DeckDealer
+// (ShortDeckEvaluator) sets the deck runouts are dealt from.
+type Evaluator interface {
+	Evaluate(hole []Card, board []Card) HandValue
+}
+
+// DeckDealer is implemented by evaluators for games dealt from a non-standard deck
+type DeckDealer interface {
+	NewDeck() *Deck
+}
+
+// NewDeckFor returns a full deck for the evaluator's game
+// Evaluators that don't implement DeckDealer (and nil) get the 52-card deck.
+func NewDeckFor(evaluator Evaluator) *Deck {
+	if dealer, ok := evaluator.(DeckDealer); ok {
+		return dealer.NewDeck()
+	}
+	return NewDeck()
+}
+
+// HoldemEvaluator plays the best five of the hole and board cards (5-7 total)
+type HoldemEvaluator struct{}
+
+// Evaluate returns the best Hold'em hand
+// Seven cards go through Evaluate, which skips flush checks when it can.
+func (HoldemEvaluator) Evaluate(hole []Card, board []Card) HandValue {
+	all := append(append(make([]Card, 0, len(hole)+len(board)), hole...), board...)
+	if len(all) == 7 {
+		return Evaluate(all)
+	}
+	return EvaluateBest(all)
+}
+
+// OmahaEvaluator uses exactly two of four hole cards and three board cards
+type OmahaEvaluator struct{}
+
+// Evaluate returns the best Omaha hand
+func (OmahaEvaluator) Evaluate(hole []Card, board []Card) HandValue {
+	return EvaluateOmaha(hole, board)
+}
+
+// ShortDeckEvaluator plays short-deck (6+) Hold'em from the 36-card deck
+// Its HandValues rank a flush above a full house and A-6-7-8-9 as the lowest
+// straight; compare them only with each other.
+type ShortDeckEvaluator struct{}
+
+// Evaluate returns the best short-deck hand
+func (ShortDeckEvaluator) Evaluate(hole []Card, board []Card) HandValue {
+	all := append(append(make([]Card, 0, len(hole)+len(board)), hole...), board...)
+	value := EvaluateShortDeck(all).HandValue
+	value.shortDeck = true
+	return value
+}
+
+// NewDeck returns the 36-card short deck
+func (ShortDeckEvaluator) NewDeck() *Deck {
+	return NewShortDeck()
+}
+
+// DefaultEvaluator is the standard Hold'em evaluator used when none is configured
+var DefaultEvaluator Evaluator = HoldemEvaluator{}
@@ -0,0 +1,38 @@
+package cards
+
+import "testing"
+
+func TestEvaluators(t *testing.T) {
+	hole, _ := ParseCards("AhKh")
+	board, _ := ParseCards("2h5h8hTs3s")
+
+	if got, want := (HoldemEvaluator{}).Evaluate(hole, board), Evaluate(append(append([]Card{}, hole...), board...)); got != want {
+		t.Errorf("HoldemEvaluator = %v, want %v", got, want)
+	}
+	if got := DefaultEvaluator.Evaluate(hole, board[:3]); got.Rank != Flush {
+		t.Errorf("DefaultEvaluator on flop = %v, want flush", got.Rank)
+	}
+
+	omahaHole, _ := ParseCards("AhKsQdJc")
+	if got, want := (OmahaEvaluator{}).Evaluate(omahaHole, board), EvaluateOmaha(omahaHole, board); got != want {
+		t.Errorf("OmahaEvaluator = %v, want %v", got, want)
+	}
+
+	// Short deck: a flush beats a full house, and the 36-card deck is dealt
+	flushHole, _ := ParseCards("AhKh")
+	boatHole, _ := ParseCards("TsTd")
+	shortBoard, _ := ParseCards("6h9hTh6s7c")
+	short := ShortDeckEvaluator{}
+	if got := short.Evaluate(flushHole, shortBoard).Compare(short.Evaluate(boatHole, shortBoard)); got != 1 {
+		t.Errorf("short-deck flush vs full house = %d, want 1", got)
+	}
+	if got := DefaultEvaluator.Evaluate(flushHole, shortBoard).Compare(DefaultEvaluator.Evaluate(boatHole, shortBoard)); got != -1 {
+		t.Errorf("Hold'em flush vs full house = %d, want -1", got)
+	}
+	if got := NewDeckFor(short).Len(); got != 36 {
+		t.Errorf("NewDeckFor(ShortDeckEvaluator) has %d cards, want 36", got)
+	}
+	if got := NewDeckFor(OmahaEvaluator{}).Len(); got != 52 {
+		t.Errorf("NewDeckFor(OmahaEvaluator) has %d cards, want 52", got)
+	}
+}
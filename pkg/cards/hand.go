@@ -24,12 +24,21 @@ const (
 type HandValue struct {
 	Rank   HandRank
 	Values [5]Rank // Tiebreaker values (e.g., trip rank, kicker ranks)
+
+	// shortDeck marks values from ShortDeckEvaluator, which Compare ranks
+	// with a flush above a full house
+	shortDeck bool
 }
 
 // Compare returns -1 if h < other, 0 if equal, 1 if h > other
+// Values from ShortDeckEvaluator compare under short-deck category order.
 func (h HandValue) Compare(other HandValue) int {
-	if h.Rank != other.Rank {
-		if h.Rank < other.Rank {
+	hs, otherStrength := int(h.Rank), int(other.Rank)
+	if h.shortDeck {
+		hs, otherStrength = shortDeckStrength(h.Rank), shortDeckStrength(other.Rank)
+	}
+	if hs != otherStrength {
+		if hs < otherStrength {
 			return -1
 		}
 		return 1
@@ -49,21 +58,54 @@ func (h HandValue) Compare(other HandValue) int {
 }
 
 // Evaluate returns the best possible 5-card hand from 7 cards
+// When no suit appears five times no combination can be a flush, so the
+// flush checks are skipped for all 21 combinations.
 func Evaluate(cards []Card) HandValue {
 	if len(cards) != 7 {
 		panic("Evaluate requires exactly 7 cards")
 	}
 
-	// Check all 21 possible 5-card combinations and return the best
+	var suitCounts [4]int
+	flushPossible := false
+	for _, card := range cards {
+		suitCounts[card.Suit]++
+		if suitCounts[card.Suit] >= 5 {
+			flushPossible = true
+		}
+	}
+
+	return bestHand(cards, flushPossible)
+}
+
+// EvaluateBest returns the best possible 5-card hand from 5 to 7 cards
+// Used for in-progress boards (hole cards plus a flop or turn)
+func EvaluateBest(cards []Card) HandValue {
+	if len(cards) < 5 || len(cards) > 7 {
+		panic("EvaluateBest requires 5 to 7 cards")
+	}
+
+	return bestHand(cards, true)
+}
+
+// EvaluateOmaha returns the best Omaha hand from 4 hole cards and a 3-5 card board
+// Omaha hands must use exactly two hole cards and exactly three board cards
+func EvaluateOmaha(hole []Card, board []Card) HandValue {
+	if len(hole) != 4 {
+		panic("EvaluateOmaha requires exactly 4 hole cards")
+	}
+	if len(board) < 3 || len(board) > 5 {
+		panic("EvaluateOmaha requires 3 to 5 board cards")
+	}
+
 	best := HandValue{Rank: HighCard}
 
-	// Generate all combinations of 5 cards from 7
-	for i := 0; i < 7; i++ {
-		for j := i + 1; j < 7; j++ {
-			for k := j + 1; k < 7; k++ {
-				for l := k + 1; l < 7; l++ {
-					for m := l + 1; m < 7; m++ {
-						hand := []Card{cards[i], cards[j], cards[k], cards[l], cards[m]}
+	// 6 hole pairs × up to 10 board triples
+	for i := 0; i < len(hole); i++ {
+		for j := i + 1; j < len(hole); j++ {
+			for k := 0; k < len(board); k++ {
+				for l := k + 1; l < len(board); l++ {
+					for m := l + 1; m < len(board); m++ {
+						hand := []Card{hole[i], hole[j], board[k], board[l], board[m]}
 						value := evaluate5Cards(hand)
 						if value.Compare(best) > 0 {
 							best = value
@@ -77,23 +119,54 @@ func Evaluate(cards []Card) HandValue {
 	return best
 }
 
+// bestHand checks every 5-card combination and returns the best
+// flushPossible false skips the flush checks; callers pass it only when no
+// suit has five cards.
+func bestHand(cards []Card, flushPossible bool) HandValue {
+	n := len(cards)
+	best := HandValue{Rank: HighCard}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k := j + 1; k < n; k++ {
+				for l := k + 1; l < n; l++ {
+					for m := l + 1; m < n; m++ {
+						hand := []Card{cards[i], cards[j], cards[k], cards[l], cards[m]}
+						value := evaluate5(hand, flushPossible)
+						if value.Compare(best) > 0 {
+							best = value
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return best
+}
+
 // evaluate5Cards evaluates exactly 5 cards
 func evaluate5Cards(cards []Card) HandValue {
-	// Count ranks and suits
-	rankCounts := make([]int, 13)
-	suitCounts := make([]int, 4)
+	return evaluate5(cards, true)
+}
 
+// evaluate5 evaluates exactly 5 cards, skipping the flush checks when
+// flushPossible is false
+func evaluate5(cards []Card, flushPossible bool) HandValue {
+	// Count ranks
+	rankCounts := make([]int, 13)
 	for _, card := range cards {
 		rankCounts[card.Rank]++
-		suitCounts[card.Suit]++
 	}
 
 	// Check for flush
-	isFlush := false
-	for _, count := range suitCounts {
-		if count == 5 {
-			isFlush = true
-			break
+	isFlush := flushPossible
+	if flushPossible {
+		for _, card := range cards[1:] {
+			if card.Suit != cards[0].Suit {
+				isFlush = false
+				break
+			}
 		}
 	}
 
@@ -265,3 +338,58 @@ func (r HandRank) String() string {
 		return "Unknown"
 	}
 }
+
+// Describe names the made hand, e.g. "Aces full of Kings" or "King-high flush"
+// It reads Rank and the Values tiebreakers, so it needs a HandValue from the
+// evaluator rather than one built by hand with Values left empty.
+func (h HandValue) Describe() string {
+	v := h.Values
+	switch h.Rank {
+	case StraightFlush:
+		if v[0] == Ace {
+			return "Royal flush"
+		}
+		if v[0] == Five {
+			return "Wheel straight flush"
+		}
+		return rankName(v[0]) + "-high straight flush"
+	case FourOfAKind:
+		return "Four " + rankPlural(v[0])
+	case FullHouse:
+		return rankPlural(v[0]) + " full of " + rankPlural(v[1])
+	case Flush:
+		return rankName(v[0]) + "-high flush"
+	case Straight:
+		if v[0] == Five {
+			return "Wheel straight"
+		}
+		return rankName(v[0]) + "-high straight"
+	case ThreeOfAKind:
+		return "Three " + rankPlural(v[0])
+	case TwoPair:
+		return "Two pair, " + rankPlural(v[0]) + " and " + rankPlural(v[1])
+	case OnePair:
+		return "Pair of " + rankPlural(v[0])
+	case HighCard:
+		return rankName(v[0]) + " high"
+	default:
+		return h.Rank.String()
+	}
+}
+
+// rankName returns a rank's English name ("Ace", "Ten", "Two")
+func rankName(r Rank) string {
+	names := [...]string{"Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"}
+	if int(r) >= len(names) {
+		return r.String()
+	}
+	return names[r]
+}
+
+// rankPlural returns a rank's plural name ("Aces", "Sixes")
+func rankPlural(r Rank) string {
+	if r == Six {
+		return "Sixes"
+	}
+	return rankName(r) + "s"
+}
@@ -65,6 +65,35 @@ func BenchmarkEvaluate(b *testing.B) {
 	}
 }
 
+// BenchmarkEvaluate_NoFlush compares Evaluate on hands with no flush potential
+// against the full evaluation it skips
+func BenchmarkEvaluate_NoFlush(b *testing.B) {
+	hands := [][]Card{
+		mustParseCards("AhKd9s7c5h3d2s"),
+		mustParseCards("AsAhKdQsJh9d7c"),
+		mustParseCards("AhKdQcJsTs2h3c"),
+		mustParseCards("AsAhAdKsKh2d3c"),
+	}
+
+	b.Run("Evaluate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, hand := range hands {
+				_ = Evaluate(hand)
+			}
+		}
+	})
+
+	b.Run("FullEvaluation", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, hand := range hands {
+				_ = bestHand(hand, true)
+			}
+		}
+	})
+}
+
 // BenchmarkCompare benchmarks hand comparison
 func BenchmarkCompare(b *testing.B) {
 	cards1 := mustParseCards("9s8s7s6s5s2h3d")
@@ -106,3 +135,30 @@ func mustParseCards(s string) []Card {
 	}
 	return cards
 }
+
+func BenchmarkCardSet_Map(b *testing.B) {
+	used, _ := ParseCards("AsKhQd7c2s")
+	deck := NewDeck().Cards()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set := make(map[Card]bool, len(used))
+		for _, c := range used {
+			set[c] = true
+		}
+		for _, c := range deck {
+			_ = set[c]
+		}
+	}
+}
+
+func BenchmarkCardSet_Mask(b *testing.B) {
+	used, _ := ParseCards("AsKhQd7c2s")
+	deck := NewDeck().Cards()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set := NewCardMask(used...)
+		for _, c := range deck {
+			_ = set.Has(c)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cards
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -134,6 +135,124 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+// TestEvaluate_NoFlushFastPath checks that skipping the flush checks when no suit
+// has five cards never changes a result
+func TestEvaluate_NoFlushFastPath(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	deck := NewDeck().Cards()
+
+	fast, flushes := 0, 0
+	for n := 0; n < 20000; n++ {
+		rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hand := deck[:7]
+
+		got := Evaluate(hand)
+		want := bestHand(hand, true)
+		if got.Compare(want) != 0 || got.Rank != want.Rank {
+			t.Fatalf("%v: Evaluate %v, full evaluation %v", hand, got, want)
+		}
+
+		var suitCounts [4]int
+		for _, c := range hand {
+			suitCounts[c.Suit]++
+		}
+		if suitCounts[0] < 5 && suitCounts[1] < 5 && suitCounts[2] < 5 && suitCounts[3] < 5 {
+			fast++
+		}
+		if want.Rank == Flush || want.Rank == StraightFlush {
+			flushes++
+		}
+	}
+
+	// Both paths must have been exercised
+	if fast == 0 || flushes == 0 {
+		t.Errorf("fast path %d hands, flushes %d hands; want both > 0", fast, flushes)
+	}
+}
+
+func TestEvaluateBest(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		wantRank HandRank
+	}{
+		{"Five cards flush", "AhKh9h4h2h", Flush},
+		{"Six cards straight", "AsKd9cQhJsTd", Straight},
+		{"Six cards pair", "AsAd9c7h4s2d", OnePair},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand, err := ParseCards(tt.cards)
+			if err != nil {
+				t.Fatalf("ParseCards(%q) error: %v", tt.cards, err)
+			}
+			got := EvaluateBest(hand)
+			if got.Rank != tt.wantRank {
+				t.Errorf("EvaluateBest(%v) = %v, want %v", tt.name, got.Rank, tt.wantRank)
+			}
+		})
+	}
+
+	// Seven cards matches Evaluate
+	seven, _ := ParseCards("AsKsQsJsTs2d3c")
+	if EvaluateBest(seven) != Evaluate(seven) {
+		t.Error("EvaluateBest should match Evaluate for 7 cards")
+	}
+}
+
+func TestEvaluateOmaha(t *testing.T) {
+	tests := []struct {
+		name     string
+		hole     string
+		board    string
+		wantRank HandRank
+	}{
+		// Four hearts on board but only one in hand: no flush in Omaha
+		// (and the Broadway straight would need four hole cards)
+		{"One suited hole card", "AhKsQdJc", "2h5h8hTh3s", HighCard},
+		// Two suited hole cards make the nut flush
+		{"Two suited hole cards", "AhKhQdJc", "2h5h8hTs3s", Flush},
+		// Four hearts in hand but only two on board: can use only two from hand
+		{"Four suited hole cards", "AhKhQhJh", "2h5h9c7d3s", HighCard},
+		// Quads on board can't be played: only three board cards count
+		{"Board quads", "AsKd7c6c", "9h9s9d9c2s", ThreeOfAKind},
+		// Trips in hand plays as a pair
+		{"Three of a kind in hand", "AsAdAh2c", "Kh9s4c7d3s", OnePair},
+		// Flop board works too
+		{"Flop board", "AsAdKhKc", "Ah7d2c", ThreeOfAKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hole, err := ParseCards(tt.hole)
+			if err != nil {
+				t.Fatalf("ParseCards(%q) error: %v", tt.hole, err)
+			}
+			board, err := ParseCards(tt.board)
+			if err != nil {
+				t.Fatalf("ParseCards(%q) error: %v", tt.board, err)
+			}
+			got := EvaluateOmaha(hole, board)
+			if got.Rank != tt.wantRank {
+				t.Errorf("EvaluateOmaha(%v) = %v, want %v", tt.name, got.Rank, tt.wantRank)
+			}
+		})
+	}
+}
+
+func TestEvaluateOmaha_Compare(t *testing.T) {
+	board, _ := ParseCards("2h5h8hTs3s")
+
+	// Ace-high flush beats king-high flush with two hearts each
+	nut, _ := ParseCards("AhJhQdJc")
+	second, _ := ParseCards("KhQhAsAd")
+
+	if EvaluateOmaha(nut, board).Compare(EvaluateOmaha(second, board)) <= 0 {
+		t.Error("Expected nut flush to beat king-high flush")
+	}
+}
+
 func TestCompare(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -265,3 +384,37 @@ func TestCheckStraight(t *testing.T) {
 		})
 	}
 }
+
+func TestHandValueDescribe(t *testing.T) {
+	tests := []struct {
+		hand string
+		want string
+	}{
+		{"AsKsQsJsTs2d3c", "Royal flush"},
+		{"5h4h3h2hAh9c9d", "Wheel straight flush"},
+		{"9d8d7d6d5dKcKh", "Nine-high straight flush"},
+		{"QsQhQdQc2s3h4d", "Four Queens"},
+		{"AsAhAdKsKh2c3d", "Aces full of Kings"},
+		{"KsKhKdAsAh2c3d", "Kings full of Aces"},
+		{"6s6h6d3s3h2c9d", "Sixes full of Threes"},
+		{"Kh9h7h4h2hAsQd", "King-high flush"},
+		{"As2d3c4h5s9dJc", "Wheel straight"},
+		{"Ts9d8c7h6s2d2c", "Ten-high straight"},
+		{"JsJhJd9s7h3c2d", "Three Jacks"},
+		{"AsAhKsKd9c7h2d", "Two pair, Aces and Kings"},
+		{"8s8hAsKd9c7h2d", "Pair of Eights"},
+		{"AsQhTs8d6c4h2d", "Ace high"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hand, func(t *testing.T) {
+			hand, err := ParseCards(tt.hand)
+			if err != nil {
+				t.Fatalf("ParseCards(%q) failed: %v", tt.hand, err)
+			}
+			if got := Evaluate(hand).Describe(); got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
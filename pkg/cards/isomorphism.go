@@ -0,0 +1,134 @@
+package cards
+
+import "sort"
+
+// suitPermutations holds all 24 orderings of the four suits
+// perm[s] is the suit that s maps to
+var suitPermutations = generateSuitPermutations()
+
+// generateSuitPermutations enumerates every permutation of the four suits
+func generateSuitPermutations() [][4]Suit {
+	var perms [][4]Suit
+	var permute func(perm [4]Suit, k int)
+	permute = func(perm [4]Suit, k int) {
+		if k == len(perm) {
+			perms = append(perms, perm)
+			return
+		}
+		for i := k; i < len(perm); i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			permute(perm, k+1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	permute([4]Suit{Spades, Hearts, Diamonds, Clubs}, 0)
+	return perms
+}
+
+// CanonicalizeBoard maps a board to a canonical suit-isomorphic representative
+// Suit-isomorphic boards (e.g., Th9h2c and Td9d2s) produce identical canonical boards.
+// Flop cards are unordered and sorted high to low; turn and river keep their positions.
+// suitMap maps every original suit to its canonical suit.
+func CanonicalizeBoard(board []Card) ([]Card, map[Suit]Suit) {
+	var best []Card
+	var bestPerm [4]Suit
+
+	for _, perm := range suitPermutations {
+		mapped := mapBoard(board, perm)
+		if best == nil || lessSuits(mapped, best) {
+			best = mapped
+			bestPerm = perm
+		}
+	}
+
+	return best, permToMap(bestPerm)
+}
+
+// CanonicalizeHand canonicalizes a board and hole cards together
+// The board matches CanonicalizeBoard; among the suit mappings that produce it,
+// the one giving the smallest hole cards is used. This collapses hands that are
+// isomorphic on this board (e.g., AsKs and AdKd when neither suit is on the board).
+func CanonicalizeHand(board []Card, hole []Card) ([]Card, []Card) {
+	var bestBoard, bestHole []Card
+
+	for _, perm := range suitPermutations {
+		mappedBoard := mapBoard(board, perm)
+		mappedHole := mapHole(hole, perm)
+
+		if bestBoard == nil || lessSuits(mappedBoard, bestBoard) ||
+			(equalCards(mappedBoard, bestBoard) && lessSuits(mappedHole, bestHole)) {
+			bestBoard = mappedBoard
+			bestHole = mappedHole
+		}
+	}
+
+	return bestBoard, bestHole
+}
+
+// mapBoard applies a suit permutation to a board and sorts the flop portion
+func mapBoard(board []Card, perm [4]Suit) []Card {
+	mapped := make([]Card, len(board))
+	for i, card := range board {
+		mapped[i] = Card{Rank: card.Rank, Suit: perm[card.Suit]}
+	}
+
+	flopLen := len(mapped)
+	if flopLen > 3 {
+		flopLen = 3
+	}
+	sortCards(mapped[:flopLen])
+
+	return mapped
+}
+
+// mapHole applies a suit permutation to hole cards and sorts them
+func mapHole(hole []Card, perm [4]Suit) []Card {
+	mapped := make([]Card, len(hole))
+	for i, card := range hole {
+		mapped[i] = Card{Rank: card.Rank, Suit: perm[card.Suit]}
+	}
+	sortCards(mapped)
+	return mapped
+}
+
+// sortCards orders cards by rank (high first), then suit
+func sortCards(cardList []Card) {
+	sort.Slice(cardList, func(i, j int) bool {
+		if cardList[i].Rank != cardList[j].Rank {
+			return cardList[i].Rank > cardList[j].Rank
+		}
+		return cardList[i].Suit < cardList[j].Suit
+	})
+}
+
+// lessSuits compares two card sequences with identical rank layouts by suit
+func lessSuits(a, b []Card) bool {
+	for i := range a {
+		if a[i].Suit != b[i].Suit {
+			return a[i].Suit < b[i].Suit
+		}
+	}
+	return false
+}
+
+// equalCards reports whether two card sequences are identical
+func equalCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// permToMap converts a suit permutation to a map
+func permToMap(perm [4]Suit) map[Suit]Suit {
+	suitMap := make(map[Suit]Suit, len(perm))
+	for from, to := range perm {
+		suitMap[Suit(from)] = to
+	}
+	return suitMap
+}
@@ -0,0 +1,126 @@
+package cards
+
+import "testing"
+
+func TestCanonicalizeBoard_Isomorphic(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"two-tone flop", "Th9h2c", "Td9d2s"},
+		{"flop card order", "Th9h2c", "2c9hTh"},
+		{"monotone flop", "AsKs7s", "AcKc7c"},
+		{"rainbow turn", "Kh9s4c7d", "Kd9c4h7s"},
+		{"paired river", "ThTd2c5s5h", "TsTc2d5h5c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boardA, _ := ParseCards(tt.a)
+			boardB, _ := ParseCards(tt.b)
+
+			canonA, _ := CanonicalizeBoard(boardA)
+			canonB, _ := CanonicalizeBoard(boardB)
+
+			if !equalCards(canonA, canonB) {
+				t.Errorf("expected identical canonical boards, got %v and %v", canonA, canonB)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeBoard_Distinct(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"two-tone vs rainbow", "Th9h2c", "Th9s2c"},
+		{"flush draw suit differs", "Th9h2c", "Th9c2c"},
+		{"turn completes different suit", "Th9h2c3h", "Th9h2c3c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boardA, _ := ParseCards(tt.a)
+			boardB, _ := ParseCards(tt.b)
+
+			canonA, _ := CanonicalizeBoard(boardA)
+			canonB, _ := CanonicalizeBoard(boardB)
+
+			if equalCards(canonA, canonB) {
+				t.Errorf("expected different canonical boards for %s and %s, both gave %v", tt.a, tt.b, canonA)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeBoard_SuitMap(t *testing.T) {
+	board, _ := ParseCards("Td9d2s7c")
+	canon, suitMap := CanonicalizeBoard(board)
+
+	if len(suitMap) != 4 {
+		t.Fatalf("expected suit map for all 4 suits, got %d", len(suitMap))
+	}
+
+	// Suit map is a bijection
+	seen := make(map[Suit]bool)
+	for _, to := range suitMap {
+		if seen[to] {
+			t.Fatalf("suit map is not a bijection: %v", suitMap)
+		}
+		seen[to] = true
+	}
+
+	// Applying the map to the original board yields the canonical board (flop sorted)
+	mapped := make([]Card, len(board))
+	for i, card := range board {
+		mapped[i] = Card{Rank: card.Rank, Suit: suitMap[card.Suit]}
+	}
+	sortCards(mapped[:3])
+	if !equalCards(mapped, canon) {
+		t.Errorf("suit map gives %v, canonical board is %v", mapped, canon)
+	}
+}
+
+func TestCanonicalizeHand_Consistent(t *testing.T) {
+	board, _ := ParseCards("Th9h2c")
+	isoBoard, _ := ParseCards("Td9d2s")
+
+	hero, _ := ParseCards("AhKh")
+	isoHero, _ := ParseCards("AdKd")
+
+	// Same hand relabeled along with the board maps to the same canonical pair
+	boardA, heroA := CanonicalizeHand(board, hero)
+	boardB, heroB := CanonicalizeHand(isoBoard, isoHero)
+	if !equalCards(boardA, boardB) || !equalCards(heroA, heroB) {
+		t.Errorf("expected identical canonical forms, got %v %v and %v %v", boardA, heroA, boardB, heroB)
+	}
+
+	// Canonical board matches CanonicalizeBoard
+	canonBoard, _ := CanonicalizeBoard(board)
+	if !equalCards(boardA, canonBoard) {
+		t.Errorf("CanonicalizeHand board %v differs from CanonicalizeBoard %v", boardA, canonBoard)
+	}
+
+	// On Th9h2c, spades and diamonds are interchangeable
+	spades, _ := ParseCards("AsKs")
+	diamonds, _ := ParseCards("AdKd")
+	_, canonSpades := CanonicalizeHand(board, spades)
+	_, canonDiamonds := CanonicalizeHand(board, diamonds)
+	if !equalCards(canonSpades, canonDiamonds) {
+		t.Errorf("expected AsKs and AdKd to collapse on %v, got %v and %v", board, canonSpades, canonDiamonds)
+	}
+
+	// But the flush draw is not isomorphic to the backdoor hands
+	_, canonHearts := CanonicalizeHand(board, hero)
+	if equalCards(canonHearts, canonSpades) {
+		t.Errorf("AhKh should not collapse with AsKs on %v", board)
+	}
+
+	// Hole card order doesn't matter
+	reversed, _ := ParseCards("KhAh")
+	_, canonReversed := CanonicalizeHand(board, reversed)
+	if !equalCards(canonReversed, canonHearts) {
+		t.Errorf("expected hole card order to be ignored, got %v and %v", canonReversed, canonHearts)
+	}
+}
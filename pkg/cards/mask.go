@@ -0,0 +1,48 @@
+package cards
+
+import "math/bits"
+
+// Index returns the card's position in [0, 52): rank*4 + suit
+// Hot loops can use it to index [52]bool arrays or CardMask bitsets instead of maps.
+func (c Card) Index() uint8 {
+	return uint8(c.Rank)*4 + uint8(c.Suit)
+}
+
+// CardFromIndex is the inverse of Card.Index
+func CardFromIndex(i uint8) Card {
+	return Card{Rank: Rank(i / 4), Suit: Suit(i % 4)}
+}
+
+// CardMask is a set of cards packed into 64 bits (bit i is the card with Index i)
+// The zero value is the empty set. Methods return new masks, so a mask can be
+// passed down a recursion without undoing changes on the way back up.
+type CardMask uint64
+
+// NewCardMask returns the set of the given cards
+func NewCardMask(cards ...Card) CardMask {
+	var m CardMask
+	for _, c := range cards {
+		m |= 1 << c.Index()
+	}
+	return m
+}
+
+// Has reports whether c is in the set
+func (m CardMask) Has(c Card) bool {
+	return m&(1<<c.Index()) != 0
+}
+
+// With returns the set plus the given cards
+func (m CardMask) With(cards ...Card) CardMask {
+	return m | NewCardMask(cards...)
+}
+
+// Overlaps reports whether the two sets share a card
+func (m CardMask) Overlaps(other CardMask) bool {
+	return m&other != 0
+}
+
+// Count returns the number of cards in the set
+func (m CardMask) Count() int {
+	return bits.OnesCount64(uint64(m))
+}
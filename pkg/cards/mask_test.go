@@ -0,0 +1,54 @@
+package cards
+
+import "testing"
+
+func TestCardIndex_RoundTrip(t *testing.T) {
+	seen := make(map[uint8]bool)
+	for rank := Two; rank <= Ace; rank++ {
+		for suit := Spades; suit <= Clubs; suit++ {
+			card := NewCard(rank, suit)
+			idx := card.Index()
+			if idx >= 52 {
+				t.Fatalf("%v: index %d out of range", card, idx)
+			}
+			if seen[idx] {
+				t.Fatalf("%v: duplicate index %d", card, idx)
+			}
+			seen[idx] = true
+
+			if back := CardFromIndex(idx); back != card {
+				t.Errorf("CardFromIndex(%d) = %v, want %v", idx, back, card)
+			}
+		}
+	}
+	if len(seen) != 52 {
+		t.Errorf("expected 52 distinct indices, got %d", len(seen))
+	}
+}
+
+func TestCardMask(t *testing.T) {
+	hole, _ := ParseCards("AsKh")
+	board, _ := ParseCards("2c7dAc")
+
+	m := NewCardMask(hole...)
+	if m.Count() != 2 || !m.Has(hole[0]) || !m.Has(hole[1]) || m.Has(board[0]) {
+		t.Errorf("NewCardMask(%v) = %064b", hole, m)
+	}
+
+	full := m.With(board...)
+	if full.Count() != 5 || !full.Has(board[2]) {
+		t.Errorf("With(%v) = %064b", board, full)
+	}
+	if m.Count() != 2 {
+		t.Error("With should not modify the receiver")
+	}
+
+	if !full.Overlaps(NewCardMask(board[1])) || m.Overlaps(NewCardMask(board...)) {
+		t.Error("Overlaps gave the wrong answer")
+	}
+
+	var empty CardMask
+	if empty.Count() != 0 || empty.Has(NewCard(Two, Spades)) {
+		t.Error("zero mask should be empty")
+	}
+}
@@ -0,0 +1,95 @@
+package cards
+
+// ShortDeckHandValue is a hand evaluated under short-deck (6+) rules
+// The embedded HandValue uses the standard categories, but Compare ranks a
+// Flush above a FullHouse, since flushes are rarer with 36 cards.
+type ShortDeckHandValue struct {
+	HandValue
+}
+
+// shortDeckStrength orders hand categories for short-deck comparisons
+func shortDeckStrength(r HandRank) int {
+	switch r {
+	case Flush:
+		return int(FullHouse)
+	case FullHouse:
+		return int(Flush)
+	default:
+		return int(r)
+	}
+}
+
+// Compare returns -1 if h < other, 0 if equal, 1 if h > other under short-deck ranking
+func (h ShortDeckHandValue) Compare(other ShortDeckHandValue) int {
+	hs, otherStrength := shortDeckStrength(h.Rank), shortDeckStrength(other.Rank)
+	if hs != otherStrength {
+		if hs < otherStrength {
+			return -1
+		}
+		return 1
+	}
+
+	// Same category, standard tiebreakers apply
+	return h.HandValue.Compare(other.HandValue)
+}
+
+// EvaluateShortDeck returns the best short-deck hand from 5 to 7 cards
+// Cards must be 6 or higher. A-6-7-8-9 is the lowest straight (9-high).
+func EvaluateShortDeck(cards []Card) ShortDeckHandValue {
+	if len(cards) < 5 || len(cards) > 7 {
+		panic("EvaluateShortDeck requires 5 to 7 cards")
+	}
+	for _, card := range cards {
+		if card.Rank < Six {
+			panic("EvaluateShortDeck requires cards 6 or higher")
+		}
+	}
+
+	n := len(cards)
+	best := ShortDeckHandValue{HandValue{Rank: HighCard}}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k := j + 1; k < n; k++ {
+				for l := k + 1; l < n; l++ {
+					for m := l + 1; m < n; m++ {
+						hand := []Card{cards[i], cards[j], cards[k], cards[l], cards[m]}
+						value := evaluate5CardsShortDeck(hand)
+						if value.Compare(best) > 0 {
+							best = value
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return best
+}
+
+// evaluate5CardsShortDeck evaluates exactly 5 short-deck cards
+// Only the low straight differs from evaluate5Cards: A-6-7-8-9 replaces the wheel.
+func evaluate5CardsShortDeck(cards []Card) ShortDeckHandValue {
+	value := evaluate5Cards(cards)
+
+	if value.Rank == HighCard || value.Rank == Flush {
+		if isShortDeckWheel(cards) {
+			rank := Straight
+			if value.Rank == Flush {
+				rank = StraightFlush
+			}
+			return ShortDeckHandValue{HandValue{Rank: rank, Values: [5]Rank{Nine, 0, 0, 0, 0}}}
+		}
+	}
+
+	return ShortDeckHandValue{value}
+}
+
+// isShortDeckWheel reports whether five cards are exactly A-6-7-8-9
+func isShortDeckWheel(cards []Card) bool {
+	var seen [13]bool
+	for _, card := range cards {
+		seen[card.Rank] = true
+	}
+	return seen[Ace] && seen[Six] && seen[Seven] && seen[Eight] && seen[Nine]
+}
@@ -0,0 +1,87 @@
+package cards
+
+import "testing"
+
+func TestEvaluateShortDeck(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		wantRank HandRank
+		wantHigh Rank
+	}{
+		{"A6789 straight", "As6d7c8h9s", Straight, Nine},
+		{"A6789 with extras", "As6d7c8h9sKdKc", Straight, Nine},
+		{"A6789 straight flush", "Ah6h7h8h9hKd", StraightFlush, Nine},
+		{"broadway", "AsKdQcJhTs", Straight, Ace},
+		{"T-high straight", "6s7d8c9hTs", Straight, Ten},
+		{"flush", "AhJh9h7h6hKs", Flush, Ace},
+		{"full house", "KsKdKc6h6s", FullHouse, King},
+		{"trips", "QsQdQc9h6s", ThreeOfAKind, Queen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := ParseCards(tt.cards)
+			if err != nil {
+				t.Fatalf("ParseCards(%q) failed: %v", tt.cards, err)
+			}
+
+			value := EvaluateShortDeck(cards)
+			if value.Rank != tt.wantRank {
+				t.Errorf("Rank = %v, want %v", value.Rank, tt.wantRank)
+			}
+			if value.Values[0] != tt.wantHigh {
+				t.Errorf("Values[0] = %v, want %v", value.Values[0], tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestShortDeckHandValue_Compare(t *testing.T) {
+	eval := func(s string) ShortDeckHandValue {
+		cards, _ := ParseCards(s)
+		return EvaluateShortDeck(cards)
+	}
+
+	fullHouse := eval("KsKdKcQhQs")
+	lowFlush := eval("JhTh8h7h6h")
+
+	if lowFlush.Compare(fullHouse) <= 0 {
+		t.Error("Expected flush to beat full house in short deck")
+	}
+	if fullHouse.Compare(lowFlush) >= 0 {
+		t.Error("Expected full house to lose to flush in short deck")
+	}
+
+	// Standard Compare still ranks full house above flush
+	if lowFlush.HandValue.Compare(fullHouse.HandValue) >= 0 {
+		t.Error("Expected standard ranking to keep full house above flush")
+	}
+
+	// A6789 is the lowest straight
+	wheel := eval("As6d7c8h9s")
+	tenHigh := eval("6s7d8c9hTs")
+	if wheel.Compare(tenHigh) >= 0 {
+		t.Error("Expected A6789 to lose to T-high straight")
+	}
+	trips := eval("QsQdQc9h6s")
+	if wheel.Compare(trips) <= 0 {
+		t.Error("Expected A6789 straight to beat trips")
+	}
+
+	// Same-category tiebreakers are unchanged
+	if eval("AhJh9h7h6h").Compare(lowFlush) <= 0 {
+		t.Error("Expected ace-high flush to beat jack-high flush")
+	}
+}
+
+func TestEvaluateShortDeck_RejectsLowCards(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for cards below 6")
+		}
+	}()
+
+	cards, _ := ParseCards("As2d7c8h9s")
+	EvaluateShortDeck(cards)
+}
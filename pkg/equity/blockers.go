@@ -0,0 +1,57 @@
+package equity
+
+import (
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// CategoryBlockers counts how hero's cards shrink one category of villain hands
+type CategoryBlockers struct {
+	Total   int // Villain combos in the category, before hero's cards are removed
+	Removed int // Of those, combos that share a card with hero
+}
+
+// RemovedFraction returns the share of the category hero blocks (0 if it is empty)
+func (b CategoryBlockers) RemovedFraction() float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return float64(b.Removed) / float64(b.Total)
+}
+
+// BlockerImpact removes the villain combos that share a card with hero
+// Returns how many were removed and the combos villain can still hold. Holding
+// the Ah, for example, removes every Ax heart flush and every AhAx from oppRange.
+func BlockerImpact(hero []cards.Card, oppRange []notation.Combo) (removed int, remaining []notation.Combo) {
+	remaining = notation.RemoveBlockers(oppRange, hero)
+	return len(oppRange) - len(remaining), remaining
+}
+
+// BlockerImpactByCategory reports hero's blockers per villain hand category on
+// board, using a default Calculator (see Calculator.BlockerImpactByCategory)
+func BlockerImpactByCategory(hero []cards.Card, board []cards.Card, oppRange []notation.Combo) map[cards.HandRank]CategoryBlockers {
+	return NewCalculator().BlockerImpactByCategory(hero, board, oppRange)
+}
+
+// BlockerImpactByCategory groups oppRange by the hand each combo makes on board
+// and counts, per category, how many combos hero's cards remove. Villain combos
+// blocked by the board itself are skipped. Useful for picking bluffs: a good
+// bluff blocks villain's calls (pairs, draws that got there) and not their folds.
+func (c *Calculator) BlockerImpactByCategory(hero []cards.Card, board []cards.Card, oppRange []notation.Combo) map[cards.HandRank]CategoryBlockers {
+	heroMask := cards.NewCardMask(hero...)
+	impact := make(map[cards.HandRank]CategoryBlockers)
+	for _, combo := range oppRange {
+		if combo.Conflicts(board) {
+			continue
+		}
+
+		rank := c.evaluate(combo.Cards(), board).Rank
+		counts := impact[rank]
+		counts.Total++
+		if heroMask.Overlaps(combo.Mask()) {
+			counts.Removed++
+		}
+		impact[rank] = counts
+	}
+	return impact
+}
@@ -0,0 +1,47 @@
+package equity
+
+import (
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestBlockerImpact(t *testing.T) {
+	ah := cards.NewCard(cards.Ace, cards.Hearts)
+	oppRange, _ := notation.ParseRange("AA,AKs,KQs")
+
+	removed, remaining := BlockerImpact([]cards.Card{ah, cards.NewCard(cards.Two, cards.Clubs)}, oppRange)
+
+	// AhAx (3 of 6 AA) and AhKh (1 of 4 AKs)
+	if removed != 4 {
+		t.Errorf("removed %d combos, want 4", removed)
+	}
+	if len(remaining) != len(oppRange)-removed {
+		t.Errorf("got %d remaining combos, want %d", len(remaining), len(oppRange)-removed)
+	}
+	for _, combo := range remaining {
+		if combo.Card1 == ah || combo.Card2 == ah {
+			t.Errorf("remaining combo %s contains the Ah", combo)
+		}
+	}
+}
+
+func TestBlockerImpactByCategory(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9h4h7d2s")
+	oppRange, _ := notation.ParseRange("AhQh,AhJh,QhJh,AsAc,AcAd")
+	hero := []cards.Card{cards.NewCard(cards.Ace, cards.Hearts), cards.NewCard(cards.Five, cards.Diamonds)}
+
+	impact := BlockerImpactByCategory(hero, board, oppRange)
+
+	// The Ah blocks two of the three flushes and none of the aces
+	if got := impact[cards.Flush]; got != (CategoryBlockers{Total: 3, Removed: 2}) {
+		t.Errorf("flush blockers = %+v, want {Total:3 Removed:2}", got)
+	}
+	if got := impact[cards.OnePair]; got != (CategoryBlockers{Total: 2, Removed: 0}) {
+		t.Errorf("one pair blockers = %+v, want {Total:2 Removed:0}", got)
+	}
+	if got := impact[cards.Flush].RemovedFraction(); got != 2.0/3.0 {
+		t.Errorf("flush RemovedFraction = %v, want 2/3", got)
+	}
+}
@@ -0,0 +1,146 @@
+package equity
+
+import (
+	"container/list"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// equityCache is a thread-safe LRU cache of exact equity results
+type equityCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List // Front is most recently used
+	entries map[equityKey]*list.Element
+
+	hits   int
+	misses int
+}
+
+// equityKey identifies an equity calculation independent of card order
+type equityKey struct {
+	hero  string // Hole cards, sorted
+	board string // Board cards, sorted
+	rng   uint64 // Hash of the sorted opponent range
+	size  int    // Opponent range size (guards against hash collisions)
+}
+
+type cacheEntry struct {
+	key    equityKey
+	result EquityResult
+}
+
+func newEquityCache(maxEntries int) *equityCache {
+	return &equityCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[equityKey]*list.Element),
+	}
+}
+
+// NewCachingCalculator creates a calculator that remembers up to maxEntries results
+// of CalculateEquity, evicting the least recently used. Keys ignore the order of hero
+// cards, board cards, and range combos. Safe for concurrent use.
+func NewCachingCalculator(maxEntries int) *Calculator {
+	if maxEntries <= 0 {
+		maxEntries = 1
+	}
+	return &Calculator{cache: newEquityCache(maxEntries)}
+}
+
+// CacheStats returns the number of cache hits and misses (0, 0 without a cache)
+func (c *Calculator) CacheStats() (hits, misses int) {
+	if c.cache == nil {
+		return 0, 0
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	return c.cache.hits, c.cache.misses
+}
+
+// get returns a cached result, marking it most recently used
+func (ec *equityCache) get(key equityKey) (EquityResult, bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	elem, ok := ec.entries[key]
+	if !ok {
+		ec.misses++
+		return EquityResult{}, false
+	}
+	ec.hits++
+	ec.order.MoveToFront(elem)
+	return copyResult(elem.Value.(*cacheEntry).result), true
+}
+
+// put stores a result, evicting the least recently used entry when full
+func (ec *equityCache) put(key equityKey, result EquityResult) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if elem, ok := ec.entries[key]; ok {
+		elem.Value.(*cacheEntry).result = copyResult(result)
+		ec.order.MoveToFront(elem)
+		return
+	}
+
+	ec.entries[key] = ec.order.PushFront(&cacheEntry{key: key, result: copyResult(result)})
+	for ec.order.Len() > ec.maxEntries {
+		oldest := ec.order.Back()
+		ec.order.Remove(oldest)
+		delete(ec.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// makeEquityKey canonicalizes hero, board, and range into a cache key
+func makeEquityKey(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) equityKey {
+	combos := make([]string, len(opponentRange))
+	for i, combo := range opponentRange {
+		combos[i] = sortedCardString(combo.Cards())
+	}
+	sort.Strings(combos)
+
+	h := fnv.New64a()
+	for _, combo := range combos {
+		h.Write([]byte(combo))
+	}
+
+	return equityKey{
+		hero:  sortedCardString(hero),
+		board: sortedCardString(board),
+		rng:   h.Sum64(),
+		size:  len(opponentRange),
+	}
+}
+
+// sortedCardString concatenates cards in a fixed order so permutations match
+func sortedCardString(cardList []cards.Card) string {
+	strs := make([]string, len(cardList))
+	for i, card := range cardList {
+		strs[i] = card.String()
+	}
+	sort.Strings(strs)
+
+	var result string
+	for _, s := range strs {
+		result += s
+	}
+	return result
+}
+
+// copyResult returns a result whose HeroHandBuckets map isn't shared with the cache
+func copyResult(result EquityResult) EquityResult {
+	if result.HeroHandBuckets != nil {
+		buckets := make(map[cards.HandRank]float64, len(result.HeroHandBuckets))
+		for rank, frac := range result.HeroHandBuckets {
+			buckets[rank] = frac
+		}
+		result.HeroHandBuckets = buckets
+	}
+	return result
+}
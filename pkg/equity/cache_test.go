@@ -0,0 +1,81 @@
+package equity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestCachingCalculator_Hits(t *testing.T) {
+	calc := NewCachingCalculator(16)
+	plain := NewCalculator()
+
+	hero, _ := cards.ParseCards("AhAd")
+	board, _ := cards.ParseCards("Kh9s4c7d")
+	oppRange, _ := notation.ParseRange("KQs,JJ")
+	oppRange = notation.RemoveBlockers(oppRange, append(append([]cards.Card{}, hero...), board...))
+
+	first := calc.CalculateEquity(hero, board, oppRange)
+	if hits, misses := calc.CacheStats(); hits != 0 || misses != 1 {
+		t.Fatalf("after first call: hits=%d misses=%d, want 0/1", hits, misses)
+	}
+
+	second := calc.CalculateEquity(hero, board, oppRange)
+	if hits, misses := calc.CacheStats(); hits != 1 || misses != 1 {
+		t.Fatalf("after second call: hits=%d misses=%d, want 1/1", hits, misses)
+	}
+
+	// Cached results match the uncached calculation
+	want := plain.CalculateEquity(hero, board, oppRange)
+	if !reflect.DeepEqual(first, want) || !reflect.DeepEqual(second, want) {
+		t.Errorf("cached results %+v / %+v differ from uncached %+v", first, second, want)
+	}
+
+	// Card and combo order don't matter
+	reversedHero := []cards.Card{hero[1], hero[0]}
+	reversedBoard := []cards.Card{board[3], board[2], board[1], board[0]}
+	reversedRange := make([]notation.Combo, len(oppRange))
+	for i, combo := range oppRange {
+		reversedRange[len(oppRange)-1-i] = notation.Combo{Card1: combo.Card2, Card2: combo.Card1}
+	}
+	calc.CalculateEquity(reversedHero, reversedBoard, reversedRange)
+	if hits, _ := calc.CacheStats(); hits != 2 {
+		t.Errorf("permuted inputs should hit the cache, hits=%d", hits)
+	}
+
+	// Mutating a returned result doesn't corrupt the cache
+	second.HeroHandBuckets[cards.HighCard] = 99
+	third := calc.CalculateEquity(hero, board, oppRange)
+	if !reflect.DeepEqual(third, want) {
+		t.Errorf("cache entry changed after caller mutation: %+v", third)
+	}
+}
+
+func TestCachingCalculator_Evicts(t *testing.T) {
+	calc := NewCachingCalculator(2)
+
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	oppRange, _ := notation.ParseRange("QQ")
+	heroes := []string{"AhAd", "JhJd", "ThTd"}
+
+	for _, h := range heroes {
+		hero, _ := cards.ParseCards(h)
+		calc.CalculateEquity(hero, board, oppRange)
+	}
+
+	// AhAd was least recently used and evicted; ThTd is still cached
+	hero, _ := cards.ParseCards("ThTd")
+	calc.CalculateEquity(hero, board, oppRange)
+	hero, _ = cards.ParseCards("AhAd")
+	calc.CalculateEquity(hero, board, oppRange)
+
+	if hits, misses := calc.CacheStats(); hits != 1 || misses != 4 {
+		t.Errorf("hits=%d misses=%d, want 1/4", hits, misses)
+	}
+
+	if hits, misses := NewCalculator().CacheStats(); hits != 0 || misses != 0 {
+		t.Errorf("uncached calculator reported stats %d/%d", hits, misses)
+	}
+}
@@ -1,6 +1,8 @@
 package equity
 
 import (
+	"math/rand"
+
 	"github.com/behrlich/poker-solver/pkg/cards"
 	"github.com/behrlich/poker-solver/pkg/notation"
 )
@@ -10,6 +12,21 @@ type EquityResult struct {
 	WinPct float64 // Percentage of times hero wins
 	TiePct float64 // Percentage of times hero ties
 	Equity float64 // Overall equity (win% + tie%/2)
+
+	// HeroHandBuckets is the fraction of evaluated runouts where hero made each
+	// hand category (e.g., HeroHandBuckets[cards.Flush] = 0.35)
+	HeroHandBuckets map[cards.HandRank]float64
+}
+
+// DetailedEquityResult is an EquityResult with the showdown counts behind it
+// Counts from separate calculations (e.g. parallel chunks of a range) can be
+// summed and turned back into percentages.
+type DetailedEquityResult struct {
+	EquityResult
+
+	Wins  int64 // Showdowns hero won
+	Ties  int64 // Showdowns hero tied
+	Total int64 // Showdowns evaluated; hero lost Total-Wins-Ties
 }
 
 // PotentialResult represents hand improvement potential
@@ -21,7 +38,11 @@ type PotentialResult struct {
 
 // Calculator computes hand equity vs opponent ranges
 type Calculator struct {
-	// Nothing needed for now (pure functions)
+	// Optional LRU cache of CalculateEquity results (see NewCachingCalculator)
+	cache *equityCache
+
+	// Hand evaluator for showdowns (nil means cards.DefaultEvaluator)
+	evaluator cards.Evaluator
 }
 
 // NewCalculator creates a new equity calculator
@@ -29,11 +50,51 @@ func NewCalculator() *Calculator {
 	return &Calculator{}
 }
 
+// SetEvaluator sets the hand evaluator used for showdowns
+// Any cached results are discarded since they were computed with the old evaluator.
+func (c *Calculator) SetEvaluator(evaluator cards.Evaluator) {
+	c.evaluator = evaluator
+	if c.cache != nil {
+		c.cache = newEquityCache(c.cache.maxEntries)
+	}
+}
+
+// evaluate scores hole cards on a board with the configured evaluator
+func (c *Calculator) evaluate(hole []cards.Card, board []cards.Card) cards.HandValue {
+	if c.evaluator == nil {
+		return cards.DefaultEvaluator.Evaluate(hole, board)
+	}
+	return c.evaluator.Evaluate(hole, board)
+}
+
+// newDeck returns a full deck for the configured evaluator's game
+func (c *Calculator) newDeck() *cards.Deck {
+	return cards.NewDeckFor(c.evaluator)
+}
+
 // CalculateEquity computes hero's equity against opponent's range
-// hero: 2 cards
-// board: 3-5 cards (flop, turn, or river)
+// hero: hole cards (two, or four with an Omaha evaluator)
+// board: 3-5 cards (flop, turn, or river), or fewer preflop (sampled, see
+// calculatePreflopEquity)
 // opponentRange: list of opponent combos
 func (c *Calculator) CalculateEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) EquityResult {
+	if c.cache == nil {
+		return c.calculateEquity(hero, board, opponentRange)
+	}
+
+	key := makeEquityKey(hero, board, opponentRange)
+	if result, ok := c.cache.get(key); ok {
+		return result
+	}
+	result := c.calculateEquity(hero, board, opponentRange)
+	c.cache.put(key, result)
+	return result
+}
+
+// CalculateEquityDetailed is CalculateEquity with the win, tie, and showdown counts
+// Every hero runout against every opponent combo is one showdown. Results are
+// computed exactly each time; a caching calculator doesn't cache them.
+func (c *Calculator) CalculateEquityDetailed(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) DetailedEquityResult {
 	// Edge case: if board is complete (5 cards), no runout needed
 	if len(board) == 5 {
 		return c.calculateRiverEquity(hero, board, opponentRange)
@@ -45,20 +106,102 @@ func (c *Calculator) CalculateEquity(hero []cards.Card, board []cards.Card, oppo
 	}
 
 	// Flop (3 cards)
-	return c.calculateFlopEquity(hero, board, opponentRange)
+	if len(board) == 3 {
+		return c.calculateFlopEquity(hero, board, opponentRange)
+	}
+
+	// Preflop: too many runouts to enumerate
+	return c.calculatePreflopEquity(hero, board, opponentRange)
 }
 
-// calculateRiverEquity handles completed board (5 cards)
-func (c *Calculator) calculateRiverEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) EquityResult {
-	heroHand := cards.Evaluate(append(hero, board...))
+// calculateEquity dispatches the exact equity calculation by street
+func (c *Calculator) calculateEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) EquityResult {
+	return c.CalculateEquityDetailed(hero, board, opponentRange).EquityResult
+}
+
+// equityTally counts hero's showdowns in an exact equity calculation
+type equityTally struct {
+	wins, ties, total int64
+	categories        handCategories
+}
+
+// add records one showdown from hero's comparison against the opponent
+func (t *equityTally) add(cmp int) {
+	if cmp > 0 {
+		t.wins++
+	} else if cmp == 0 {
+		t.ties++
+	}
+	t.total++
+}
+
+// result converts the tally (0.5 equity if there were no showdowns)
+func (t *equityTally) result() DetailedEquityResult {
+	if t.total == 0 {
+		return DetailedEquityResult{EquityResult: EquityResult{Equity: 0.5}}
+	}
+
+	winPct := float64(t.wins) / float64(t.total)
+	tiePct := float64(t.ties) / float64(t.total)
+	return DetailedEquityResult{
+		EquityResult: EquityResult{
+			WinPct:          winPct,
+			TiePct:          tiePct,
+			Equity:          winPct + tiePct/2.0,
+			HeroHandBuckets: t.categories.fractions(),
+		},
+		Wins:  t.wins,
+		Ties:  t.ties,
+		Total: t.total,
+	}
+}
+
+// CalculateEquityMC estimates hero's equity against opponent's range by sampling
+// Each sample draws an opponent combo and a runout uniformly at random, so accuracy
+// is controlled by samples rather than board size. River boards are enumerated exactly.
+// Opponent combos that conflict with hero or board cards are ignored.
+func (c *Calculator) CalculateEquityMC(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo, samples int, seed int64) EquityResult {
+	if len(board) == 5 {
+		return c.calculateRiverEquity(hero, board, opponentRange).EquityResult
+	}
+
+	usedCards := cards.NewCardMask(hero...).With(board...)
+
+	// Only opponent combos that don't collide with known cards are possible
+	validCombos := make([]notation.Combo, 0, len(opponentRange))
+	for _, combo := range opponentRange {
+		if !usedCards.Overlaps(combo.Mask()) {
+			validCombos = append(validCombos, combo)
+		}
+	}
+
+	if len(validCombos) == 0 || samples <= 0 {
+		return EquityResult{Equity: 0.5}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	cardsNeeded := 5 - len(board)
+
+	baseDeck := c.newDeck()
+	baseDeck.Remove(hero...)
+	baseDeck.Remove(board...)
 
 	wins := 0.0
 	ties := 0.0
-	total := 0.0
+	var categories handCategories
 
-	for _, oppCombo := range opponentRange {
-		oppCards := []cards.Card{oppCombo.Card1, oppCombo.Card2}
-		oppHand := cards.Evaluate(append(oppCards, board...))
+	for i := 0; i < samples; i++ {
+		oppCombo := validCombos[rng.Intn(len(validCombos))]
+
+		// Deal the runout from the deck minus this opponent combo
+		deck := baseDeck.Clone()
+		deck.Remove(oppCombo.Cards()...)
+
+		fullBoard := append(append([]cards.Card{}, board...), deck.Deal(rng, cardsNeeded)...)
+
+		heroHand := c.evaluate(hero, fullBoard)
+		oppHand := c.evaluate(oppCombo.Cards(), fullBoard)
+		categories.add(heroHand)
 
 		cmp := heroHand.Compare(oppHand)
 		if cmp > 0 {
@@ -66,236 +209,430 @@ func (c *Calculator) calculateRiverEquity(hero []cards.Card, board []cards.Card,
 		} else if cmp == 0 {
 			ties++
 		}
-		total++
-	}
-
-	if total == 0 {
-		return EquityResult{Equity: 0.5} // No valid opponent combos
 	}
 
+	total := float64(samples)
 	winPct := wins / total
 	tiePct := ties / total
 	equity := winPct + tiePct/2.0
 
 	return EquityResult{
-		WinPct: winPct,
-		TiePct: tiePct,
-		Equity: equity,
+		WinPct:          winPct,
+		TiePct:          tiePct,
+		Equity:          equity,
+		HeroHandBuckets: categories.fractions(),
 	}
 }
 
-// calculateTurnEquity handles turn (4 cards, need 1 river)
-func (c *Calculator) calculateTurnEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) EquityResult {
-	usedCards := makeCardSet(append(hero, board...))
+// CalculateEquityMultiway computes hero's equity against several independent opponent ranges
+// Every combination of one combo per opponent (with no shared cards) is enumerated
+// against every runout. Hero's share of a trial is 1 for beating all opponents,
+// 1/(k+1) when tying k opponents for the best hand, and 0 otherwise.
+// Cost grows with the product of range sizes; use CalculateEquityMultiwayMC for wide ranges.
+func (c *Calculator) CalculateEquityMultiway(hero []cards.Card, board []cards.Card, oppRanges [][]notation.Combo) EquityResult {
+	used := cards.NewCardMask(hero...).With(board...)
+
+	deck := c.newDeck()
+	deck.Remove(hero...)
+	deck.Remove(board...)
+
+	tally := multiwayTally{calc: c}
+	forEachOpponentDeal(oppRanges, used, nil, func(opps []notation.Combo) {
+		remaining := deck.Clone()
+		for _, opp := range opps {
+			remaining.Remove(opp.Cards()...)
+		}
 
-	wins := 0.0
-	ties := 0.0
-	total := 0.0
+		forEachRunout(remaining.Cards(), 5-len(board), nil, func(runout []cards.Card) {
+			fullBoard := append(append([]cards.Card{}, board...), runout...)
+			tally.add(hero, opps, fullBoard)
+		})
+	})
 
-	// Enumerate all possible river cards
-	for rank := cards.Two; rank <= cards.Ace; rank++ {
-		for suit := cards.Spades; suit <= cards.Clubs; suit++ {
-			river := cards.Card{Rank: rank, Suit: suit}
-			if usedCards[river] {
-				continue
-			}
+	return tally.result()
+}
 
-			fullBoard := append(board, river)
-			heroHand := cards.Evaluate(append(hero, fullBoard...))
+// CalculateEquityMultiwayMC estimates multiway equity by sampling
+// Each sample draws one combo per opponent (redrawing on card collisions) and a runout.
+// Samples where no collision-free deal is found are skipped.
+func (c *Calculator) CalculateEquityMultiwayMC(hero []cards.Card, board []cards.Card, oppRanges [][]notation.Combo, samples int, seed int64) EquityResult {
+	rng := rand.New(rand.NewSource(seed))
+
+	baseDeck := c.newDeck()
+	baseDeck.Remove(hero...)
+	baseDeck.Remove(board...)
+
+	tally := multiwayTally{calc: c}
+	for i := 0; i < samples; i++ {
+		opps, ok := sampleOpponentDeal(rng, oppRanges, cards.NewCardMask(hero...).With(board...))
+		if !ok {
+			continue
+		}
 
-			// Evaluate against each opponent combo
-			for _, oppCombo := range opponentRange {
-				oppCards := []cards.Card{oppCombo.Card1, oppCombo.Card2}
+		deck := baseDeck.Clone()
+		for _, opp := range opps {
+			deck.Remove(opp.Cards()...)
+		}
 
-				// Skip if opponent has the river card
-				if oppCombo.Card1 == river || oppCombo.Card2 == river {
-					continue
-				}
+		fullBoard := append(append([]cards.Card{}, board...), deck.Deal(rng, 5-len(board))...)
+		tally.add(hero, opps, fullBoard)
+	}
 
-				oppHand := cards.Evaluate(append(oppCards, fullBoard...))
+	return tally.result()
+}
 
-				cmp := heroHand.Compare(oppHand)
-				if cmp > 0 {
-					wins++
-				} else if cmp == 0 {
-					ties++
-				}
-				total++
-			}
+// multiwayTally accumulates hero's results over multiway trials
+type multiwayTally struct {
+	calc                     *Calculator // Supplies the hand evaluator
+	wins, ties, share, total float64
+	categories               handCategories
+}
+
+// add scores one trial: hero against every opponent on a complete board
+func (t *multiwayTally) add(hero []cards.Card, opps []notation.Combo, fullBoard []cards.Card) {
+	heroHand := t.calc.evaluate(hero, fullBoard)
+	t.categories.add(heroHand)
+
+	tiedWith := 0
+	for _, opp := range opps {
+		oppHand := t.calc.evaluate(opp.Cards(), fullBoard)
+		cmp := heroHand.Compare(oppHand)
+		if cmp < 0 {
+			t.total++
+			return // hero loses the pot
+		}
+		if cmp == 0 {
+			tiedWith++
 		}
 	}
 
-	if total == 0 {
+	if tiedWith == 0 {
+		t.wins++
+		t.share++
+	} else {
+		t.ties++
+		t.share += 1.0 / float64(tiedWith+1)
+	}
+	t.total++
+}
+
+// result converts the tally to an EquityResult (0.5 equity if there were no valid trials)
+func (t *multiwayTally) result() EquityResult {
+	if t.total == 0 {
 		return EquityResult{Equity: 0.5}
 	}
+	return EquityResult{
+		WinPct:          t.wins / t.total,
+		TiePct:          t.ties / t.total,
+		Equity:          t.share / t.total,
+		HeroHandBuckets: t.categories.fractions(),
+	}
+}
 
-	winPct := wins / total
-	tiePct := ties / total
-	equity := winPct + tiePct/2.0
+// forEachOpponentDeal calls fn with every assignment of one combo per range
+// in which no card is shared with used cards or another opponent
+func forEachOpponentDeal(ranges [][]notation.Combo, used cards.CardMask, dealt []notation.Combo, fn func([]notation.Combo)) {
+	if len(dealt) == len(ranges) {
+		fn(dealt)
+		return
+	}
 
-	return EquityResult{
-		WinPct: winPct,
-		TiePct: tiePct,
-		Equity: equity,
+	for _, combo := range ranges[len(dealt)] {
+		if used.Overlaps(combo.Mask()) {
+			continue
+		}
+		forEachOpponentDeal(ranges, used|combo.Mask(), append(dealt, combo), fn)
+	}
+}
+
+// forEachRunout calls fn with every unordered choice of n cards from deck
+func forEachRunout(deck []cards.Card, n int, chosen []cards.Card, fn func([]cards.Card)) {
+	if n == 0 {
+		fn(chosen)
+		return
+	}
+
+	for i := 0; i <= len(deck)-n; i++ {
+		forEachRunout(deck[i+1:], n-1, append(chosen, deck[i]), fn)
 	}
 }
 
+// sampleOpponentDeal draws one combo per range uniformly, rejecting card collisions
+// Gives up after a bounded number of attempts (e.g., ranges that always collide)
+func sampleOpponentDeal(rng *rand.Rand, ranges [][]notation.Combo, used cards.CardMask) ([]notation.Combo, bool) {
+	const maxAttempts = 100
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		opps := make([]notation.Combo, 0, len(ranges))
+		taken := used
+		ok := true
+
+		for _, r := range ranges {
+			if len(r) == 0 {
+				return nil, false
+			}
+			combo := r[rng.Intn(len(r))]
+			if taken.Overlaps(combo.Mask()) {
+				ok = false
+				break
+			}
+			taken |= combo.Mask()
+			opps = append(opps, combo)
+		}
+
+		if ok {
+			return opps, true
+		}
+	}
+
+	return nil, false
+}
+
+// calculateRiverEquity handles completed board (5 cards)
+func (c *Calculator) calculateRiverEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) DetailedEquityResult {
+	heroHand := c.evaluate(hero, board)
+
+	var tally equityTally
+	tally.categories.add(heroHand)
+
+	for _, oppCombo := range opponentRange {
+		oppCards := oppCombo.Cards()
+		oppHand := c.evaluate(oppCards, board)
+		tally.add(heroHand.Compare(oppHand))
+	}
+
+	// With no opponent combos the result is 0.5 equity
+	return tally.result()
+}
+
+// calculateTurnEquity handles turn (4 cards, need 1 river)
+func (c *Calculator) calculateTurnEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) DetailedEquityResult {
+	usedCards := cards.NewCardMask(hero...).With(board...)
+
+	var tally equityTally
+
+	// Enumerate all possible river cards
+	for _, river := range c.newDeck().Cards() {
+		if usedCards.Has(river) {
+			continue
+		}
+
+		fullBoard := append(board, river)
+		heroHand := c.evaluate(hero, fullBoard)
+		tally.categories.add(heroHand)
+
+		// Evaluate against each opponent combo
+		for _, oppCombo := range opponentRange {
+			// Skip if opponent has the river card
+			if oppCombo.Mask().Has(river) {
+				continue
+			}
+
+			oppHand := c.evaluate(oppCombo.Cards(), fullBoard)
+
+			tally.add(heroHand.Compare(oppHand))
+		}
+	}
+
+	return tally.result()
+}
+
 // calculateFlopEquity handles flop (3 cards, need turn + river)
-func (c *Calculator) calculateFlopEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) EquityResult {
-	usedCards := makeCardSet(append(hero, board...))
+func (c *Calculator) calculateFlopEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) DetailedEquityResult {
+	usedCards := cards.NewCardMask(hero...).With(board...)
 
-	wins := 0.0
-	ties := 0.0
-	total := 0.0
+	var tally equityTally
+
+	deck := c.newDeck().Cards()
 
 	// Enumerate all possible turn cards
-	for turnRank := cards.Two; turnRank <= cards.Ace; turnRank++ {
-		for turnSuit := cards.Spades; turnSuit <= cards.Clubs; turnSuit++ {
-			turn := cards.Card{Rank: turnRank, Suit: turnSuit}
-			if usedCards[turn] {
+	for _, turn := range deck {
+		if usedCards.Has(turn) {
+			continue
+		}
+
+		turnBoard := append(board, turn)
+		turnUsed := usedCards.With(turn)
+
+		// Enumerate all possible river cards
+		for _, river := range deck {
+			if turnUsed.Has(river) {
 				continue
 			}
 
-			turnBoard := append(board, turn)
-			turnUsed := makeCardSet(append(hero, turnBoard...))
-
-			// Enumerate all possible river cards
-			for riverRank := cards.Two; riverRank <= cards.Ace; riverRank++ {
-				for riverSuit := cards.Spades; riverSuit <= cards.Clubs; riverSuit++ {
-					river := cards.Card{Rank: riverRank, Suit: riverSuit}
-					if turnUsed[river] {
-						continue
-					}
-
-					fullBoard := append(turnBoard, river)
-					heroHand := cards.Evaluate(append(hero, fullBoard...))
-
-					// Evaluate against each opponent combo
-					for _, oppCombo := range opponentRange {
-						oppCards := []cards.Card{oppCombo.Card1, oppCombo.Card2}
-
-						// Skip if opponent has turn or river
-						if oppCombo.Card1 == turn || oppCombo.Card2 == turn ||
-							oppCombo.Card1 == river || oppCombo.Card2 == river {
-							continue
-						}
-
-						oppHand := cards.Evaluate(append(oppCards, fullBoard...))
-
-						cmp := heroHand.Compare(oppHand)
-						if cmp > 0 {
-							wins++
-						} else if cmp == 0 {
-							ties++
-						}
-						total++
-					}
+			fullBoard := append(turnBoard, river)
+			heroHand := c.evaluate(hero, fullBoard)
+			tally.categories.add(heroHand)
+
+			// Evaluate against each opponent combo
+			runout := cards.NewCardMask(turn, river)
+			for _, oppCombo := range opponentRange {
+				// Skip if opponent has turn or river
+				if oppCombo.Mask().Overlaps(runout) {
+					continue
 				}
+
+				oppHand := c.evaluate(oppCombo.Cards(), fullBoard)
+
+				tally.add(heroHand.Compare(oppHand))
 			}
 		}
 	}
 
-	if total == 0 {
-		return EquityResult{Equity: 0.5}
+	return tally.result()
+}
+
+// preflopEquitySamples is the number of runouts calculatePreflopEquity deals
+// Hero's equity is within about ±0.5% at this count.
+const preflopEquitySamples = 20000
+
+// calculatePreflopEquity handles boards of 0-2 cards by sampling full runouts
+// There are 1.7 million five-card runouts preflop, so preflopEquitySamples of
+// them are dealt instead, each shown down against every opponent combo it
+// doesn't collide with. The generator is seeded from hero and the board, so a
+// given spot always gets the same answer.
+func (c *Calculator) calculatePreflopEquity(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) DetailedEquityResult {
+	usedCards := cards.NewCardMask(hero...).With(board...)
+
+	// Opponent combos that collide with known cards are impossible
+	validCombos := make([]notation.Combo, 0, len(opponentRange))
+	for _, combo := range opponentRange {
+		if !usedCards.Overlaps(combo.Mask()) {
+			validCombos = append(validCombos, combo)
+		}
 	}
 
-	winPct := wins / total
-	tiePct := ties / total
-	equity := winPct + tiePct/2.0
+	seed := int64(1)
+	for _, card := range append(append([]cards.Card{}, hero...), board...) {
+		seed = seed*53 + int64(card.Index())
+	}
+	rng := rand.New(rand.NewSource(seed))
 
-	return EquityResult{
-		WinPct: winPct,
-		TiePct: tiePct,
-		Equity: equity,
+	baseDeck := c.newDeck()
+	baseDeck.Remove(hero...)
+	baseDeck.Remove(board...)
+
+	var tally equityTally
+	for i := 0; i < preflopEquitySamples; i++ {
+		runout := baseDeck.Clone().Deal(rng, 5-len(board))
+		fullBoard := append(append([]cards.Card{}, board...), runout...)
+		dealt := cards.NewCardMask(runout...)
+
+		heroHand := c.evaluate(hero, fullBoard)
+		tally.categories.add(heroHand)
+
+		for _, oppCombo := range validCombos {
+			if dealt.Overlaps(oppCombo.Mask()) {
+				continue
+			}
+			oppHand := c.evaluate(oppCombo.Cards(), fullBoard)
+			tally.add(heroHand.Compare(oppHand))
+		}
 	}
+
+	return tally.result()
 }
 
+// Hand potential states relative to the opponent
+const (
+	potAhead = iota
+	potTied
+	potBehind
+)
+
 // CalculatePotential computes hand improvement potential
-// Only works for flop (3 cards) - returns zero for turn/river
-// Simplified version: measures equity variance across runouts as a proxy for potential
-// High variance = drawing hand (high potential), low variance = made hand (low potential)
+// Works for flop (3 cards, two-card lookahead) and turn (4 cards, one-card lookahead);
+// returns zero for river. Uses the classic hand potential (HP) matrix: each opponent
+// combo is classified as ahead/tied/behind now and again on every runout, and
+// Ppot/Npot are derived from the transitions, with ties counting half in both
+// the numerators and the denominators.
 func (c *Calculator) CalculatePotential(hero []cards.Card, board []cards.Card, opponentRange []notation.Combo) PotentialResult {
-	// Only calculate potential for flop
-	if len(board) != 3 {
+	if len(board) != 3 && len(board) != 4 {
 		return PotentialResult{}
 	}
 
-	usedCards := makeCardSet(append(hero, board...))
-
-	// Sample different turn cards and calculate equity on each
-	var equities []float64
-	sampleTurns := 0
-	maxSamples := 10 // Sample 10 turn cards for efficiency
-
-	for turnRank := cards.Two; turnRank <= cards.Ace && sampleTurns < maxSamples; turnRank++ {
-		for turnSuit := cards.Spades; turnSuit <= cards.Clubs && sampleTurns < maxSamples; turnSuit++ {
-			turn := cards.Card{Rank: turnRank, Suit: turnSuit}
-			if usedCards[turn] {
-				continue
-			}
+	usedCards := cards.NewCardMask(hero...).With(board...)
 
-			// Calculate equity on this turn
-			turnBoard := append(board, turn)
-			result := c.calculateTurnEquity(hero, turnBoard, opponentRange)
-			equities = append(equities, result.Equity)
-			sampleTurns++
+	// Classify the current state against each valid opponent combo
+	heroNow := c.evaluate(hero, board)
+	var oppCombos []notation.Combo
+	var oppStates []int
+	for _, combo := range opponentRange {
+		if usedCards.Overlaps(combo.Mask()) {
+			continue
 		}
+		oppHand := c.evaluate(combo.Cards(), board)
+		oppCombos = append(oppCombos, combo)
+		oppStates = append(oppStates, potentialState(heroNow.Compare(oppHand)))
 	}
 
-	if len(equities) == 0 {
+	if len(oppCombos) == 0 {
 		return PotentialResult{}
 	}
 
-	// Calculate mean equity
-	mean := 0.0
-	for _, eq := range equities {
-		mean += eq
+	var deck []cards.Card
+	for _, card := range c.newDeck().Cards() {
+		if !usedCards.Has(card) {
+			deck = append(deck, card)
+		}
 	}
-	mean /= float64(len(equities))
 
-	// Calculate variance
-	variance := 0.0
-	for _, eq := range equities {
-		diff := eq - mean
-		variance += diff * diff
-	}
-	variance /= float64(len(equities))
+	// hp[now][final] counts runouts transitioning between states
+	var hp [3][3]float64
+
+	tally := func(runout []cards.Card) {
+		fullBoard := append(append([]cards.Card{}, board...), runout...)
+		heroHand := c.evaluate(hero, fullBoard)
+
+		dealt := cards.NewCardMask(runout...)
+		for i, combo := range oppCombos {
+			if combo.Mask().Overlaps(dealt) {
+				continue
+			}
 
-	// Standard deviation as potential metric
-	stdDev := 0.0
-	if variance > 0 {
-		// Use sqrt for standard deviation
-		stdDev = variance // For simplicity, use variance directly (already small)
+			oppHand := c.evaluate(combo.Cards(), fullBoard)
+			hp[oppStates[i]][potentialState(heroHand.Compare(oppHand))]++
+		}
 	}
 
-	// Map variance to potential metrics:
-	// Variance ranges from 0 (no change across runouts) to ~0.25 (max variance at 50/50)
-	// - High variance (>0.05) = drawing hand with high potential
-	// - Low variance (<0.01) = made hand with low potential
+	if len(board) == 3 {
+		for i := 0; i < len(deck); i++ {
+			for j := i + 1; j < len(deck); j++ {
+				tally([]cards.Card{deck[i], deck[j]})
+			}
+		}
+	} else {
+		for _, card := range deck {
+			tally([]cards.Card{card})
+		}
+	}
 
-	// Normalize variance to 0-1 range for potential
-	// Max theoretical variance is 0.25 (at 50/50 split)
-	normalizedVar := stdDev / 0.25
-	if normalizedVar > 1.0 {
-		normalizedVar = 1.0
+	var rowTotal [3]float64
+	total := 0.0
+	for now := range hp {
+		for final := range hp[now] {
+			rowTotal[now] += hp[now][final]
+		}
+		total += rowTotal[now]
 	}
 
-	// Positive potential: If currently behind, potential to improve
+	// Ppot: chance of getting ahead when currently behind or tied
 	positivePot := 0.0
-	if mean < 0.5 {
-		// Behind with high variance = high positive potential
-		positivePot = normalizedVar
+	if denom := rowTotal[potBehind] + rowTotal[potTied]/2; denom > 0 {
+		positivePot = (hp[potBehind][potAhead] + hp[potBehind][potTied]/2 + hp[potTied][potAhead]/2) / denom
 	}
 
-	// Negative potential: If currently ahead, risk of getting outdrawn
+	// Npot: chance of falling behind when currently ahead or tied
 	negativePot := 0.0
-	if mean > 0.5 {
-		// Ahead with high variance = high negative potential (vulnerable)
-		negativePot = normalizedVar
+	if denom := rowTotal[potAhead] + rowTotal[potTied]/2; denom > 0 {
+		negativePot = (hp[potAhead][potBehind] + hp[potTied][potBehind]/2 + hp[potAhead][potTied]/2) / denom
 	}
 
-	// Improvement percentage: overall volatility
-	improvePct := normalizedVar
+	// Improvement: fraction of all runouts where hero's standing gets better
+	improvePct := 0.0
+	if total > 0 {
+		improvePct = (hp[potBehind][potAhead] + hp[potBehind][potTied] + hp[potTied][potAhead]) / total
+	}
 
 	return PotentialResult{
 		PositivePot: positivePot,
@@ -304,11 +641,36 @@ func (c *Calculator) CalculatePotential(hero []cards.Card, board []cards.Card, o
 	}
 }
 
-// makeCardSet creates a set of cards for fast lookup
-func makeCardSet(cardList []cards.Card) map[cards.Card]bool {
-	set := make(map[cards.Card]bool)
-	for _, c := range cardList {
-		set[c] = true
+// potentialState maps a hand comparison to ahead/tied/behind
+func potentialState(cmp int) int {
+	if cmp > 0 {
+		return potAhead
+	} else if cmp == 0 {
+		return potTied
+	}
+	return potBehind
+}
+
+// handCategories counts hero's hand category per evaluated runout
+type handCategories struct {
+	counts map[cards.HandRank]float64
+	total  float64
+}
+
+// add records hero's hand on one runout
+func (h *handCategories) add(hand cards.HandValue) {
+	if h.counts == nil {
+		h.counts = make(map[cards.HandRank]float64)
+	}
+	h.counts[hand.Rank]++
+	h.total++
+}
+
+// fractions returns each category's share of the recorded runouts
+func (h *handCategories) fractions() map[cards.HandRank]float64 {
+	result := make(map[cards.HandRank]float64, len(h.counts))
+	for rank, count := range h.counts {
+		result[rank] = count / h.total
 	}
-	return set
+	return result
 }
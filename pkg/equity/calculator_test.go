@@ -2,6 +2,7 @@ package equity
 
 import (
 	"math"
+	"reflect"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -72,6 +73,32 @@ func TestCalculateEquity_TurnOverpair(t *testing.T) {
 	}
 }
 
+func TestCalculateEquityDetailed_TurnCounts(t *testing.T) {
+	calc := NewCalculator()
+
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh9s4c7d")
+	oppRange, _ := notation.ParseRange("QQ")
+
+	result := calc.CalculateEquityDetailed(hero, board, oppRange)
+
+	// 46 rivers × 6 QQ combos, less the 3 combos each queen river blocks
+	if want := int64(46*6 - 4*3); result.Total != want {
+		t.Errorf("Total = %d, want %d", result.Total, want)
+	}
+	if result.Wins+result.Ties > result.Total {
+		t.Errorf("Wins %d + Ties %d exceed Total %d", result.Wins, result.Ties, result.Total)
+	}
+
+	// The counts are the percentages, and agree with CalculateEquity
+	if got := float64(result.Wins) / float64(result.Total); got != result.WinPct {
+		t.Errorf("Wins/Total = %v, WinPct = %v", got, result.WinPct)
+	}
+	if plain := calc.CalculateEquity(hero, board, oppRange); !reflect.DeepEqual(plain, result.EquityResult) {
+		t.Errorf("CalculateEquity = %+v, detailed = %+v", plain, result.EquityResult)
+	}
+}
+
 func TestCalculateEquity_FlopOverpair(t *testing.T) {
 	calc := NewCalculator()
 
@@ -201,7 +228,209 @@ func TestCalculateEquity_CoinFlip(t *testing.T) {
 	t.Logf("AK vs QQ on 9-7-2 flop: Equity=%.1f%%", result.Equity*100)
 }
 
+func TestCalculateEquity_Preflop(t *testing.T) {
+	calc := NewCalculator()
+
+	// AKs vs 22 is the classic preflop coinflip, within a couple of points of 50/50
+	hero, _ := cards.ParseCards("AhKh")
+	oppRange, _ := notation.ParseRange("22")
+
+	result := calc.CalculateEquityDetailed(hero, nil, oppRange)
+	if result.Equity < 0.46 || result.Equity > 0.54 {
+		t.Errorf("Expected AKs equity ~50%% vs 22 preflop, got %.1f%%", result.Equity*100)
+	}
+	if result.Total == 0 || result.Wins+result.Ties > result.Total {
+		t.Errorf("Inconsistent counts: %d wins, %d ties, %d showdowns", result.Wins, result.Ties, result.Total)
+	}
+
+	// The pair's side of the flip is close to the complement
+	pair, _ := cards.ParseCards("2c2d")
+	akRange, _ := notation.ParseRange("AKs")
+	reverse := calc.CalculateEquity(pair, nil, akRange)
+	if reverse.Equity < 0.46 || reverse.Equity > 0.54 {
+		t.Errorf("Expected 22 equity ~50%% vs AKs preflop, got %.1f%%", reverse.Equity*100)
+	}
+
+	// Sampling is seeded from the cards, so repeats agree
+	if again := calc.CalculateEquity(hero, nil, oppRange); again.Equity != result.Equity {
+		t.Errorf("Preflop equity changed between calls: %v vs %v", result.Equity, again.Equity)
+	}
+
+	t.Logf("AKs vs 22 preflop: %.1f%% / %.1f%%", result.Equity*100, reverse.Equity*100)
+}
+
+func TestCalculateEquityMC_ConvergesToExact(t *testing.T) {
+	calc := NewCalculator()
+
+	// Hero: AhKh (flush draw), Board: Th-9h-2c (flop), Opponent: AsAd (overpair)
+	hero, _ := cards.ParseCards("AhKh")
+	board, _ := cards.ParseCards("Th9h2c")
+	oppRange := []notation.Combo{
+		{Card1: cards.Card{Rank: cards.Ace, Suit: cards.Spades}, Card2: cards.Card{Rank: cards.Ace, Suit: cards.Diamonds}},
+	}
+
+	exact := calc.CalculateEquity(hero, board, oppRange)
+	mc := calc.CalculateEquityMC(hero, board, oppRange, 20000, 42)
+
+	// Standard error at 20k samples is ~0.35%, so 1% is a comfortable bound
+	if math.Abs(mc.Equity-exact.Equity) > 0.01 {
+		t.Errorf("MC equity %.4f too far from exact %.4f", mc.Equity, exact.Equity)
+	}
+	if math.Abs(mc.WinPct-exact.WinPct) > 0.01 {
+		t.Errorf("MC win pct %.4f too far from exact %.4f", mc.WinPct, exact.WinPct)
+	}
+	if math.Abs(mc.TiePct-exact.TiePct) > 0.01 {
+		t.Errorf("MC tie pct %.4f too far from exact %.4f", mc.TiePct, exact.TiePct)
+	}
+
+	// Same seed gives the same estimate
+	again := calc.CalculateEquityMC(hero, board, oppRange, 20000, 42)
+	if !reflect.DeepEqual(again, mc) {
+		t.Errorf("Expected identical results for identical seeds, got %+v and %+v", mc, again)
+	}
+
+	t.Logf("AhKh vs AsAd on Th-9h-2c: exact=%.2f%%, MC=%.2f%%", exact.Equity*100, mc.Equity*100)
+}
+
+func TestCalculateEquityMC_RiverExact(t *testing.T) {
+	calc := NewCalculator()
+
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	oppRange := []notation.Combo{
+		{Card1: cards.Card{Rank: cards.Queen, Suit: cards.Diamonds}, Card2: cards.Card{Rank: cards.Queen, Suit: cards.Hearts}},
+		{Card1: cards.Card{Rank: cards.King, Suit: cards.Diamonds}, Card2: cards.Card{Rank: cards.King, Suit: cards.Clubs}},
+	}
+
+	// River is enumerated exactly regardless of sample count
+	mc := calc.CalculateEquityMC(hero, board, oppRange, 1, 7)
+	exact := calc.CalculateEquity(hero, board, oppRange)
+	if !reflect.DeepEqual(mc, exact) {
+		t.Errorf("Expected exact river equity %+v, got %+v", exact, mc)
+	}
+}
+
+func TestCalculateEquityMC_BlockedRange(t *testing.T) {
+	calc := NewCalculator()
+
+	// Every opponent combo conflicts with hero's cards
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh9s4c")
+	oppRange := []notation.Combo{
+		{Card1: cards.Card{Rank: cards.Ace, Suit: cards.Diamonds}, Card2: cards.Card{Rank: cards.Ace, Suit: cards.Hearts}},
+	}
+
+	result := calc.CalculateEquityMC(hero, board, oppRange, 1000, 1)
+	if result.Equity != 0.5 {
+		t.Errorf("Expected default equity 0.5 with no valid combos, got %.2f", result.Equity)
+	}
+}
+
 // Benchmark flop equity calculation (most expensive)
+func TestCalculateEquity_HeroHandBuckets(t *testing.T) {
+	calc := NewCalculator()
+
+	// Nut flush draw on the flop: 9 hearts left, so hero gets there on
+	// 1 - (38/47 × 37/46) ≈ 35% of turn/river runouts
+	hero, _ := cards.ParseCards("AhKh")
+	board, _ := cards.ParseCards("Th9h2c")
+	oppRange := []notation.Combo{
+		{Card1: cards.Card{Rank: cards.Ace, Suit: cards.Spades}, Card2: cards.Card{Rank: cards.Ace, Suit: cards.Diamonds}},
+	}
+
+	result := calc.CalculateEquity(hero, board, oppRange)
+
+	flush := result.HeroHandBuckets[cards.Flush]
+	if flush < 0.30 || flush > 0.40 {
+		t.Errorf("Expected flush on ~35%% of runouts, got %.1f%%", flush*100)
+	}
+
+	sum := 0.0
+	for _, frac := range result.HeroHandBuckets {
+		sum += frac
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Hand category fractions sum to %.4f, want 1", sum)
+	}
+
+	// River: one runout, one category
+	river, _ := cards.ParseCards("Th9h2c7h3s")
+	riverResult := calc.CalculateEquity(hero, river, oppRange)
+	if riverResult.HeroHandBuckets[cards.Flush] != 1 {
+		t.Errorf("Expected a made flush on the river, got %v", riverResult.HeroHandBuckets)
+	}
+}
+
+func TestCalculateEquityMultiway_LowerThanHeadsUp(t *testing.T) {
+	calc := NewCalculator()
+
+	// AA vs QQ and JJ on a dry flop: hero must beat both to win
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh7d2c")
+	qq, _ := notation.ParseRange("QQ")
+	jj, _ := notation.ParseRange("JJ")
+
+	multiway := calc.CalculateEquityMultiway(hero, board, [][]notation.Combo{qq, jj})
+	vsQQ := calc.CalculateEquity(hero, board, qq)
+	vsJJ := calc.CalculateEquity(hero, board, jj)
+
+	if multiway.Equity >= vsQQ.Equity || multiway.Equity >= vsJJ.Equity {
+		t.Errorf("Expected 3-way equity %.4f below heads-up vs QQ (%.4f) and vs JJ (%.4f)",
+			multiway.Equity, vsQQ.Equity, vsJJ.Equity)
+	}
+
+	t.Logf("AA vs {QQ}{JJ} on Kh7d2c: %.2f%% (vs QQ %.2f%%, vs JJ %.2f%%)",
+		multiway.Equity*100, vsQQ.Equity*100, vsJJ.Equity*100)
+}
+
+func TestCalculateEquityMultiway_SingleRangeMatchesHeadsUp(t *testing.T) {
+	calc := NewCalculator()
+
+	hero, _ := cards.ParseCards("AhKh")
+	board, _ := cards.ParseCards("Th9h2c7s")
+	oppRange, _ := notation.ParseRange("QQ,JJ,86s")
+
+	multiway := calc.CalculateEquityMultiway(hero, board, [][]notation.Combo{oppRange})
+	headsUp := calc.CalculateEquity(hero, board, oppRange)
+
+	if math.Abs(multiway.Equity-headsUp.Equity) > 1e-9 || math.Abs(multiway.TiePct-headsUp.TiePct) > 1e-9 {
+		t.Errorf("Single-range multiway %+v differs from heads-up %+v", multiway, headsUp)
+	}
+}
+
+func TestCalculateEquityMultiway_SplitPot(t *testing.T) {
+	calc := NewCalculator()
+
+	// Royal flush on board: all three players chop
+	hero, _ := cards.ParseCards("2d3d")
+	board, _ := cards.ParseCards("AsKsQsJsTs")
+	opp1, _ := notation.ParseRange("44")
+	opp2, _ := notation.ParseRange("55")
+
+	result := calc.CalculateEquityMultiway(hero, board, [][]notation.Combo{opp1, opp2})
+	if result.TiePct != 1 || math.Abs(result.Equity-1.0/3.0) > 1e-9 {
+		t.Errorf("Expected a three-way chop (equity 1/3), got %+v", result)
+	}
+}
+
+func TestCalculateEquityMultiwayMC_ConvergesToExact(t *testing.T) {
+	calc := NewCalculator()
+
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh7d2c")
+	qq, _ := notation.ParseRange("QQ")
+	jj, _ := notation.ParseRange("JJ")
+	ranges := [][]notation.Combo{qq, jj}
+
+	exact := calc.CalculateEquityMultiway(hero, board, ranges)
+	mc := calc.CalculateEquityMultiwayMC(hero, board, ranges, 10000, 42)
+
+	// Standard error at 10k samples is ~0.4%
+	if math.Abs(mc.Equity-exact.Equity) > 0.015 {
+		t.Errorf("MC multiway equity %.4f too far from exact %.4f", mc.Equity, exact.Equity)
+	}
+}
+
 func BenchmarkCalculateEquity_Flop(b *testing.B) {
 	calc := NewCalculator()
 	hero, _ := cards.ParseCards("AdAc")
@@ -216,6 +445,21 @@ func BenchmarkCalculateEquity_Flop(b *testing.B) {
 	}
 }
 
+// Benchmark Monte Carlo flop equity calculation
+func BenchmarkCalculateEquityMC_Flop(b *testing.B) {
+	calc := NewCalculator()
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh9s4c")
+	oppRange := []notation.Combo{
+		{Card1: cards.Card{Rank: cards.Queen, Suit: cards.Diamonds}, Card2: cards.Card{Rank: cards.Queen, Suit: cards.Hearts}},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		calc.CalculateEquityMC(hero, board, oppRange, 1000, int64(i))
+	}
+}
+
 // Benchmark turn equity calculation
 func BenchmarkCalculateEquity_Turn(b *testing.B) {
 	calc := NewCalculator()
@@ -261,11 +505,14 @@ func TestCalculatePotential_FlushDraw(t *testing.T) {
 
 	result := calc.CalculatePotential(hero, board, oppRange)
 
-	// Flush draw should have positive potential (behind but can improve)
-	// Variance-based: flush draws have volatile equity across runouts
-	// Expected: >20% positive potential (equity varies significantly)
-	if result.PositivePot < 0.15 {
-		t.Errorf("Expected flush draw positive potential >15%%, got %.1f%%", result.PositivePot*100)
+	// Flush draw is behind now but gets ahead on ~35% of runouts (9 outs twice)
+	if result.PositivePot < 0.30 {
+		t.Errorf("Expected flush draw positive potential >30%%, got %.1f%%", result.PositivePot*100)
+	}
+
+	// Hero is never ahead on the flop, so there is nothing to lose
+	if result.NegativePot > 0.05 {
+		t.Errorf("Expected flush draw negative potential ~0%%, got %.1f%%", result.NegativePot*100)
 	}
 
 	t.Logf("Flush draw potential: PPot=%.1f%%, NPot=%.1f%%, Improve=%.1f%%",
@@ -285,10 +532,16 @@ func TestCalculatePotential_MadeHand(t *testing.T) {
 
 	result := calc.CalculatePotential(hero, board, oppRange)
 
-	// Made hand should have lower variance than pure drawing hands
-	// Variance-based: even strong hands can have equity swings if opponent has outs
-	// Expected: improve% exists but reasonable (can vary significantly with small pairs catching up)
-	// Just log the result - variance can be high if opponent has runner-runner outs
+	// Dry overpair is already ahead: no positive potential
+	if result.PositivePot != 0 {
+		t.Errorf("Expected zero positive potential for made hand, got %.1f%%", result.PositivePot*100)
+	}
+
+	// 22 only gets there with a set (2 outs twice) or runner-runner
+	if result.NegativePot > 0.12 {
+		t.Errorf("Expected low negative potential for dry overpair, got %.1f%%", result.NegativePot*100)
+	}
+
 	if result.ImprovePct < 0 || result.ImprovePct > 1.0 {
 		t.Errorf("Expected valid improve percentage 0-100, got %.1f%%", result.ImprovePct*100)
 	}
@@ -310,12 +563,10 @@ func TestCalculatePotential_WeakDraw(t *testing.T) {
 
 	result := calc.CalculatePotential(hero, board, oppRange)
 
-	// Gutshot should have moderate variance (some outs but not many)
-	// Variance-based: weaker draws have lower equity volatility than flush draws
-	// Expected: Some potential but less than flush draw
-	// Just check it's non-negative (weak draws may have low variance)
-	if result.PositivePot < 0 {
-		t.Errorf("Expected non-negative positive potential, got %.1f%%", result.PositivePot*100)
+	// 76 on 9-8 is actually open-ended (5 or T), plus runner-runner pairs
+	// Just check it's a valid probability
+	if result.PositivePot < 0 || result.PositivePot > 1.0 {
+		t.Errorf("Expected valid positive potential, got %.1f%%", result.PositivePot*100)
 	}
 
 	t.Logf("Gutshot potential: PPot=%.1f%%, NPot=%.1f%%, Improve=%.1f%%",
@@ -335,11 +586,14 @@ func TestCalculatePotential_VulnerableMadeHand(t *testing.T) {
 
 	result := calc.CalculatePotential(hero, board, oppRange)
 
-	// Vulnerable made hand should have some variance (drawy board)
-	// Variance-based: equity swings across different runouts
-	// Expected: some improve% indicating volatility (>2%)
-	if result.ImprovePct < 0.02 {
-		t.Errorf("Expected vulnerable hand volatility >2%%, got %.1f%%", result.ImprovePct*100)
+	// Opponent has trips/two-pair outs plus backdoor draws
+	// Expected: meaningful negative potential (>20%)
+	if result.NegativePot < 0.20 {
+		t.Errorf("Expected vulnerable hand negative potential >20%%, got %.1f%%", result.NegativePot*100)
+	}
+
+	if result.PositivePot != 0 {
+		t.Errorf("Expected zero positive potential when ahead, got %.1f%%", result.PositivePot*100)
 	}
 
 	t.Logf("Vulnerable hand potential: PPot=%.1f%%, NPot=%.1f%%, Improve=%.1f%%",
@@ -349,8 +603,7 @@ func TestCalculatePotential_VulnerableMadeHand(t *testing.T) {
 func TestCalculatePotential_TurnAndRiver(t *testing.T) {
 	calc := NewCalculator()
 
-	// Potential only works on flop (3 cards)
-	// Turn and river should return zero potential
+	// Turn uses a one-card lookahead; river has no potential
 	hero, _ := cards.ParseCards("AdAc")
 	turnBoard, _ := cards.ParseCards("Kh9s4c7d")
 	riverBoard, _ := cards.ParseCards("Kh9s4c7d2s")
@@ -361,9 +614,13 @@ func TestCalculatePotential_TurnAndRiver(t *testing.T) {
 	turnResult := calc.CalculatePotential(hero, turnBoard, oppRange)
 	riverResult := calc.CalculatePotential(hero, riverBoard, oppRange)
 
-	if turnResult.PositivePot != 0 || turnResult.NegativePot != 0 {
-		t.Errorf("Expected zero potential for turn, got PPot=%.1f%%, NPot=%.1f%%",
-			turnResult.PositivePot*100, turnResult.NegativePot*100)
+	// AA loses only to the 2 remaining queens out of 44 rivers
+	expectedNpot := 2.0 / 44.0
+	if math.Abs(turnResult.NegativePot-expectedNpot) > 1e-9 {
+		t.Errorf("Expected turn NPot %.4f, got %.4f", expectedNpot, turnResult.NegativePot)
+	}
+	if turnResult.PositivePot != 0 {
+		t.Errorf("Expected zero turn PPot when ahead, got %.1f%%", turnResult.PositivePot*100)
 	}
 
 	if riverResult.PositivePot != 0 || riverResult.NegativePot != 0 {
@@ -372,6 +629,33 @@ func TestCalculatePotential_TurnAndRiver(t *testing.T) {
 	}
 }
 
+func TestCalculatePotential_TiedCountsHalf(t *testing.T) {
+	calc := NewCalculator()
+
+	// Both players hold the same straight; 9 diamond rivers give JdTd a flush
+	board, _ := cards.ParseCards("AsKdQh7d")
+	draw, _ := cards.ParseCards("JdTd")
+	made, _ := cards.ParseCards("JcTc")
+
+	// Every river starts tied: 9 of the 44 end ahead, so Ppot is 4.5 / 22
+	result := calc.CalculatePotential(draw, board, []notation.Combo{{Card1: made[0], Card2: made[1]}})
+	if want := 9.0 / 44.0; math.Abs(result.PositivePot-want) > 1e-9 {
+		t.Errorf("tied draw PPot = %.4f, want %.4f", result.PositivePot, want)
+	}
+	if result.NegativePot != 0 {
+		t.Errorf("tied draw NPot = %.4f, want 0", result.NegativePot)
+	}
+
+	// The same runouts from the other side fall behind
+	result = calc.CalculatePotential(made, board, []notation.Combo{{Card1: draw[0], Card2: draw[1]}})
+	if want := 9.0 / 44.0; math.Abs(result.NegativePot-want) > 1e-9 {
+		t.Errorf("tied made hand NPot = %.4f, want %.4f", result.NegativePot, want)
+	}
+	if result.PositivePot != 0 {
+		t.Errorf("tied made hand PPot = %.4f, want 0", result.PositivePot)
+	}
+}
+
 // Benchmark potential calculation
 func BenchmarkCalculatePotential_Flop(b *testing.B) {
 	calc := NewCalculator()
@@ -386,3 +670,32 @@ func BenchmarkCalculatePotential_Flop(b *testing.B) {
 		calc.CalculatePotential(hero, board, oppRange)
 	}
 }
+
+// lowCardEvaluator is a stub where the lowest first hole card wins
+type lowCardEvaluator struct{}
+
+func (lowCardEvaluator) Evaluate(hole []cards.Card, board []cards.Card) cards.HandValue {
+	return cards.HandValue{Rank: cards.HighCard, Values: [5]cards.Rank{cards.Ace - hole[0].Rank}}
+}
+
+func TestCalculator_SetEvaluator(t *testing.T) {
+	hero, _ := cards.ParseCards("AdAc")
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	oppRange, _ := notation.ParseRange("QQ")
+
+	calc := NewCachingCalculator(4)
+	if result := calc.CalculateEquity(hero, board, oppRange); result.Equity != 1.0 {
+		t.Fatalf("default evaluator: expected AA equity 1.0, got %.2f", result.Equity)
+	}
+
+	// Swapping the evaluator discards cached results
+	calc.SetEvaluator(lowCardEvaluator{})
+	if result := calc.CalculateEquity(hero, board, oppRange); result.Equity != 0.0 {
+		t.Errorf("stub evaluator: expected AA equity 0.0, got %.2f", result.Equity)
+	}
+
+	turn := board[:4]
+	if result := calc.CalculateEquityMultiway(hero, turn, [][]notation.Combo{oppRange}); result.Equity != 0.0 {
+		t.Errorf("stub evaluator multiway: expected AA equity 0.0, got %.2f", result.Equity)
+	}
+}
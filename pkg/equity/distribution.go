@@ -0,0 +1,59 @@
+package equity
+
+import (
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// RangeEquityDistribution returns a histogram of each hero combo's equity against
+// oppRange on board, using a default Calculator (see Calculator.RangeEquityDistribution)
+func RangeEquityDistribution(heroRange []notation.Combo, board []cards.Card, oppRange []notation.Combo, bins int) []float64 {
+	return NewCalculator().RangeEquityDistribution(heroRange, board, oppRange, bins)
+}
+
+// RangeEquityDistribution returns a histogram of each hero combo's equity against oppRange
+// Bin i covers equities in [i/bins, (i+1)/bins), with equity 1 in the last bin. Values
+// are fractions of the hero combos, so they sum to 1. A polarized range piles up in
+// the end bins; a condensed one in the middle. Each hero combo plays only the
+// opponent combos that share no card with it or the board. Hero combos blocked by
+// the board, or that block the whole opponent range, are skipped; bins < 1 or no
+// live combos returns nil.
+func (c *Calculator) RangeEquityDistribution(heroRange []notation.Combo, board []cards.Card, oppRange []notation.Combo, bins int) []float64 {
+	if bins < 1 {
+		return nil
+	}
+
+	oppRange = notation.RemoveBlockers(oppRange, board)
+
+	histogram := make([]float64, bins)
+	counted := 0
+	for _, combo := range heroRange {
+		if combo.Conflicts(board) {
+			continue
+		}
+		hero := combo.Cards()
+		opponents := notation.RemoveBlockers(oppRange, hero)
+		if len(opponents) == 0 {
+			continue
+		}
+
+		result := c.CalculateEquity(hero, board, opponents)
+		bin := int(result.Equity * float64(bins))
+		if bin >= bins {
+			bin = bins - 1
+		}
+		if bin < 0 {
+			bin = 0
+		}
+		histogram[bin]++
+		counted++
+	}
+
+	if counted == 0 {
+		return nil
+	}
+	for i := range histogram {
+		histogram[i] /= float64(counted)
+	}
+	return histogram
+}
@@ -0,0 +1,68 @@
+package equity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestRangeEquityDistribution(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	oppRange, _ := notation.ParseRange("QQ,TT")
+
+	// Top set always wins: every combo lands in the top bin
+	nuts, _ := notation.ParseRange("KK")
+	histogram := RangeEquityDistribution(nuts, board, oppRange, 10)
+	if len(histogram) != 10 {
+		t.Fatalf("got %d bins, want 10", len(histogram))
+	}
+	if histogram[9] != 1 {
+		t.Errorf("nutted range histogram = %v, want everything in the top bin", histogram)
+	}
+
+	// Sets, a middling pair, and an underpair spread across the bins
+	mixed, _ := notation.ParseRange("KK,JJ,33")
+	histogram = RangeEquityDistribution(mixed, board, oppRange, 10)
+	sum, occupied := 0.0, 0
+	for _, fraction := range histogram {
+		sum += fraction
+		if fraction > 0 {
+			occupied++
+		}
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("histogram sums to %v, want 1", sum)
+	}
+	if occupied < 3 {
+		t.Errorf("mixed range histogram = %v, want at least 3 occupied bins", histogram)
+	}
+	if histogram[0] == 0 || histogram[9] == 0 {
+		t.Errorf("mixed range histogram = %v, want mass at both ends", histogram)
+	}
+
+	if got := RangeEquityDistribution(mixed, board, oppRange, 0); got != nil {
+		t.Errorf("zero bins returned %v, want nil", got)
+	}
+}
+
+func TestRangeEquityDistribution_Blockers(t *testing.T) {
+	board, _ := cards.ParseCards("2c3d7h9sJc")
+	oppRange, _ := notation.ParseRange("AA,KK")
+
+	// AsAh leaves one AA combo (a tie) and six KK (wins): equity 6.5/7 = 0.93.
+	// Counting the five blocked aces as ties would put it at 0.75.
+	hero, _ := notation.ParseRange("AsAh")
+	histogram := RangeEquityDistribution(hero, board, oppRange, 10)
+	if histogram[9] != 1 {
+		t.Errorf("AsAh histogram = %v, want everything in the top bin", histogram)
+	}
+
+	// A hero combo with no live opponent combos is skipped
+	blocked, _ := notation.ParseRange("AsAh")
+	onlyAces, _ := notation.ParseRange("AsAd,AhAd")
+	if got := RangeEquityDistribution(blocked, board, onlyAces, 10); got != nil {
+		t.Errorf("fully blocked histogram = %v, want nil", got)
+	}
+}
@@ -0,0 +1,41 @@
+package equity
+
+import (
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// RangeVsRange returns each range's equity against the other on board, using a
+// default Calculator (see Calculator.RangeVsRange)
+func RangeVsRange(range0, range1 []notation.Combo, board []cards.Card) (eq0, eq1 float64) {
+	return NewCalculator().RangeVsRange(range0, range1, board)
+}
+
+// RangeVsRange returns each range's equity against the other on a 3-5 card board
+// Every combo pair that shares no card with the board or with each other is
+// equally likely, the same pairs BuildRange deals, and ties count half to each
+// side, so eq0+eq1 = 1. Returns 0, 0 if no pair is possible.
+func (c *Calculator) RangeVsRange(range0, range1 []notation.Combo, board []cards.Card) (eq0, eq1 float64) {
+	range1 = notation.RemoveBlockers(range1, board)
+
+	total, pairs := 0.0, 0
+	for _, combo := range notation.RemoveBlockers(range0, board) {
+		hero := combo.Cards()
+		opponents := notation.RemoveBlockers(range1, hero)
+		if len(opponents) == 0 {
+			continue
+		}
+
+		// The combo's equity averages over its opponent pairs; weighting it by
+		// their count makes every pair count once
+		result := c.CalculateEquity(hero, board, opponents)
+		total += result.Equity * float64(len(opponents))
+		pairs += len(opponents)
+	}
+
+	if pairs == 0 {
+		return 0, 0
+	}
+	eq0 = total / float64(pairs)
+	return eq0, 1 - eq0
+}
@@ -0,0 +1,68 @@
+package equity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestRangeVsRange(t *testing.T) {
+	// Blank river: AA beats QQ every time
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	aces, _ := notation.ParseRange("AA")
+	queens, _ := notation.ParseRange("QQ")
+	eq0, eq1 := RangeVsRange(aces, queens, board)
+	if eq0 != 1 || eq1 != 0 {
+		t.Errorf("AA vs QQ = %.4f / %.4f, want 1 / 0", eq0, eq1)
+	}
+
+	// Broadway on board: both ranges play the board and chop
+	board, _ = cards.ParseCards("AhKdQcJsTh")
+	eq0, eq1 = RangeVsRange(aces, queens, board)
+	if math.Abs(eq0-0.5) > 1e-12 || math.Abs(eq1-0.5) > 1e-12 {
+		t.Errorf("chopped board = %.4f / %.4f, want 0.5 / 0.5", eq0, eq1)
+	}
+
+	// Pairs sharing a card are skipped: AA vs AK on a blank river, where AA
+	// only ever faces the AK combos it doesn't block
+	ak, _ := notation.ParseRange("AKo,AKs")
+	board, _ = cards.ParseCards("2c7d9hJs3s")
+	eq0, eq1 = RangeVsRange(aces, ak, board)
+	if eq0 != 1 || eq1 != 0 {
+		t.Errorf("AA vs AK = %.4f / %.4f, want 1 / 0", eq0, eq1)
+	}
+
+	asah, _ := notation.ParseRange("AsAh")
+	asad, _ := notation.ParseRange("AsAd")
+	if eq0, eq1 := RangeVsRange(asah, asad, board); eq0 != 0 || eq1 != 0 {
+		t.Errorf("all pairs blocked = %.4f / %.4f, want 0 / 0", eq0, eq1)
+	}
+}
+
+func TestRangeVsRange_Turn(t *testing.T) {
+	// The QQ combo with the Qh is drawing to the other queen; compare with
+	// averaging exact pair equities
+	board, _ := cards.ParseCards("Kh9s4c7d")
+	aces, _ := notation.ParseRange("AA")
+	queens, _ := notation.ParseRange("QQ")
+
+	c := NewCalculator()
+	want, pairs := 0.0, 0
+	for _, a := range aces {
+		for _, q := range queens {
+			want += c.CalculateEquity([]cards.Card{a.Card1, a.Card2}, board, []notation.Combo{q}).Equity
+			pairs++
+		}
+	}
+	want /= float64(pairs)
+
+	eq0, eq1 := c.RangeVsRange(aces, queens, board)
+	if math.Abs(eq0-want) > 1e-12 || math.Abs(eq0+eq1-1) > 1e-12 {
+		t.Errorf("turn AA vs QQ = %.4f / %.4f, want %.4f", eq0, eq1, want)
+	}
+	if eq0 < 0.9 || eq0 >= 1 {
+		t.Errorf("turn AA vs QQ equity %.4f, want just under 1 (QQ has two outs)", eq0)
+	}
+}
@@ -0,0 +1,209 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+)
+
+// ToFEN serializes the game state back to position FEN notation
+// Inverse of ParsePosition: ParsePosition(gs.ToFEN()) yields an equivalent state
+// Example: "BTN:AsKd:S98/BB:QQ,JJ@0.5:S97|P3|Th9h2c/Js|b3.5|>BB"
+//
+// Ranges are written as hand classes (e.g., "AKs"), so every class in a range
+// must be complete with a single weight; other ranges return an error.
+func (gs *GameState) ToFEN() (string, error) {
+	if len(gs.Players) == 0 {
+		return "", fmt.Errorf("game state has no players")
+	}
+	if gs.ToAct < 0 || gs.ToAct >= len(gs.Players) {
+		return "", fmt.Errorf("invalid player to act %d", gs.ToAct)
+	}
+
+	playerStrs := make([]string, len(gs.Players))
+	for i, player := range gs.Players {
+		cardsStr, err := formatPlayerCards(player)
+		if err != nil {
+			return "", fmt.Errorf("error formatting player %s: %w", player.Position, err)
+		}
+		playerStrs[i] = fmt.Sprintf("%s:%s:S%s", player.Position, cardsStr, formatAmount(player.Stack))
+	}
+
+	// Dead money is written apart from the live pot, as parsePot reads it
+	potStr := "P" + formatAmount(gs.Pot)
+	if gs.DeadMoney > 0 {
+		potStr = "P" + formatAmount(gs.Pot-gs.DeadMoney) + "+A" + formatAmount(gs.DeadMoney)
+	}
+
+	parts := []string{
+		strings.Join(playerStrs, "/"),
+		potStr,
+		formatBoard(gs.Board),
+	}
+
+	if len(gs.ActionHistory) > 0 {
+		parts = append(parts, formatHistory(gs.ActionHistory))
+	}
+
+	parts = append(parts, ">"+string(gs.Players[gs.ToAct].Position))
+
+	return strings.Join(parts, "|"), nil
+}
+
+// formatPlayerCards formats a player's holding: "??", specific cards, or a range
+func formatPlayerCards(player PlayerRange) (string, error) {
+	if player.Unknown || len(player.Range) == 0 {
+		return "??", nil
+	}
+
+	if player.Weights != nil && len(player.Weights) != len(player.Range) {
+		return "", fmt.Errorf("weights length %d doesn't match range length %d", len(player.Weights), len(player.Range))
+	}
+
+	// A single unweighted combo is written as specific hole cards
+	if len(player.Range) == 1 && (player.Weights == nil || player.Weights[0] == 1) {
+		return player.Range[0].String(), nil
+	}
+
+	return formatRange(player.Range, player.Weights), nil
+}
+
+// formatRange writes combos as comma-separated hand classes with optional weights
+// Classes appear in order of their first combo in the range. A class that is
+// only partly in the range, or whose combos have different weights, is written
+// as its specific combos instead (e.g., "AhKh").
+func formatRange(combos []Combo, weights []float64) string {
+	type handClass struct {
+		name    string
+		combos  []Combo
+		weights []float64
+	}
+
+	var order []string
+	classes := make(map[string]*handClass)
+
+	for i, combo := range combos {
+		name := handClassName(combo)
+		class, ok := classes[name]
+		if !ok {
+			class = &handClass{name: name}
+			classes[name] = class
+			order = append(order, name)
+		}
+		class.combos = append(class.combos, combo)
+		class.weights = append(class.weights, comboWeight(weights, i))
+	}
+
+	var parts []string
+	for _, name := range order {
+		class := classes[name]
+
+		uniform := true
+		for _, w := range class.weights {
+			uniform = uniform && w == class.weights[0]
+		}
+		if uniform && len(class.combos) == handClassSize(name) {
+			parts = append(parts, name+formatWeight(class.weights[0]))
+			continue
+		}
+
+		for i, combo := range class.combos {
+			parts = append(parts, combo.String()+formatWeight(class.weights[i]))
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// formatWeight returns a range entry's "@weight" suffix ("" for weight 1)
+func formatWeight(weight float64) string {
+	if weight == 1 {
+		return ""
+	}
+	return "@" + strconv.FormatFloat(weight, 'f', -1, 64)
+}
+
+// handClassName returns the hand class of a combo (e.g., "AA", "AKs", "AKo")
+// An Omaha combo is its own class, named by its cards.
+func handClassName(combo Combo) string {
+	if combo.Omaha {
+		return combo.Canonical().String()
+	}
+
+	high, low := combo.Card1, combo.Card2
+	if low.Rank > high.Rank {
+		high, low = low, high
+	}
+
+	switch {
+	case high.Rank == low.Rank:
+		return high.Rank.String() + low.Rank.String()
+	case high.Suit == low.Suit:
+		return high.Rank.String() + low.Rank.String() + "s"
+	default:
+		return high.Rank.String() + low.Rank.String() + "o"
+	}
+}
+
+// handClassSize returns the number of combos in a hand class
+func handClassSize(name string) int {
+	switch {
+	case len(name) == 8:
+		return 1
+	case len(name) == 2:
+		return 6
+	case name[2] == 's':
+		return 4
+	default:
+		return 12
+	}
+}
+
+// comboWeight returns the weight of combo i (1 when weights are nil)
+func comboWeight(weights []float64, i int) float64 {
+	if weights == nil {
+		return 1
+	}
+	return weights[i]
+}
+
+// formatBoard writes the board with street separators: "Th9h2c/Js/3d"
+// Empty board is written as "-" (preflop)
+func formatBoard(board []cards.Card) string {
+	if len(board) == 0 {
+		return "-"
+	}
+
+	var sb strings.Builder
+	for i, card := range board {
+		if i >= 3 {
+			sb.WriteString("/")
+		}
+		sb.WriteString(card.String())
+	}
+	return sb.String()
+}
+
+// formatHistory writes action history at full precision: "b3.25r10c"
+// Unlike Action.String, amounts are not rounded so they parse back exactly
+func formatHistory(history []Action) string {
+	var sb strings.Builder
+	for _, action := range history {
+		switch action.Type {
+		case Bet:
+			sb.WriteString("b" + formatAmount(action.Amount))
+		case Raise:
+			sb.WriteString("r" + formatAmount(action.Amount))
+		default:
+			sb.WriteString(action.String())
+		}
+	}
+	return sb.String()
+}
+
+// formatAmount writes a bb amount with no trailing zeros: 100 → "100", 2.5 → "2.5"
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
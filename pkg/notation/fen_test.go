@@ -0,0 +1,239 @@
+package notation
+
+import (
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+)
+
+func TestGameState_ToFEN(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		want string
+	}{
+		{"river specific cards", "BTN:AsKd:S98/BB:QhQd:S97|P3|Th9h2c7d2s|>BTN", "BTN:AsKd:S98/BB:QhQd:S97|P3|Th9h2c/7d/2s|>BTN"},
+		{"flop with history", "BTN:AsKd:S98/BB:??:S97|P3|Th9h2c|b3.5c|>BB", "BTN:AsKd:S98/BB:??:S97|P3|Th9h2c|b3.5c|>BB"},
+		{"range dash expanded", "BTN:AA,KK,AKs:S100/BB:QQ-JJ:S100|P20|Kh9s4c7d2s|>BTN", "BTN:AA,KK,AKs:S100/BB:QQ,JJ:S100|P20|Kh9s4c/7d/2s|>BTN"},
+		{"weighted range", "BTN:AA,KK@0.5:S100/BB:QQ@0.25:S100|P10|Kh9s4c|>BTN", "BTN:AA,KK@0.5:S100/BB:QQ@0.25:S100|P10|Kh9s4c|>BTN"},
+		{"preflop", "BTN:AA:S100/BB:KK:S100|P1.5|-|>BTN", "BTN:AA:S100/BB:KK:S100|P1.5|-|>BTN"},
+		{"precise amounts", "BTN:AsKd:S97.25/BB:??:S97|P3|Th9h2c|b3.25r10.75|>BTN", "BTN:AsKd:S97.25/BB:??:S97|P3|Th9h2c|b3.25r10.75|>BTN"},
+		{"dead money", "BTN:AsKd:S98/BB:QhQd:S97|P10+A2|Th9h2c|>BTN", "BTN:AsKd:S98/BB:QhQd:S97|P10+A2|Th9h2c|>BTN"},
+		{"specific combo in range", "BTN:AA,AhKh:S100/BB:QQ:S100|P10|2c3d4s7d8s|>BTN", "BTN:AA,AhKh:S100/BB:QQ:S100|P10|2c3d4s/7d/8s|>BTN"},
+		{"weighted combos", "BTN:AhKh@0.5,AKo:S100/BB:QQ:S100|P10|2c3d4s|>BTN", "BTN:AhKh@0.5,AKo:S100/BB:QQ:S100|P10|2c3d4s|>BTN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs, err := ParsePosition(tt.fen)
+			if err != nil {
+				t.Fatalf("ParsePosition(%q) failed: %v", tt.fen, err)
+			}
+
+			got, err := gs.ToFEN()
+			if err != nil {
+				t.Fatalf("ToFEN failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ToFEN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameState_ToFEN_RoundTrip(t *testing.T) {
+	corpus := []string{
+		"BTN:AsKd:S98/BB:??:S97|P3|Th9h2c|>BTN",
+		"BTN:AsKd:S98/BB:QhQd:S97|P3|Th9h2c7d2s|>BTN",
+		"BTN:AA,KK,AKs:S100/BB:QQ-JJ:S100|P20|Kh9s4c7d2s|>BTN",
+		"BTN:AA:S100/BB:KK:S100|P10|Ah7h3c/5s/2d|>BTN",
+		"BTN:AA:S100/BB:KK:S100|P1.5|-|>BTN",
+		"BTN:AKo,AQs-ATs:S50/BB:TT-77:S50|P12|Kh9s4c7d|x|>BB",
+		"BTN:AA@0.5,KK:S100/BB:QQ@0.25,JJ@0.75:S100|P10|Kh9s4c7d2s|>BTN",
+		"BTN:AsKd:S90/BB:QhQd:S80|P30|Th9h2c|xb10r25c|>BB",
+		"SB:AhAd:S40/BB:KK-QQ:S40|P5|Ks7h2c|b2.5f|>BB",
+		"CO:??:S100/BTN:??:S100|P6|2c3d4h|>CO",
+		"BTN:AsKd:S98/BB:QhQd:S97|P10+A2|Th9h2c|>BTN",
+		"BTN:AA,AhKh:S100/BB:QQ:S100|P10|2c3d4s7d8s|>BTN",
+	}
+
+	for _, fen := range corpus {
+		t.Run(fen, func(t *testing.T) {
+			gs1, err := ParsePosition(fen)
+			if err != nil {
+				t.Fatalf("ParsePosition(%q) failed: %v", fen, err)
+			}
+
+			serialized, err := gs1.ToFEN()
+			if err != nil {
+				t.Fatalf("ToFEN failed: %v", err)
+			}
+
+			gs2, err := ParsePosition(serialized)
+			if err != nil {
+				t.Fatalf("ParsePosition(%q) of serialized state failed: %v", serialized, err)
+			}
+
+			assertEquivalentStates(t, gs1, gs2)
+
+			// Serializing again is stable
+			again, err := gs2.ToFEN()
+			if err != nil {
+				t.Fatalf("second ToFEN failed: %v", err)
+			}
+			if again != serialized {
+				t.Errorf("ToFEN not stable: %q then %q", serialized, again)
+			}
+		})
+	}
+}
+
+func TestGameState_ToFEN_Errors(t *testing.T) {
+	aks, _ := ParseRange("AKs")
+
+	tests := []struct {
+		name string
+		gs   *GameState
+	}{
+		{"no players", &GameState{}},
+		{"bad to act", &GameState{
+			Players: []PlayerRange{{Position: BTN, Stack: 100}},
+			ToAct:   3,
+		}},
+		{"weights length", &GameState{
+			Players: []PlayerRange{{Position: BTN, Range: aks, Weights: []float64{1, 0.5}, Stack: 100}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.gs.ToFEN(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestGameState_ToFEN_PartialClasses(t *testing.T) {
+	// Blocker removal leaves classes partly in the range
+	gs, err := ParsePositionWithOptions("BTN:AA,KK:S100/BB:QQ:S100|P10|Ah7h3c|>BTN", ParseOptions{RemoveBlockers: true})
+	if err != nil {
+		t.Fatalf("ParsePositionWithOptions failed: %v", err)
+	}
+	fen, err := gs.ToFEN()
+	if err != nil {
+		t.Fatalf("ToFEN failed: %v", err)
+	}
+	if want := "BTN:AsAd,AsAc,AdAc,KK:S100/BB:QQ:S100|P10|Ah7h3c|>BTN"; fen != want {
+		t.Errorf("ToFEN() = %q, want %q", fen, want)
+	}
+
+	// A class whose combos differ in weight is written combo by combo
+	aks, _ := ParseRange("AKs")
+	gs.Players[0].Range = aks
+	gs.Players[0].Weights = []float64{1, 1, 0.5, 1}
+	fen, err = gs.ToFEN()
+	if err != nil {
+		t.Fatalf("ToFEN failed: %v", err)
+	}
+	again, err := ParsePosition(fen)
+	if err != nil {
+		t.Fatalf("ParsePosition(%q) failed: %v", fen, err)
+	}
+	assertEquivalentStates(t, gs, again)
+}
+
+func TestGameState_ToFEN_Omaha(t *testing.T) {
+	for _, fen := range []string{
+		"BTN:AsAhKsKh:S100/BB:QsQhJsJh:S100|P10|2c5d9h|>BTN",
+		"BTN:AsAhKsKh,QdQc8h7h@0.5:S100/BB:KdKcJhJc:S100|P20|2s5s9s/Jd/Kh|>BTN",
+	} {
+		gs, err := ParsePosition(fen)
+		if err != nil {
+			t.Fatalf("ParsePosition(%q) failed: %v", fen, err)
+		}
+		got, err := gs.ToFEN()
+		if err != nil {
+			t.Fatalf("ToFEN failed: %v", err)
+		}
+		if got != fen {
+			t.Errorf("ToFEN() = %q, want %q", got, fen)
+		}
+	}
+}
+
+// assertEquivalentStates checks two game states describe the same position
+// Ranges are compared as combo→weight sets since class order may differ
+func assertEquivalentStates(t *testing.T, a, b *GameState) {
+	t.Helper()
+
+	if a.Pot != b.Pot || a.DeadMoney != b.DeadMoney || a.ToAct != b.ToAct || a.Street != b.Street {
+		t.Errorf("pot/dead/toAct/street mismatch: %v vs %v", a, b)
+	}
+
+	if len(a.Board) != len(b.Board) {
+		t.Fatalf("board length mismatch: %d vs %d", len(a.Board), len(b.Board))
+	}
+	for i := range a.Board {
+		if a.Board[i] != b.Board[i] {
+			t.Errorf("board card %d: %v vs %v", i, a.Board[i], b.Board[i])
+		}
+	}
+
+	if len(a.ActionHistory) != len(b.ActionHistory) {
+		t.Fatalf("history length mismatch: %d vs %d", len(a.ActionHistory), len(b.ActionHistory))
+	}
+	for i := range a.ActionHistory {
+		if a.ActionHistory[i] != b.ActionHistory[i] {
+			t.Errorf("action %d: %+v vs %+v", i, a.ActionHistory[i], b.ActionHistory[i])
+		}
+	}
+
+	if len(a.Players) != len(b.Players) {
+		t.Fatalf("player count mismatch: %d vs %d", len(a.Players), len(b.Players))
+	}
+	for i := range a.Players {
+		pa, pb := a.Players[i], b.Players[i]
+		if pa.Position != pb.Position || pa.Stack != pb.Stack {
+			t.Errorf("player %d: %s S%v vs %s S%v", i, pa.Position, pa.Stack, pb.Position, pb.Stack)
+		}
+
+		wa := rangeWeights(pa)
+		wb := rangeWeights(pb)
+		if len(wa) != len(wb) {
+			t.Errorf("player %d: range size %d vs %d", i, len(wa), len(wb))
+			continue
+		}
+		for combo, w := range wa {
+			if wb[combo] != w {
+				t.Errorf("player %d: combo %s weight %v vs %v", i, combo, w, wb[combo])
+			}
+		}
+	}
+}
+
+// rangeWeights maps each combo in a player's range to its weight
+func rangeWeights(p PlayerRange) map[Combo]float64 {
+	weights := make(map[Combo]float64, len(p.Range))
+	for i, combo := range p.Range {
+		weights[combo] = comboWeight(p.Weights, i)
+	}
+	return weights
+}
+
+func TestHandClassName(t *testing.T) {
+	tests := []struct {
+		combo Combo
+		want  string
+	}{
+		{Combo{Card1: cards.NewCard(cards.Ace, cards.Spades), Card2: cards.NewCard(cards.Ace, cards.Hearts)}, "AA"},
+		{Combo{Card1: cards.NewCard(cards.King, cards.Hearts), Card2: cards.NewCard(cards.Ace, cards.Hearts)}, "AKs"},
+		{Combo{Card1: cards.NewCard(cards.Two, cards.Clubs), Card2: cards.NewCard(cards.Seven, cards.Diamonds)}, "72o"},
+	}
+
+	for _, tt := range tests {
+		if got := handClassName(tt.combo); got != tt.want {
+			t.Errorf("handClassName(%s) = %q, want %q", tt.combo, got, tt.want)
+		}
+	}
+}
@@ -8,11 +8,32 @@ import (
 	"github.com/behrlich/poker-solver/pkg/cards"
 )
 
+// ParseOptions controls optional post-processing in ParsePositionWithOptions
+type ParseOptions struct {
+	// RemoveBlockers strips combos that conflict with the board from each player's range
+	RemoveBlockers bool
+
+	// StrictHistory rejects illegal action sequences (see ParseHistoryStrict)
+	StrictHistory bool
+
+	// LooseBoard accepts hand-history style boards like "[Th 9h 2c] [Js]" (see ParseBoardLoose)
+	LooseBoard bool
+}
+
 // ParsePosition parses a position FEN string into a GameState
 // Format: <players>|<pot>|<board>|<history>|<action>
 // Example: "BTN:AsKd:S98/BB:??:S97|P3|Th9h2c|>BTN"
 // Example with range: "BTN:AA,KK/BB:QQ-JJ|P20|Kh9s4c7d2s|>BTN"
+// Example after a check: "BTN:AA/BB:QQ|P20|Kh9s4c7d2s|x|>BB"
+// While the street's betting is still open, the history decides who acts and
+// <action> must agree with it (see historyToAct).
 func ParsePosition(fen string) (*GameState, error) {
+	return ParsePositionWithOptions(fen, ParseOptions{})
+}
+
+// ParsePositionWithOptions parses a position FEN string like ParsePosition, then applies opts
+// With RemoveBlockers, a player whose whole range conflicts with the board is an error.
+func ParsePositionWithOptions(fen string, opts ParseOptions) (*GameState, error) {
 	fen = strings.TrimSpace(fen)
 	if fen == "" {
 		return nil, fmt.Errorf("empty position FEN")
@@ -49,17 +70,26 @@ func ParsePosition(fen string) (*GameState, error) {
 		return nil, fmt.Errorf("error parsing players: %w", err)
 	}
 
-	pot, err := parsePot(potStr)
+	pot, dead, err := parsePot(potStr)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing pot: %w", err)
 	}
 
-	board, err := parseBoard(boardStr)
+	parseBoardStr := parseBoard
+	if opts.LooseBoard {
+		parseBoardStr = ParseBoardLoose
+	}
+	board, err := parseBoardStr(boardStr)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing board: %w", err)
 	}
 
-	history, err := parseHistory(historyStr)
+	var history []Action
+	if opts.StrictHistory {
+		history, err = ParseHistoryStrict(historyStr, GetStreet(len(board)))
+	} else {
+		history, err = parseHistory(historyStr)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error parsing history: %w", err)
 	}
@@ -68,12 +98,32 @@ func ParsePosition(fen string) (*GameState, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing action: %w", err)
 	}
+	if implied, ok := historyToAct(history, len(players)); ok && implied != toAct {
+		return nil, fmt.Errorf("history %q puts %s to act, but action indicator is >%s",
+			historyStr, players[implied].Position, players[toAct].Position)
+	}
+
+	if opts.RemoveBlockers && len(board) > 0 {
+		for i := range players {
+			if len(players[i].Range) == 0 {
+				continue // unknown cards
+			}
+
+			players[i].Range, players[i].Weights = removeBlockersWeighted(players[i].Range, players[i].Weights, board)
+			if len(players[i].Range) == 0 {
+				return nil, fmt.Errorf("range for %s is empty after removing board cards", players[i].Position)
+			}
+		}
+	}
+
+	expandUnknownRanges(players, board)
 
 	street := GetStreet(len(board))
 
 	return &GameState{
 		Players:       players,
 		Pot:           pot,
+		DeadMoney:     dead,
 		Board:         board,
 		ActionHistory: history,
 		ToAct:         toAct,
@@ -90,12 +140,17 @@ func parsePlayers(playersStr string) ([]PlayerRange, error) {
 
 	playerParts := strings.Split(playersStr, "/")
 	players := make([]PlayerRange, 0, len(playerParts))
+	seen := make(map[Position]bool, len(playerParts))
 
 	for _, playerStr := range playerParts {
 		player, err := parsePlayer(playerStr)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing player %q: %w", playerStr, err)
 		}
+		if seen[player.Position] {
+			return nil, fmt.Errorf("duplicate position %q", player.Position)
+		}
+		seen[player.Position] = true
 		players = append(players, player)
 	}
 
@@ -106,6 +161,7 @@ func parsePlayers(playersStr string) ([]PlayerRange, error) {
 // CARDS can be:
 //   - Specific cards: "AsKd"
 //   - Range: "AA,KK,AKs"
+//   - Weighted range: "AA,KK@0.5,AKs@0.25"
 //   - Unknown: "??"
 func parsePlayer(playerStr string) (PlayerRange, error) {
 	playerStr = strings.TrimSpace(playerStr)
@@ -116,6 +172,9 @@ func parsePlayer(playerStr string) (PlayerRange, error) {
 	}
 
 	position := Position(strings.TrimSpace(parts[0]))
+	if !position.IsValid() {
+		return PlayerRange{}, fmt.Errorf("unknown position %q (expected SB, BB, UTG, MP, CO, or BTN)", position)
+	}
 	cardsStr := strings.TrimSpace(parts[1])
 	stackStr := strings.TrimSpace(parts[2])
 
@@ -131,68 +190,133 @@ func parsePlayer(playerStr string) (PlayerRange, error) {
 
 	// Parse cards/range
 	var combos []Combo
+	var weights []float64
 
-	if cardsStr == "??" {
-		// Unknown range - leave empty for now
-		// In actual solving, this would be filled by context
+	unknown := cardsStr == "??"
+	if unknown {
+		// Unknown range - expanded once the board and other hands are known
 		combos = nil
-	} else if len(cardsStr) == 4 && isSpecificCards(cardsStr) {
-		// Specific hole cards (e.g., "AsKd")
-		card1, err := cards.ParseCard(cardsStr[0:2])
+	} else if isSpecificCards(cardsStr) {
+		// Specific hole cards (e.g., "AsKd", or "AsAhKsKh" for Omaha)
+		hole, err := cards.ParseCards(cardsStr)
 		if err != nil {
-			return PlayerRange{}, fmt.Errorf("error parsing card1 from %q: %w", cardsStr, err)
+			return PlayerRange{}, fmt.Errorf("error parsing cards %q: %w", cardsStr, err)
 		}
-		card2, err := cards.ParseCard(cardsStr[2:4])
+		combo, err := NewCombo(hole)
 		if err != nil {
-			return PlayerRange{}, fmt.Errorf("error parsing card2 from %q: %w", cardsStr, err)
+			return PlayerRange{}, fmt.Errorf("error parsing cards %q: %w", cardsStr, err)
 		}
-		combos = []Combo{{Card1: card1, Card2: card2}}
+		combos = []Combo{combo}
 	} else {
 		// Range notation (e.g., "AA,KK,AKs")
 		var err error
-		combos, err = ParseRange(cardsStr)
+		combos, weights, err = ParseWeightedRange(cardsStr)
 		if err != nil {
 			return PlayerRange{}, fmt.Errorf("error parsing range %q: %w", cardsStr, err)
 		}
+
+		// Only keep weights when the range actually uses them
+		if !strings.Contains(cardsStr, "@") {
+			weights = nil
+		}
 	}
 
 	return PlayerRange{
 		Position: position,
 		Range:    combos,
+		Weights:  weights,
 		Stack:    stack,
+		Unknown:  unknown,
 	}, nil
 }
 
-// isSpecificCards checks if a string represents specific hole cards (e.g., "AsKd")
+// expandUnknownRanges fills each "??" player's range with every combo that doesn't
+// use a board card or the hole cards of a player holding one specific combo
+func expandUnknownRanges(players []PlayerRange, board []cards.Card) {
+	for i := range players {
+		if !players[i].Unknown {
+			continue
+		}
+
+		dead := append([]cards.Card{}, board...)
+		for j, other := range players {
+			if j != i && !other.Unknown && len(other.Range) == 1 {
+				dead = append(dead, other.Range[0].Cards()...)
+			}
+		}
+		players[i].Range = AllCombos(dead)
+	}
+}
+
+// isSpecificCards checks if a string represents specific hole cards: two cards
+// (e.g., "AsKd") or four for Omaha (e.g., "AsAhKsKh")
 func isSpecificCards(s string) bool {
-	if len(s) != 4 {
+	if len(s) != 4 && len(s) != 8 {
 		return false
 	}
-	// Check if it looks like two cards: rank+suit+rank+suit
+	// Check if it looks like cards: rank+suit pairs
 	// Valid ranks: A,K,Q,J,T,9-2
 	// Valid suits: s,h,d,c
 	ranks := "AKQJT98765432"
 	suits := "shdc"
 
-	return strings.ContainsRune(ranks, rune(s[0])) &&
-		strings.ContainsRune(suits, rune(s[1])) &&
-		strings.ContainsRune(ranks, rune(s[2])) &&
-		strings.ContainsRune(suits, rune(s[3]))
+	for i := 0; i < len(s); i += 2 {
+		if !strings.ContainsRune(ranks, rune(s[i])) || !strings.ContainsRune(suits, rune(s[i+1])) {
+			return false
+		}
+	}
+	return true
 }
 
 // parsePot parses pot string: "P3" → 3.0
-func parsePot(potStr string) (float64, error) {
+// Dead money (antes, a dead straddle) may follow as "+A{amount}": "P10+A2" is a
+// 12bb pot of which 2bb was put in by neither player. Returns the total pot and
+// the dead part of it.
+func parsePot(potStr string) (pot, dead float64, err error) {
 	potStr = strings.TrimSpace(potStr)
 	if len(potStr) < 2 || potStr[0] != 'P' {
-		return 0, fmt.Errorf("invalid pot format %q (expected P{amount})", potStr)
+		return 0, 0, fmt.Errorf("invalid pot format %q (expected P{amount})", potStr)
 	}
 
-	pot, err := strconv.ParseFloat(potStr[1:], 64)
+	liveStr, deadStr, hasDead := strings.Cut(potStr[1:], "+")
+	pot, err = strconv.ParseFloat(liveStr, 64)
 	if err != nil {
-		return 0, fmt.Errorf("invalid pot amount %q: %w", potStr, err)
+		return 0, 0, fmt.Errorf("invalid pot amount %q: %w", potStr, err)
 	}
 
-	return pot, nil
+	if hasDead {
+		if len(deadStr) < 2 || deadStr[0] != 'A' {
+			return 0, 0, fmt.Errorf("invalid dead money %q (expected P{amount}+A{amount})", potStr)
+		}
+		dead, err = strconv.ParseFloat(deadStr[1:], 64)
+		if err != nil || dead < 0 {
+			return 0, 0, fmt.Errorf("invalid dead money amount %q", potStr)
+		}
+		pot += dead
+	}
+
+	return pot, dead, nil
+}
+
+// ParseBoardLoose parses a board written the way hand histories print it
+// Brackets, commas, and whitespace are ignored, "10" is read as a ten, and
+// ranks and suits may be either case: "[Th 9h 2c] [Js] [3d]" and "th9h2c/js"
+// both parse. The result must still be a flop, turn, or river (or empty for preflop).
+func ParseBoardLoose(s string) ([]cards.Card, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case '[', ']', ',', ' ', '\t':
+			return -1
+		}
+		return r
+	}, s)
+	normalized = strings.ReplaceAll(normalized, "10", "T")
+
+	board, err := parseBoard(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("invalid board %q: %w", s, err)
+	}
+	return board, nil
 }
 
 // parseBoard parses board string: "Th9h2c" (flop), "Th9h2c/Js" (turn), "Th9h2c/Js/3d" (river)
@@ -229,6 +353,84 @@ func parseBoard(boardStr string) ([]cards.Card, error) {
 	return board, nil
 }
 
+// ParseHistory parses an action history string: "xb5r15c" → [check, bet 5, raise to 15, call]
+// Concatenated tree action keys (Action.String) parse back to the same actions
+// up to the 0.1bb precision of the keys (see RoundAmount); amounts are kept as
+// written, so positions can carry finer amounts than keys show.
+func ParseHistory(historyStr string) ([]Action, error) {
+	return parseHistory(historyStr)
+}
+
+// ParseHistoryStrict parses one street's action history like ParseHistory and rejects illegal sequences
+// Legality depends on whether the actor faces a live (uncalled) bet or raise:
+//   - check and bet require no live bet
+//   - fold and call require a live bet
+//   - raise requires an earlier bet and must exceed the current bet level
+//   - nothing may follow a fold
+//
+// Preflop the first actor faces the 1bb big blind as a live bet, so they can
+// fold, call (limp), or raise it, and a limp leaves the bet level at 1bb.
+// So "xf" and "cc" are errors, while preflop "cb5", "r3c", and "cr3c" and
+// postflop "b5cr10c" are accepted.
+func ParseHistoryStrict(historyStr string, street Street) ([]Action, error) {
+	actions, err := parseHistory(historyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	liveBet := false
+	betLevel := 0.0
+	if street == Preflop {
+		liveBet = true
+		betLevel = 1 // The big blind
+	}
+	for i, action := range actions {
+		if i > 0 && actions[i-1].Type == Fold {
+			return nil, fmt.Errorf("action %d (%s): no action allowed after a fold", i, action)
+		}
+
+		switch action.Type {
+		case Check:
+			if liveBet {
+				return nil, fmt.Errorf("action %d (%s): cannot check facing a bet", i, action)
+			}
+
+		case Bet:
+			if liveBet {
+				return nil, fmt.Errorf("action %d (%s): cannot bet facing a bet (raise instead)", i, action)
+			}
+			if action.Amount <= 0 {
+				return nil, fmt.Errorf("action %d (%s): bet amount must be positive", i, action)
+			}
+			liveBet = true
+			betLevel = action.Amount
+
+		case Raise:
+			if betLevel == 0 {
+				return nil, fmt.Errorf("action %d (%s): cannot raise without a bet", i, action)
+			}
+			if action.Amount <= betLevel {
+				return nil, fmt.Errorf("action %d (%s): raise to %.1f must exceed the bet of %.1f", i, action, action.Amount, betLevel)
+			}
+			liveBet = true
+			betLevel = action.Amount
+
+		case Call:
+			if !liveBet {
+				return nil, fmt.Errorf("action %d (%s): nothing to call", i, action)
+			}
+			liveBet = false
+
+		case Fold:
+			if !liveBet {
+				return nil, fmt.Errorf("action %d (%s): cannot fold when checking is allowed", i, action)
+			}
+		}
+	}
+
+	return actions, nil
+}
+
 // parseHistory parses action history: "b3.5c" → [bet 3.5, call]
 // Empty string returns empty slice
 func parseHistory(historyStr string) ([]Action, error) {
@@ -317,6 +519,27 @@ func parseActionAmount(s string) (float64, int, error) {
 	return amount, end, nil
 }
 
+// historyToAct returns the player the street history puts to act
+// The first listed player opens the street and players alternate, so "x" means
+// the second player is to act. ok is false when the history is empty or the
+// betting round is over (a fold, a second check, or a call that isn't the
+// opening limp), since the next street's first actor isn't implied.
+// Only heads-up histories are resolved.
+func historyToAct(history []Action, numPlayers int) (int, bool) {
+	if numPlayers != 2 || len(history) == 0 {
+		return 0, false
+	}
+
+	last := history[len(history)-1]
+	switch {
+	case last.Type == Fold:
+		return 0, false
+	case (last.Type == Check || last.Type == Call) && len(history) > 1:
+		return 0, false
+	}
+	return len(history) % 2, true
+}
+
 // parseAction parses action indicator: ">BTN" → player index
 func parseAction(actionStr string, players []PlayerRange) (int, error) {
 	actionStr = strings.TrimSpace(actionStr)
@@ -94,9 +94,18 @@ func TestParsePosition_WithUnknownRange(t *testing.T) {
 		t.Errorf("expected 1 combo for BTN, got %d", len(gs.Players[0].Range))
 	}
 
-	// BB has unknown range (??)
-	if gs.Players[1].Range != nil && len(gs.Players[1].Range) != 0 {
-		t.Errorf("expected nil or empty range for BB, got %d combos", len(gs.Players[1].Range))
+	// BB has unknown range (??): every combo not using the board or AsKd
+	if !gs.Players[1].Unknown {
+		t.Error("expected BB to be marked unknown")
+	}
+	if len(gs.Players[1].Range) != 1081 {
+		t.Errorf("expected C(47,2) = 1081 combos for BB, got %d", len(gs.Players[1].Range))
+	}
+	dead := append(append([]cards.Card{}, gs.Board...), gs.Players[0].Range[0].Card1, gs.Players[0].Range[0].Card2)
+	for _, combo := range gs.Players[1].Range {
+		if combo.Conflicts(dead) {
+			t.Errorf("unknown range includes blocked combo %s", combo)
+		}
 	}
 
 	// Check flop (3 cards)
@@ -205,6 +214,50 @@ func TestParsePosition_NoHistory(t *testing.T) {
 	}
 }
 
+func TestParsePosition_CheckThenToAct(t *testing.T) {
+	// BTN has checked, so BB is to act
+	gs, err := ParsePosition("BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|x|>BB")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+
+	if len(gs.ActionHistory) != 1 || gs.ActionHistory[0].Type != Check {
+		t.Fatalf("expected history [check], got %v", gs.ActionHistory)
+	}
+	if gs.ToAct != 1 {
+		t.Errorf("expected ToAct 1 (BB), got %d", gs.ToAct)
+	}
+
+	// Check, bet: BTN faces the bet
+	gs, err = ParsePosition("BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|xb10|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+	if gs.ToAct != 0 {
+		t.Errorf("expected ToAct 0 (BTN), got %d", gs.ToAct)
+	}
+
+	// The indicator must agree with an open history
+	if _, err := ParsePosition("BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|x|>BTN"); err == nil {
+		t.Error("expected error when >BTN contradicts a history where BB is to act")
+	}
+
+	// A closed round doesn't imply who acts next, so either indicator is accepted
+	for _, fen := range []string{
+		"BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|xx|>BTN",
+		"BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|b10c|>BB",
+	} {
+		if _, err := ParsePosition(fen); err != nil {
+			t.Errorf("ParsePosition(%q) failed: %v", fen, err)
+		}
+	}
+
+	// An opening limp leaves the round open for the big blind
+	if _, err := ParsePosition("SB:AA:S100/BB:KK:S100|P2||c|>SB"); err == nil {
+		t.Error("expected error when >SB contradicts an open limp")
+	}
+}
+
 func TestParsePosition_DecimalAmounts(t *testing.T) {
 	fen := "BTN:AA:S100/BB:KK:S100|P15.5|Kh9s4c7d2s|b3.5c|>BTN"
 	gs, err := ParsePosition(fen)
@@ -224,6 +277,24 @@ func TestParsePosition_DecimalAmounts(t *testing.T) {
 	}
 }
 
+func TestParsePosition_DeadMoney(t *testing.T) {
+	gs, err := ParsePosition("BTN:AA:S100/BB:KK:S100|P10+A2|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+	if gs.Pot != 12 || gs.DeadMoney != 2 {
+		t.Errorf("got pot %.1f dead %.1f, want pot 12.0 dead 2.0", gs.Pot, gs.DeadMoney)
+	}
+
+	gs, err = ParsePosition("BTN:AA:S100/BB:KK:S100|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+	if gs.DeadMoney != 0 {
+		t.Errorf("expected no dead money without +A, got %.1f", gs.DeadMoney)
+	}
+}
+
 func TestParsePosition_AllActionTypes(t *testing.T) {
 	fen := "BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|xb5cr10f|>BTN"
 	gs, err := ParsePosition(fen)
@@ -302,10 +373,15 @@ func TestParsePosition_Errors(t *testing.T) {
 		{"invalid player format", "BTN-AA-S100/BB:KK:S100|P3|Kh9s4c|>BTN"},
 		{"invalid stack format", "BTN:AA:100/BB:KK:S100|P3|Kh9s4c|>BTN"},
 		{"invalid pot format", "BTN:AA:S100/BB:KK:S100|3|Kh9s4c|>BTN"},
+		{"invalid dead money", "BTN:AA:S100/BB:KK:S100|P3+2|Kh9s4c|>BTN"},
+		{"negative dead money", "BTN:AA:S100/BB:KK:S100|P3+A-1|Kh9s4c|>BTN"},
 		{"invalid board length", "BTN:AA:S100/BB:KK:S100|P3|Kh9s|>BTN"},
 		{"invalid card in board", "BTN:AA:S100/BB:KK:S100|P3|Xh9s4c|>BTN"},
 		{"invalid action", "BTN:AA:S100/BB:KK:S100|P3|Kh9s4c|BTN"},
 		{"position not found", "BTN:AA:S100/BB:KK:S100|P3|Kh9s4c|>CO"},
+		{"unknown position", "BTN:AA:S100/XX:KK:S100|P3|Kh9s4c|>BTN"},
+		{"lowercase position", "btn:AA:S100/BB:KK:S100|P3|Kh9s4c|>btn"},
+		{"duplicate position", "BTN:AA:S100/BTN:KK:S100|P3|Kh9s4c|>BTN"},
 		{"invalid action in history", "BTN:AA:S100/BB:KK:S100|P3|Kh9s4c|z|>BTN"},
 		{"bet without amount", "BTN:AA:S100/BB:KK:S100|P3|Kh9s4c|b|>BTN"},
 	}
@@ -363,6 +439,11 @@ func TestParsePlayer(t *testing.T) {
 			playerStr: "BTN:AA:100",
 			wantErr:   true,
 		},
+		{
+			name:      "unknown position",
+			playerStr: "HJ:AA:S100",
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -435,6 +516,64 @@ func TestParseBoard(t *testing.T) {
 	}
 }
 
+func TestParseBoardLoose(t *testing.T) {
+	tests := []struct {
+		name     string
+		boardStr string
+		want     string
+		wantErr  bool
+	}{
+		{"bracketed flop", "[Th 9h 2c]", "Th9h2c", false},
+		{"bracketed turn", "[Th 9h 2c] [Js]", "Th9h2cJs", false},
+		{"bracketed river", "[Th 9h 2c] [Js] [3d]", "Th9h2cJs3d", false},
+		{"mixed case", "th 9H 2C js", "Th9h2cJs", false},
+		{"ten as 10", "[10h 9h 2c]", "Th9h2c", false},
+		{"compact still works", "Th9h2c/Js", "Th9h2cJs", false},
+		{"six cards", "[Th 9h 2c] [Js] [3d] [4s]", "", true},
+		{"invalid card", "[Xh 9h 2c]", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := ParseBoardLoose(tt.boardStr)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", board)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := ""
+			for _, card := range board {
+				got += card.String()
+			}
+			if got != tt.want {
+				t.Errorf("ParseBoardLoose(%q) = %s, want %s", tt.boardStr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePositionWithOptions_LooseBoard(t *testing.T) {
+	fen := "BTN:AA:S100/BB:KK:S100|P20|[Th 9h 2c] [Js]|>BTN"
+
+	if _, err := ParsePosition(fen); err == nil {
+		t.Error("expected default ParsePosition to reject a bracketed board")
+	}
+	gs, err := ParsePositionWithOptions(fen, ParseOptions{LooseBoard: true})
+	if err != nil {
+		t.Fatalf("loose ParsePositionWithOptions failed: %v", err)
+	}
+	if len(gs.Board) != 4 || gs.Street != Turn {
+		t.Errorf("got %d board cards on %v, want a 4-card turn", len(gs.Board), gs.Street)
+	}
+}
+
 func TestParseHistory(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -488,6 +627,17 @@ func TestParseHistory(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "check-raise call (action keys)",
+			historyStr: "xb5.0r15.0c",
+			wantActions: []Action{
+				{Type: Check},
+				{Type: Bet, Amount: 5},
+				{Type: Raise, Amount: 15},
+				{Type: Call},
+			},
+			wantErr: false,
+		},
 		{
 			name:        "invalid action",
 			historyStr:  "z",
@@ -533,3 +683,102 @@ func TestParseHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePosition_WeightedRange(t *testing.T) {
+	gs, err := ParsePosition("BTN:AA,KK:S100/BB:QQ@0.5,JJ:S100|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+
+	// Unweighted range keeps nil weights (uniform)
+	if gs.Players[0].Weights != nil {
+		t.Errorf("expected nil weights for unweighted BTN range, got %v", gs.Players[0].Weights)
+	}
+
+	bb := gs.Players[1]
+	if len(bb.Weights) != len(bb.Range) {
+		t.Fatalf("expected %d BB weights, got %d", len(bb.Range), len(bb.Weights))
+	}
+	for i, combo := range bb.Range {
+		want := 1.0
+		if combo.Card1.Rank == cards.Queen {
+			want = 0.5
+		}
+		if bb.Weights[i] != want {
+			t.Errorf("combo %s: weight = %.2f, want %.2f", combo, bb.Weights[i], want)
+		}
+	}
+}
+
+func TestParseHistoryStrict(t *testing.T) {
+	tests := []struct {
+		historyStr string
+		street     Street
+		wantErr    bool
+	}{
+		{"", River, false},
+		{"xx", River, false},
+		{"xb5f", River, false},
+		{"cb5", Preflop, false},   // complete the blind, then bet
+		{"r3c", Preflop, false},   // raise the big blind
+		{"r3r9c", Preflop, false}, // 3-bet
+		{"cr3c", Preflop, false},  // limp-raise
+		{"f", Preflop, false},     // fold to the big blind
+		{"b5cr10c", River, false}, // raise over the called bet
+		{"xb5r15r45c", Flop, false},
+		{"xf", River, true},    // fold when checking is allowed
+		{"cc", River, true},    // nothing to call
+		{"cc", Preflop, true},  // nothing to call after a limp
+		{"f", River, true},     // fold when first to act
+		{"c", Turn, true},      // call when first to act
+		{"r3", Flop, true},     // raise when first to act
+		{"x", Preflop, true},   // check facing the big blind
+		{"b5x", River, true},   // check facing a bet
+		{"b5b10", River, true}, // bet facing a bet
+		{"xr10", River, true},  // raise without a bet
+		{"b10r5", River, true}, // raise smaller than the bet
+		{"r1c", Preflop, true}, // raise no bigger than the big blind
+		{"b5fc", River, true},  // action after a fold
+		{"b0", River, true},    // zero bet
+		{"b5q", River, true},   // unparseable
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.street.String()+"/"+tt.historyStr, func(t *testing.T) {
+			actions, err := ParseHistoryStrict(tt.historyStr, tt.street)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHistoryStrict(%q, %s) error = %v, wantErr %v", tt.historyStr, tt.street, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			// Legal histories parse the same as the lenient parser
+			lenient, _ := ParseHistory(tt.historyStr)
+			if len(actions) != len(lenient) {
+				t.Errorf("strict parse gave %v, lenient gave %v", actions, lenient)
+			}
+		})
+	}
+}
+
+func TestParsePositionWithOptions_StrictHistory(t *testing.T) {
+	fen := "BTN:AA:S100/BB:KK:S100|P20|Kh9s4c7d2s|xf|>BTN"
+
+	if _, err := ParsePosition(fen); err != nil {
+		t.Fatalf("lenient ParsePosition failed: %v", err)
+	}
+	if _, err := ParsePositionWithOptions(fen, ParseOptions{StrictHistory: true}); err == nil {
+		t.Error("expected strict parse to reject illegal fold")
+	}
+
+	// The board sets the street: first to act can fold only preflop
+	river := "BTN:AA:S100/BB:QQ:S100|P10|Kh9s4c7d2s|f|>BTN"
+	if _, err := ParsePositionWithOptions(river, ParseOptions{StrictHistory: true}); err == nil {
+		t.Error("expected strict parse to reject a first-to-act fold on the river")
+	}
+	preflop := "BTN:AA:S100/BB:QQ:S100|P1.5|-|cr3c|>BTN"
+	if _, err := ParsePositionWithOptions(preflop, ParseOptions{StrictHistory: true}); err != nil {
+		t.Errorf("strict parse rejected a preflop limp-raise: %v", err)
+	}
+}
@@ -2,22 +2,145 @@ package notation
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
 )
 
-// Combo represents a specific 2-card combination (hole cards)
+// Combo represents a specific set of hole cards: two for Hold'em, or four for
+// Omaha when Omaha is set (Card3 and Card4 are unused otherwise)
 type Combo struct {
 	Card1 cards.Card
 	Card2 cards.Card
+	Card3 cards.Card
+	Card4 cards.Card
+	Omaha bool
 }
 
-// String returns the combo in standard notation (e.g., "AsKh")
+// NewCombo returns the canonical combo holding the given two or four distinct cards
+func NewCombo(hole []cards.Card) (Combo, error) {
+	if len(hole) != 2 && len(hole) != 4 {
+		return Combo{}, fmt.Errorf("combo needs 2 or 4 cards, got %d", len(hole))
+	}
+	var seen cards.CardMask
+	for _, card := range hole {
+		if seen.Has(card) {
+			return Combo{}, fmt.Errorf("combo uses %s twice", card)
+		}
+		seen = seen.With(card)
+	}
+
+	if len(hole) == 2 {
+		return Combo{Card1: hole[0], Card2: hole[1]}.Canonical(), nil
+	}
+	return Combo{Card1: hole[0], Card2: hole[1], Card3: hole[2], Card4: hole[3], Omaha: true}.Canonical(), nil
+}
+
+// Cards returns the combo's hole cards (two, or four for Omaha)
+func (c Combo) Cards() []cards.Card {
+	if c.Omaha {
+		return []cards.Card{c.Card1, c.Card2, c.Card3, c.Card4}
+	}
+	return []cards.Card{c.Card1, c.Card2}
+}
+
+// String returns the combo in standard notation (e.g., "AsKh", "AsAhKsKh")
 func (c Combo) String() string {
+	if c.Omaha {
+		return fmt.Sprintf("%s%s%s%s", c.Card1, c.Card2, c.Card3, c.Card4)
+	}
 	return fmt.Sprintf("%s%s", c.Card1, c.Card2)
 }
 
+// Canonical returns the combo with its cards in a fixed order: higher rank first,
+// then lower suit index (spades, hearts, diamonds, clubs). AhAs and AsAh both
+// become AsAh, matching the order ranges generate combos in.
+func (c Combo) Canonical() Combo {
+	if c.Omaha {
+		hole := c.Cards()
+		sort.Slice(hole, func(i, j int) bool {
+			return cardLess(hole[i], hole[j])
+		})
+		return Combo{Card1: hole[0], Card2: hole[1], Card3: hole[2], Card4: hole[3], Omaha: true}
+	}
+	if cardLess(c.Card2, c.Card1) {
+		return Combo{Card1: c.Card2, Card2: c.Card1}
+	}
+	return c
+}
+
+// Mask returns the combo's hole cards as a CardMask
+func (c Combo) Mask() cards.CardMask {
+	mask := cards.NewCardMask(c.Card1, c.Card2)
+	if c.Omaha {
+		mask = mask.With(c.Card3, c.Card4)
+	}
+	return mask
+}
+
+// Conflicts reports whether the combo shares a card with any of the given cards
+func (c Combo) Conflicts(dead []cards.Card) bool {
+	return cards.NewCardMask(dead...).Overlaps(c.Mask())
+}
+
+// cardLess orders cards from the highest rank down, then by suit index
+func cardLess(a, b cards.Card) bool {
+	return a.Rank > b.Rank || (a.Rank == b.Rank && a.Suit < b.Suit)
+}
+
+// RemoveBlockers returns the combos that don't contain any dead card
+// (e.g., an AA range on an A-high board keeps only the 3 combos without the board ace)
+func RemoveBlockers(combos []Combo, dead []cards.Card) []Combo {
+	result, _ := removeBlockersWeighted(combos, nil, dead)
+	return result
+}
+
+// AllCombos returns every two-card combo that doesn't use a dead card, in canonical
+// form from the highest cards down (1326 with no dead cards, 1081 on a river board)
+// This is the "any two cards" range.
+func AllCombos(dead []cards.Card) []Combo {
+	deadMask := cards.NewCardMask(dead...)
+	combos := make([]Combo, 0, 1326)
+	for i := 51; i >= 1; i-- {
+		card1 := cards.CardFromIndex(uint8(i))
+		if deadMask.Has(card1) {
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			card2 := cards.CardFromIndex(uint8(j))
+			if deadMask.Has(card2) {
+				continue
+			}
+			combos = append(combos, Combo{Card1: card1, Card2: card2}.Canonical())
+		}
+	}
+	return combos
+}
+
+// removeBlockersWeighted removes combos containing dead cards, keeping weights aligned
+// Nil weights stay nil.
+func removeBlockersWeighted(combos []Combo, weights []float64, dead []cards.Card) ([]Combo, []float64) {
+	result := make([]Combo, 0, len(combos))
+	var resultWeights []float64
+	if weights != nil {
+		resultWeights = make([]float64, 0, len(weights))
+	}
+
+	for i, combo := range combos {
+		if combo.Conflicts(dead) {
+			continue
+		}
+		result = append(result, combo)
+		if weights != nil {
+			resultWeights = append(resultWeights, weights[i])
+		}
+	}
+
+	return result, resultWeights
+}
+
 // ParseRange parses a range string and returns all possible combos
 // Examples:
 //   - "AA" → 6 combos (AsAh, AsAd, AsAc, AhAd, AhAc, AdAc)
@@ -25,43 +148,224 @@ func (c Combo) String() string {
 //   - "AKo" → 12 combos (all offsuit combinations)
 //   - "KK-JJ" → 18 combos (KK, QQ, JJ)
 //   - "AA,KK,AKs" → 6+6+4 = 16 combos
+//   - "T15%" → the strongest hand classes covering ~15% of the 1326 combos
+//   - "AA,AhKh" → 6+1 = 7 combos (4-character elements are specific combos)
+//   - "AsAhKsKh,QsQhJsJh" → 2 Omaha combos (8-character elements are four-card holdings)
+//
+// Overlapping components are unioned, so "T15%,AKo" doesn't repeat AKo.
+// A specific combo listed twice (e.g., "AhKh,KhAh") is an error.
+// Weighted components (e.g., "AA@0.5") are accepted; the weights are dropped
+// but hands at weight 0 are still excluded. Use ParseWeightedRange to keep them.
 func ParseRange(rangeStr string) ([]Combo, error) {
+	combos, _, err := ParseWeightedRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	return combos, nil
+}
+
+// ParseWeightedRange parses a range string where each component may carry a
+// frequency suffix, and returns the combos plus a parallel slice of weights
+// Examples:
+//   - "AA@0.5,KK" → 12 combos (AA at weight 0.5, KK at weight 1)
+//   - "QQ-JJ@0.25" → 12 combos, all at weight 0.25
+//
+// Components without a suffix have weight 1. Weights must be in [0, 1];
+// components at weight 0 are excluded from the result.
+func ParseWeightedRange(rangeStr string) ([]Combo, []float64, error) {
 	rangeStr = strings.TrimSpace(rangeStr)
 	if rangeStr == "" {
-		return nil, fmt.Errorf("empty range string")
+		return nil, nil, fmt.Errorf("empty range string")
 	}
 
 	// Split by comma to get individual range components
 	parts := strings.Split(rangeStr, ",")
 
 	var allCombos []Combo
+	var allWeights []float64
+	seen := make(map[Combo]bool)
+	specific := make(map[Combo]bool)
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
 		}
 
-		// Check if this is a range (contains dash)
-		if strings.Contains(part, "-") {
-			combos, err := parseRangeWithDash(part)
+		// Split off optional weight suffix (e.g., "AKs@0.25")
+		weight := 1.0
+		if idx := strings.Index(part, "@"); idx >= 0 {
+			w, err := parseWeight(part[idx+1:])
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing weight in %q: %w", part, err)
+			}
+			weight = w
+			part = strings.TrimSpace(part[:idx])
+		}
+
+		var combos []Combo
+		var err error
+
+		// Check if this is a top-percent range (e.g., "T15%") or contains a dash
+		if isTopPercent(part) {
+			combos, err = parseTopPercent(part)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing range %q: %w", part, err)
+			}
+		} else if len(part) == 4 || isSpecificCards(part) {
+			// Specific combo (e.g., "AhKh", or "AsAhKsKh" for Omaha)
+			combo, err := parseSpecificCombo(part)
 			if err != nil {
-				return nil, fmt.Errorf("error parsing range %q: %w", part, err)
+				return nil, nil, fmt.Errorf("error parsing combo %q: %w", part, err)
+			}
+			if specific[combo] {
+				return nil, nil, fmt.Errorf("duplicate combo %q in range", part)
+			}
+			specific[combo] = true
+			combos = []Combo{combo}
+		} else if strings.Contains(part, "-") {
+			combos, err = parseRangeWithDash(part)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing range %q: %w", part, err)
 			}
-			allCombos = append(allCombos, combos...)
 		} else {
 			// Single hand notation
-			combos, err := parseSingleHand(part)
+			combos, err = parseSingleHand(part)
 			if err != nil {
-				return nil, fmt.Errorf("error parsing hand %q: %w", part, err)
+				return nil, nil, fmt.Errorf("error parsing hand %q: %w", part, err)
+			}
+		}
+
+		// Zero-weight hands are not part of the range
+		if weight == 0 {
+			continue
+		}
+
+		// Overlapping components union: a combo keeps its first weight
+		for _, combo := range combos {
+			if seen[combo] {
+				continue
 			}
-			allCombos = append(allCombos, combos...)
+			seen[combo] = true
+			allCombos = append(allCombos, combo)
+			allWeights = append(allWeights, weight)
 		}
 	}
 
-	return allCombos, nil
+	return allCombos, allWeights, nil
+}
+
+// NormalizeRange parses a range string and writes it back in canonical form
+// Hand classes are listed strongest first (pairs before the unpaired hands with
+// the same top card, offsuit before suited) without dashes or repeats, so
+// equivalent inputs give the same string:
+//   - "KK,AA,AA" → "AA,KK"
+//   - "KK-QQ,QQ-JJ" → "KK,QQ,JJ"
+//   - "AKs,AKo" → "AKo,AKs"
+//
+// Weights other than 1 are kept as "@w" suffixes. A class that isn't complete,
+// or whose combos have different weights, is written as specific combos.
+func NormalizeRange(rangeStr string) (string, error) {
+	combos, weights, err := ParseWeightedRange(rangeStr)
+	if err != nil {
+		return "", err
+	}
+
+	type entry struct {
+		combo  Combo
+		weight float64
+	}
+	classes := make(map[string][]entry)
+	for i, combo := range combos {
+		name := handClassName(combo)
+		classes[name] = append(classes[name], entry{combo.Canonical(), weights[i]})
+	}
+
+	names := make([]string, 0, len(classes))
+	for name := range classes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return handClassLess(names[i], names[j])
+	})
+
+	var parts []string
+	withWeight := func(s string, weight float64) string {
+		if weight == 1 {
+			return s
+		}
+		return s + "@" + strconv.FormatFloat(weight, 'f', -1, 64)
+	}
+	for _, name := range names {
+		entries := classes[name]
+
+		uniform := true
+		for _, e := range entries {
+			uniform = uniform && e.weight == entries[0].weight
+		}
+		if uniform && len(entries) == handClassSize(name) {
+			parts = append(parts, withWeight(name, entries[0].weight))
+			continue
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return comboLess(entries[i].combo, entries[j].combo)
+		})
+		for _, e := range entries {
+			parts = append(parts, withWeight(e.combo.String(), e.weight))
+		}
+	}
+
+	return strings.Join(parts, ","), nil
+}
+
+// handClassLess orders hand class names by top rank, then second rank (both
+// descending), then pair, offsuit, suited
+func handClassLess(a, b string) bool {
+	rank := func(c byte) cards.Rank {
+		r, _ := parseRankChar(c)
+		return r
+	}
+	if ra, rb := rank(a[0]), rank(b[0]); ra != rb {
+		return ra > rb
+	}
+	if ra, rb := rank(a[1]), rank(b[1]); ra != rb {
+		return ra > rb
+	}
+	return a < b
+}
+
+// comboLess orders canonical combos from the highest cards down
+func comboLess(a, b Combo) bool {
+	ha, hb := a.Cards(), b.Cards()
+	for i := 0; i < len(ha) && i < len(hb); i++ {
+		if ha[i] != hb[i] {
+			return cardLess(ha[i], hb[i])
+		}
+	}
+	return len(ha) < len(hb)
+}
+
+// parseWeight parses a range component weight: "0.5" → 0.5
+func parseWeight(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("missing weight after @")
+	}
+
+	weight, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid weight %q: %w", s, err)
+	}
+
+	if weight < 0 || weight > 1 {
+		return 0, fmt.Errorf("weight %v out of range (must be between 0 and 1)", weight)
+	}
+
+	return weight, nil
 }
 
 // parseSingleHand parses a single hand notation (e.g., "AA", "AKs", "AKo")
+// Two distinct ranks without an indicator ("AK") mean both suited and offsuit (16 combos).
 func parseSingleHand(hand string) ([]Combo, error) {
 	hand = strings.TrimSpace(hand)
 
@@ -91,16 +395,26 @@ func parseSingleHand(hand string) ([]Combo, error) {
 		default:
 			return nil, fmt.Errorf("invalid suited/offsuit indicator: %c (expected 's' or 'o')", hand[2])
 		}
-	} else {
-		// No indicator means it's a pair
-		if rank1 != rank2 {
-			return nil, fmt.Errorf("ambiguous hand %q (use 's' for suited or 'o' for offsuit)", hand)
-		}
+	} else if rank1 != rank2 {
+		// No indicator on a non-pair: suited and offsuit combos
+		combos := generateCombos(rank1, rank2, true)
+		return append(combos, generateCombos(rank1, rank2, false)...), nil
 	}
 
 	return generateCombos(rank1, rank2, suited), nil
 }
 
+// parseSpecificCombo parses two or four concrete cards (e.g., "AhKh", "AsAhKsKh")
+// into a combo. Cards are ordered like generateCombos (higher rank first, pairs by
+// suit order) so a specific combo matches the same combo from a category like "AKs".
+func parseSpecificCombo(s string) (Combo, error) {
+	hole, err := cards.ParseCards(s)
+	if err != nil {
+		return Combo{}, err
+	}
+	return NewCombo(hole)
+}
+
 // parseRangeWithDash parses a range with a dash (e.g., "KK-JJ", "AKs-ATs")
 func parseRangeWithDash(rangeStr string) ([]Combo, error) {
 	parts := strings.Split(rangeStr, "-")
@@ -228,7 +228,7 @@ func TestParseRange_Errors(t *testing.T) {
 	}{
 		{"", true},        // empty
 		{"A", true},       // too short
-		{"AK", true},      // ambiguous (need s or o)
+		{"AKK", true},     // invalid indicator
 		{"AKx", true},     // invalid indicator
 		{"AA-KKo", true},  // mismatched suited/offsuit
 		{"AKs-AQo", true}, // mismatched suited/offsuit
@@ -246,6 +246,38 @@ func TestParseRange_Errors(t *testing.T) {
 	}
 }
 
+func TestParseRange_SuitedAndOffsuit(t *testing.T) {
+	combos, err := ParseRange("AK")
+	if err != nil {
+		t.Fatalf("ParseRange(AK) error = %v", err)
+	}
+	if len(combos) != 16 {
+		t.Fatalf("AK: got %d combos, want 16", len(combos))
+	}
+
+	suited := 0
+	for _, c := range combos {
+		if c.Card1.Rank != cards.Ace || c.Card2.Rank != cards.King {
+			t.Errorf("unexpected combo %v in AK", c)
+		}
+		if c.Card1.Suit == c.Card2.Suit {
+			suited++
+		}
+	}
+	if suited != 4 || len(combos)-suited != 12 {
+		t.Errorf("AK: got %d suited + %d offsuit, want 4 + 12", suited, len(combos)-suited)
+	}
+
+	// Lowercase and combined with other components
+	combos, err = ParseRange("QQ,kq")
+	if err != nil {
+		t.Fatalf("ParseRange(QQ,kq) error = %v", err)
+	}
+	if len(combos) != 22 {
+		t.Errorf("QQ,kq: got %d combos, want 22", len(combos))
+	}
+}
+
 func TestCombo_String(t *testing.T) {
 	combo := Combo{
 		Card1: cards.NewCard(cards.Ace, cards.Spades),
@@ -294,3 +326,308 @@ func TestParseRange_PairComboOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRange_SpecificCombos(t *testing.T) {
+	combos, err := ParseRange("AA,AhKh")
+	if err != nil {
+		t.Fatalf("ParseRange(AA,AhKh) error = %v", err)
+	}
+	if len(combos) != 7 {
+		t.Fatalf("AA,AhKh: got %d combos, want 7", len(combos))
+	}
+	want := Combo{Card1: cards.NewCard(cards.Ace, cards.Hearts), Card2: cards.NewCard(cards.King, cards.Hearts)}
+	if combos[6] != want {
+		t.Errorf("last combo = %v, want %v", combos[6], want)
+	}
+
+	// Card order is normalized, so a specific combo unions with its category
+	combos, err = ParseRange("KhAh,AKs,KsQs@0.5")
+	if err != nil {
+		t.Fatalf("ParseRange(KhAh,AKs,KsQs@0.5) error = %v", err)
+	}
+	if len(combos) != 5 {
+		t.Errorf("KhAh,AKs,KsQs: got %d combos, want 5", len(combos))
+	}
+
+	for _, bad := range []string{
+		"AA,AhKx",   // invalid suit
+		"AhAh",      // same card twice
+		"AhKh,AhKh", // duplicate combo
+		"AhKh,KhAh", // duplicate combo, reversed
+		"1hKh",      // invalid rank
+	} {
+		if _, err := ParseRange(bad); err == nil {
+			t.Errorf("ParseRange(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseRange_OmahaCombos(t *testing.T) {
+	combos, err := ParseRange("KhAsKsAh,QsQhJsJh@0.5")
+	if err != nil {
+		t.Fatalf("ParseRange() error = %v", err)
+	}
+	if len(combos) != 2 {
+		t.Fatalf("got %d combos, want 2", len(combos))
+	}
+
+	// Cards are put in canonical order
+	if got := combos[0].String(); got != "AsAhKsKh" {
+		t.Errorf("first combo = %s, want AsAhKsKh", got)
+	}
+	if !combos[0].Omaha || len(combos[0].Cards()) != 4 {
+		t.Errorf("first combo %#v should hold four cards", combos[0])
+	}
+	if !combos[0].Conflicts([]cards.Card{cards.NewCard(cards.King, cards.Hearts)}) {
+		t.Error("AsAhKsKh should conflict with Kh")
+	}
+
+	for _, bad := range []string{
+		"AsAhKsKs",          // same card twice
+		"AsAhKsKh,KhKsAhAs", // duplicate combo, reordered
+	} {
+		if _, err := ParseRange(bad); err == nil {
+			t.Errorf("ParseRange(%q) expected error", bad)
+		}
+	}
+}
+
+func TestParseWeightedRange(t *testing.T) {
+	combos, weights, err := ParseWeightedRange("AA@0.5,KK,AKs@0.25")
+	if err != nil {
+		t.Fatalf("ParseWeightedRange error = %v", err)
+	}
+
+	// AA(6) + KK(6) + AKs(4)
+	if len(combos) != 16 {
+		t.Fatalf("expected 16 combos, got %d", len(combos))
+	}
+	if len(weights) != len(combos) {
+		t.Fatalf("expected %d weights, got %d", len(combos), len(weights))
+	}
+
+	for i, combo := range combos {
+		var want float64
+		switch {
+		case combo.Card1.Rank == cards.Ace && combo.Card2.Rank == cards.Ace:
+			want = 0.5
+		case combo.Card1.Rank == cards.King && combo.Card2.Rank == cards.King:
+			want = 1.0
+		default:
+			want = 0.25
+		}
+		if weights[i] != want {
+			t.Errorf("combo %s: weight = %.2f, want %.2f", combo, weights[i], want)
+		}
+	}
+}
+
+func TestParseWeightedRange_ZeroWeightExcluded(t *testing.T) {
+	combos, weights, err := ParseWeightedRange("AA,KK@0,QQ-JJ@0.3")
+	if err != nil {
+		t.Fatalf("ParseWeightedRange error = %v", err)
+	}
+
+	// AA(6) + QQ(6) + JJ(6), KK excluded
+	if len(combos) != 18 || len(weights) != 18 {
+		t.Fatalf("expected 18 combos/weights, got %d/%d", len(combos), len(weights))
+	}
+	for _, combo := range combos {
+		if combo.Card1.Rank == cards.King {
+			t.Errorf("weight-0 hand KK should be excluded, found %s", combo)
+		}
+	}
+
+	// ParseRange honors the same exclusion
+	plain, err := ParseRange("AA,KK@0")
+	if err != nil {
+		t.Fatalf("ParseRange error = %v", err)
+	}
+	if len(plain) != 6 {
+		t.Errorf("ParseRange(\"AA,KK@0\") returned %d combos, want 6", len(plain))
+	}
+}
+
+func TestParseWeightedRange_Errors(t *testing.T) {
+	tests := []string{
+		"AA@",     // missing weight
+		"AA@x",    // not a number
+		"AA@1.5",  // above 1
+		"AA@-1",   // negative
+		"AKx@0.5", // bad hand with weight
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if _, _, err := ParseWeightedRange(input); err == nil {
+				t.Errorf("ParseWeightedRange(%q) expected error", input)
+			}
+		})
+	}
+}
+
+func TestRemoveBlockers(t *testing.T) {
+	aa, _ := ParseRange("AA")
+	board := []cards.Card{
+		cards.NewCard(cards.Ace, cards.Hearts),
+		cards.NewCard(cards.Seven, cards.Diamonds),
+		cards.NewCard(cards.Two, cards.Clubs),
+	}
+
+	remaining := RemoveBlockers(aa, board)
+	if len(remaining) != 3 {
+		t.Fatalf("RemoveBlockers(AA, Ah7d2c) returned %d combos, want 3", len(remaining))
+	}
+	for _, combo := range remaining {
+		if combo.Conflicts(board) {
+			t.Errorf("combo %s still contains a board card", combo)
+		}
+	}
+
+	// No dead cards leaves the range untouched
+	if got := RemoveBlockers(aa, nil); len(got) != 6 {
+		t.Errorf("RemoveBlockers(AA, nil) returned %d combos, want 6", len(got))
+	}
+}
+
+func TestParsePositionWithOptions_RemoveBlockers(t *testing.T) {
+	fen := "BTN:AA@0.5,KK:S100/BB:QQ:S100|P10|Ah7d2c|>BTN"
+
+	plain, err := ParsePosition(fen)
+	if err != nil {
+		t.Fatalf("ParsePosition failed: %v", err)
+	}
+	if len(plain.Players[0].Range) != 12 {
+		t.Errorf("ParsePosition kept %d BTN combos, want 12", len(plain.Players[0].Range))
+	}
+
+	gs, err := ParsePositionWithOptions(fen, ParseOptions{RemoveBlockers: true})
+	if err != nil {
+		t.Fatalf("ParsePositionWithOptions failed: %v", err)
+	}
+
+	btn := gs.Players[0]
+	if len(btn.Range) != 9 || len(btn.Weights) != 9 {
+		t.Fatalf("BTN has %d combos / %d weights, want 9 / 9", len(btn.Range), len(btn.Weights))
+	}
+	for i, combo := range btn.Range {
+		want := 1.0
+		if combo.Card1.Rank == cards.Ace {
+			want = 0.5
+		}
+		if btn.Weights[i] != want {
+			t.Errorf("combo %s: weight %v, want %v", combo, btn.Weights[i], want)
+		}
+	}
+
+	if len(gs.Players[1].Range) != 6 {
+		t.Errorf("BB has %d combos, want 6", len(gs.Players[1].Range))
+	}
+
+	// Specific cards that hit the board leave an empty range
+	if _, err := ParsePositionWithOptions("BTN:AhKd:S100/BB:QQ:S100|P10|Ah7d2c|>BTN", ParseOptions{RemoveBlockers: true}); err == nil {
+		t.Error("expected error for hole cards that conflict with the board")
+	}
+}
+
+func TestCombo_Canonical(t *testing.T) {
+	tests := []struct {
+		cards string
+		want  string
+	}{
+		{"AsAh", "AsAh"},
+		{"AhAs", "AsAh"},
+		{"KdAs", "AsKd"},
+		{"AsKd", "AsKd"},
+		{"2c2d", "2d2c"},
+	}
+
+	for _, tt := range tests {
+		parsed, err := cards.ParseCards(tt.cards)
+		if err != nil {
+			t.Fatalf("ParseCards(%q) error = %v", tt.cards, err)
+		}
+		combo := Combo{Card1: parsed[0], Card2: parsed[1]}
+		if got := combo.Canonical().String(); got != tt.want {
+			t.Errorf("%s.Canonical() = %s, want %s", tt.cards, got, tt.want)
+		}
+	}
+
+	// Typed hole cards and generated pair combos agree
+	gs, err := ParsePosition("BTN:AhAs:S100/BB:KK:S100|P10|Kh9s4c7d2d|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition error = %v", err)
+	}
+	pairs, _ := ParseRange("AA")
+	if gs.Players[0].Range[0] != pairs[0] {
+		t.Errorf("typed AhAs = %s, want generated %s", gs.Players[0].Range[0], pairs[0])
+	}
+}
+
+func TestAllCombos(t *testing.T) {
+	all := AllCombos(nil)
+	if len(all) != 1326 {
+		t.Errorf("AllCombos(nil) returned %d combos, want 1326", len(all))
+	}
+
+	seen := make(map[Combo]bool)
+	for _, combo := range all {
+		if combo.Card1 == combo.Card2 || combo != combo.Canonical() || seen[combo] {
+			t.Fatalf("invalid or duplicate combo %s", combo)
+		}
+		seen[combo] = true
+	}
+
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	live := AllCombos(board)
+	if len(live) != 1081 {
+		t.Errorf("AllCombos(river board) returned %d combos, want C(47,2) = 1081", len(live))
+	}
+	for _, combo := range live {
+		if combo.Conflicts(board) {
+			t.Errorf("combo %s uses a dead card", combo)
+		}
+	}
+}
+
+func TestNormalizeRange(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"KK,AA,AA", "AA,KK"},
+		{"AKs,AKo", "AKo,AKs"},
+		{"AKo,AKs", "AKo,AKs"},
+		{" 22 , AA ", "AA,22"},
+		{"KK-QQ,QQ-JJ", "KK,QQ,JJ"},
+		{"AKs-ATs,AJs-A9s", "AKs,AQs,AJs,ATs,A9s"},
+		{"AKs,AA,T9s,KQo", "AA,AKs,KQo,T9s"},
+		{"QQ@0.5,AA", "AA,QQ@0.5"},
+		{"KhAh,AA", "AA,AhKh"},
+		{"KhAh,AKs", "AKs"},
+		{"AA@0.5,AsAh", "AA@0.5"},
+		{"AhAs@0.5,AA", "AsAh@0.5,AsAd,AsAc,AhAd,AhAc,AdAc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeRange(tt.input)
+			if err != nil {
+				t.Fatalf("NormalizeRange(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeRange(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			// Normalizing is idempotent and keeps the same combos
+			again, err := NormalizeRange(got)
+			if err != nil || again != got {
+				t.Errorf("NormalizeRange(%q) = %q, %v; want it unchanged", got, again, err)
+			}
+		})
+	}
+
+	if _, err := NormalizeRange("AXs"); err == nil {
+		t.Error("expected error for an invalid range")
+	}
+}
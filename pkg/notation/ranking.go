@@ -0,0 +1,73 @@
+package notation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// totalCombos is the number of distinct two-card starting hands
+const totalCombos = 1326
+
+// preflopHandRanking orders all 169 starting hand classes from strongest to weakest
+// (heads-up all-in equity against a random hand)
+var preflopHandRanking = []string{
+	"AA", "KK", "QQ", "AKs", "JJ", "AQs", "KQs", "AJs", "KJs", "TT",
+	"AKo", "ATs", "QJs", "KTs", "QTs", "JTs", "99", "AQo", "A9s", "KQo",
+	"88", "K9s", "T9s", "A8s", "Q9s", "J9s", "AJo", "A5s", "77", "A7s",
+	"KJo", "A4s", "A3s", "A6s", "QJo", "66", "K8s", "T8s", "A2s", "98s",
+	"J8s", "ATo", "Q8s", "K7s", "KTo", "55", "JTo", "87s", "QTo", "44",
+	"33", "22", "K6s", "97s", "K5s", "76s", "T7s", "K4s", "K3s", "K2s",
+	"Q7s", "86s", "65s", "J7s", "54s", "Q6s", "75s", "96s", "Q5s", "64s",
+	"Q4s", "Q3s", "T9o", "T6s", "Q2s", "A9o", "53s", "85s", "J6s", "J9o",
+	"K9o", "J5s", "Q9o", "43s", "74s", "J4s", "J3s", "95s", "J2s", "63s",
+	"A8o", "52s", "T5s", "84s", "T4s", "T3s", "42s", "T2s", "98o", "T8o",
+	"A5o", "A7o", "73s", "A4o", "32s", "94s", "93s", "J8o", "A3o", "62s",
+	"92s", "K8o", "A6o", "87o", "Q8o", "83s", "A2o", "82s", "97o", "72s",
+	"76o", "K7o", "65o", "T7o", "K6o", "86o", "54o", "K5o", "J7o", "75o",
+	"Q7o", "K4o", "K3o", "96o", "K2o", "64o", "Q6o", "53o", "85o", "T6o",
+	"Q5o", "43o", "Q4o", "Q3o", "74o", "Q2o", "J6o", "63o", "J5o", "95o",
+	"52o", "J4o", "J3o", "42o", "J2o", "84o", "T5o", "T4o", "32o", "T3o",
+	"73o", "T2o", "62o", "94o", "93o", "92o", "83o", "82o", "72o",
+}
+
+// isTopPercent reports whether a range component uses top-N-percent notation ("T15%")
+func isTopPercent(part string) bool {
+	return len(part) > 2 && (part[0] == 'T' || part[0] == 't') && strings.HasSuffix(part, "%")
+}
+
+// parseTopPercent expands "T15%" into the strongest hand classes covering 15% of all combos
+// Whole hand classes are taken in ranking order; the boundary class is included
+// when more than half of its combos fall inside the requested fraction.
+func parseTopPercent(part string) ([]Combo, error) {
+	pctStr := strings.TrimSpace(part[1 : len(part)-1])
+	pct, err := strconv.ParseFloat(pctStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid percentage %q: %w", pctStr, err)
+	}
+	if pct <= 0 || pct > 100 {
+		return nil, fmt.Errorf("percentage %v out of range (must be above 0 and at most 100)", pct)
+	}
+
+	target := pct / 100 * totalCombos
+
+	var combos []Combo
+	for _, hand := range preflopHandRanking {
+		size := float64(handClassSize(hand))
+		if float64(len(combos))+size/2 > target+1e-9 {
+			break
+		}
+
+		handCombos, err := parseSingleHand(hand)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ranking entry %q: %w", hand, err)
+		}
+		combos = append(combos, handCombos...)
+	}
+
+	if len(combos) == 0 {
+		return nil, fmt.Errorf("top %v%% is smaller than the strongest hand", pct)
+	}
+
+	return combos, nil
+}
@@ -0,0 +1,138 @@
+package notation
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPreflopHandRanking_Complete(t *testing.T) {
+	if len(preflopHandRanking) != 169 {
+		t.Fatalf("ranking has %d hands, want 169", len(preflopHandRanking))
+	}
+
+	seen := make(map[string]bool)
+	total := 0
+	for _, hand := range preflopHandRanking {
+		if seen[hand] {
+			t.Errorf("duplicate hand %s in ranking", hand)
+		}
+		seen[hand] = true
+
+		combos, err := parseSingleHand(hand)
+		if err != nil {
+			t.Fatalf("invalid ranking entry %q: %v", hand, err)
+		}
+		total += len(combos)
+	}
+
+	if total != totalCombos {
+		t.Errorf("ranking covers %d combos, want %d", total, totalCombos)
+	}
+}
+
+func TestParseRange_TopPercent(t *testing.T) {
+	all, err := ParseRange("T100%")
+	if err != nil {
+		t.Fatalf("ParseRange(T100%%) error = %v", err)
+	}
+	if len(all) != totalCombos {
+		t.Errorf("T100%% returned %d combos, want %d", len(all), totalCombos)
+	}
+
+	top, err := ParseRange("T15%")
+	if err != nil {
+		t.Fatalf("ParseRange(T15%%) error = %v", err)
+	}
+
+	// Whole classes are taken, so the count lands within half a class (≤ 6 combos) of 15%
+	target := 0.15 * totalCombos
+	if math.Abs(float64(len(top))-target) > 6 {
+		t.Errorf("T15%% returned %d combos, want about %.0f", len(top), target)
+	}
+
+	contains := func(combos []Combo, hand string) bool {
+		handCombos, _ := parseSingleHand(hand)
+		set := make(map[Combo]bool)
+		for _, c := range combos {
+			set[c] = true
+		}
+		for _, c := range handCombos {
+			if !set[c] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !contains(top, "AA") || !contains(top, "AKo") {
+		t.Error("T15% should contain AA and AKo")
+	}
+	if contains(top, "72o") {
+		t.Error("T15% should not contain 72o")
+	}
+
+	// Monotonic: a bigger percentage never has fewer combos
+	prev := 0
+	for pct := 1; pct <= 100; pct++ {
+		combos, err := ParseRange(fmt.Sprintf("T%d%%", pct))
+		if err != nil {
+			t.Fatalf("T%d%% error = %v", pct, err)
+		}
+		if len(combos) < prev {
+			t.Errorf("T%d%% has %d combos, fewer than previous %d", pct, len(combos), prev)
+		}
+		prev = len(combos)
+	}
+}
+
+func TestParseRange_TopPercentUnion(t *testing.T) {
+	top, _ := ParseRange("T15%")
+
+	// AKo is already in the top 15%, so the union adds nothing
+	withAKo, err := ParseRange("T15%,AKo")
+	if err != nil {
+		t.Fatalf("ParseRange error = %v", err)
+	}
+	if len(withAKo) != len(top) {
+		t.Errorf("T15%%,AKo returned %d combos, want %d", len(withAKo), len(top))
+	}
+
+	// 72o is not, so it adds its 12 combos
+	with72o, err := ParseRange("T15%,72o")
+	if err != nil {
+		t.Fatalf("ParseRange error = %v", err)
+	}
+	if len(with72o) != len(top)+12 {
+		t.Errorf("T15%%,72o returned %d combos, want %d", len(with72o), len(top)+12)
+	}
+
+	// Weight suffix applies to the whole top-percent component
+	combos, weights, err := ParseWeightedRange("T5%@0.5")
+	if err != nil {
+		t.Fatalf("ParseWeightedRange error = %v", err)
+	}
+	for i := range combos {
+		if weights[i] != 0.5 {
+			t.Fatalf("combo %s: weight %v, want 0.5", combos[i], weights[i])
+		}
+	}
+}
+
+func TestParseRange_TopPercentErrors(t *testing.T) {
+	tests := []string{
+		"T0%",    // empty range
+		"T-5%",   // negative
+		"T101%",  // above 100
+		"Tx%",    // not a number
+		"T0.01%", // smaller than the strongest hand
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseRange(input); err == nil {
+				t.Errorf("ParseRange(%q) expected error", input)
+			}
+		})
+	}
+}
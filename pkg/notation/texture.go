@@ -0,0 +1,146 @@
+package notation
+
+import (
+	"strings"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+)
+
+// BoardTexture summarizes the structure of a board for reporting and strategy grouping
+type BoardTexture struct {
+	// Suit distribution
+	MaxSuitCount  int  // Most cards sharing a single suit
+	Monotone      bool // All cards share one suit (3+ cards)
+	TwoTone       bool // Exactly two cards of the most common suit (flush draw possible)
+	Rainbow       bool // No two cards share a suit
+	FlushPossible bool // Three or more cards of one suit
+
+	// Rank distribution
+	Paired bool // At least two cards share a rank
+	Trips  bool // At least three cards share a rank
+
+	// Connectivity
+	Connected        bool // Two distinct ranks within two of each other (e.g., T9 or T8)
+	StraightPossible bool // Three distinct ranks fit in a five-rank window
+
+	// Dry means no straight or flush draws: not connected and rainbow
+	Dry bool
+
+	// High cards
+	HighCard      cards.Rank // Highest rank on the board
+	BroadwayCount int        // Distinct ranks from Ten to Ace
+	Broadway      bool       // Two or more distinct broadway ranks
+}
+
+// ClassifyBoard computes the texture of a flop, turn, or river board
+// An empty board returns the zero BoardTexture.
+func ClassifyBoard(board []cards.Card) BoardTexture {
+	var tex BoardTexture
+	if len(board) == 0 {
+		return tex
+	}
+
+	var suitCounts [4]int
+	var rankCounts [13]int
+	for _, card := range board {
+		suitCounts[card.Suit]++
+		rankCounts[card.Rank]++
+		if card.Rank > tex.HighCard {
+			tex.HighCard = card.Rank
+		}
+	}
+
+	for _, n := range suitCounts {
+		if n > tex.MaxSuitCount {
+			tex.MaxSuitCount = n
+		}
+	}
+	tex.Monotone = len(board) >= 3 && tex.MaxSuitCount == len(board)
+	tex.TwoTone = tex.MaxSuitCount == 2
+	tex.Rainbow = tex.MaxSuitCount == 1
+	tex.FlushPossible = tex.MaxSuitCount >= 3
+
+	// Rank presence with the ace also counted as low (index 0) for wheel connectivity
+	var present [14]bool
+	for r, n := range rankCounts {
+		if n >= 2 {
+			tex.Paired = true
+		}
+		if n >= 3 {
+			tex.Trips = true
+		}
+		if n > 0 {
+			present[r+1] = true
+			if cards.Rank(r) >= cards.Ten {
+				tex.BroadwayCount++
+			}
+		}
+	}
+	present[0] = present[cards.Ace+1]
+	tex.Broadway = tex.BroadwayCount >= 2
+
+	for low := 0; low < len(present); low++ {
+		if !present[low] {
+			continue
+		}
+		for gap := 1; gap <= 2 && low+gap < len(present); gap++ {
+			if present[low+gap] {
+				tex.Connected = true
+			}
+		}
+	}
+
+	// Windows A-5 through T-A
+	for low := 0; low+4 < len(present); low++ {
+		distinct := 0
+		for r := low; r <= low+4; r++ {
+			if present[r] {
+				distinct++
+			}
+		}
+		if distinct >= 3 {
+			tex.StraightPossible = true
+			break
+		}
+	}
+
+	tex.Dry = !tex.Connected && tex.Rainbow
+
+	return tex
+}
+
+// String returns a short description (e.g., "two-tone, connected, broadway")
+func (t BoardTexture) String() string {
+	var parts []string
+
+	switch {
+	case t.Monotone:
+		parts = append(parts, "monotone")
+	case t.FlushPossible:
+		parts = append(parts, "flush possible")
+	case t.TwoTone:
+		parts = append(parts, "two-tone")
+	case t.Rainbow:
+		parts = append(parts, "rainbow")
+	}
+
+	switch {
+	case t.Trips:
+		parts = append(parts, "trips")
+	case t.Paired:
+		parts = append(parts, "paired")
+	}
+
+	switch {
+	case t.Connected:
+		parts = append(parts, "connected")
+	case t.Dry:
+		parts = append(parts, "dry")
+	}
+
+	if t.Broadway {
+		parts = append(parts, "broadway")
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,101 @@
+package notation
+
+import (
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+)
+
+func TestClassifyBoard(t *testing.T) {
+	tests := []struct {
+		board string
+		want  BoardTexture
+		desc  string
+	}{
+		{
+			board: "Th9h2c",
+			want: BoardTexture{
+				MaxSuitCount:  2,
+				TwoTone:       true,
+				Connected:     true,
+				HighCard:      cards.Ten,
+				BroadwayCount: 1,
+			},
+			desc: "two-tone, connected",
+		},
+		{
+			board: "KsKd4h",
+			want: BoardTexture{
+				MaxSuitCount:  1,
+				Rainbow:       true,
+				Paired:        true,
+				Dry:           true,
+				HighCard:      cards.King,
+				BroadwayCount: 1,
+			},
+			desc: "rainbow, paired, dry",
+		},
+		{
+			board: "AhQhTh",
+			want: BoardTexture{
+				MaxSuitCount:     3,
+				Monotone:         true,
+				FlushPossible:    true,
+				Connected:        true,
+				StraightPossible: true,
+				HighCard:         cards.Ace,
+				BroadwayCount:    3,
+				Broadway:         true,
+			},
+			desc: "monotone, connected, broadway",
+		},
+		{
+			// Ace plays low: A-2 is connected and A-2-4 fits the wheel window
+			board: "As2d4c",
+			want: BoardTexture{
+				MaxSuitCount:     1,
+				Rainbow:          true,
+				Connected:        true,
+				StraightPossible: true,
+				HighCard:         cards.Ace,
+				BroadwayCount:    1,
+			},
+			desc: "rainbow, connected",
+		},
+		{
+			board: "7s7d7c2h",
+			want: BoardTexture{
+				MaxSuitCount: 1,
+				Rainbow:      true,
+				Paired:       true,
+				Trips:        true,
+				Dry:          true,
+				HighCard:     cards.Seven,
+			},
+			desc: "rainbow, trips, dry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.board, func(t *testing.T) {
+			board, err := parseBoard(tt.board)
+			if err != nil {
+				t.Fatalf("parseBoard(%q) error = %v", tt.board, err)
+			}
+
+			got := ClassifyBoard(board)
+			if got != tt.want {
+				t.Errorf("ClassifyBoard(%s) = %+v, want %+v", tt.board, got, tt.want)
+			}
+			if got.String() != tt.desc {
+				t.Errorf("String() = %q, want %q", got.String(), tt.desc)
+			}
+		})
+	}
+}
+
+func TestClassifyBoard_Empty(t *testing.T) {
+	if got := ClassifyBoard(nil); got != (BoardTexture{}) {
+		t.Errorf("ClassifyBoard(nil) = %+v, want zero value", got)
+	}
+}
@@ -2,6 +2,8 @@ package notation
 
 import (
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
 )
@@ -36,12 +38,27 @@ func (a ActionType) String() string {
 }
 
 // Action represents a poker action with an optional amount (for bets/raises)
+// Bet amounts are the chips put in. Raise amounts are "raise to" totals: the
+// raiser's entire commitment for the street, so "b5r15" means a 5bb bet raised
+// to 15bb total (10bb more than the bet). Calls match the opponent's total.
 type Action struct {
 	Type   ActionType
 	Amount float64 // In big blinds (0 for check/call/fold)
 }
 
+// amountTolerance absorbs float error when rounding amounts, in tenths of a bb
+const amountTolerance = 1e-6
+
+// RoundAmount rounds a bet or raise amount to the 0.1bb precision of action keys
+// Halves round up, and amounts within a hair of a half (9.7499999999 from
+// 0.75 × 13 with float error) count as one, so sizes that should be equal
+// always format the same way.
+func RoundAmount(amount float64) float64 {
+	return math.Floor(amount*10+0.5+amountTolerance) / 10
+}
+
 // String returns the action in notation format (e.g., "c", "b3.5", "r9")
+// Amounts are rounded with RoundAmount, so String is stable as an action key.
 func (a Action) String() string {
 	switch a.Type {
 	case Check:
@@ -49,9 +66,9 @@ func (a Action) String() string {
 	case Call:
 		return "c"
 	case Bet:
-		return fmt.Sprintf("b%.1f", a.Amount)
+		return fmt.Sprintf("b%.1f", RoundAmount(a.Amount))
 	case Raise:
-		return fmt.Sprintf("r%.1f", a.Amount)
+		return fmt.Sprintf("r%.1f", RoundAmount(a.Amount))
 	case Fold:
 		return "f"
 	default:
@@ -71,11 +88,54 @@ const (
 	CO  Position = "CO"  // Cutoff
 )
 
+// postflopOrder lists the known positions in postflop action order: the blinds
+// act first and the button last. Preflop the blinds act last instead.
+var postflopOrder = []Position{SB, BB, UTG, MP, CO, BTN}
+
+// IsValid reports whether p is one of the known positions
+func (p Position) IsValid() bool {
+	return p.PostflopOrder() >= 0
+}
+
+// PostflopOrder returns p's place in postflop action order (0 acts first), or -1
+// for an unknown position
+func (p Position) PostflopOrder() int {
+	for i, pos := range postflopOrder {
+		if pos == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// PreflopOrder returns p's place in preflop action order (UTG first, BB last),
+// or -1 for an unknown position
+func (p Position) PreflopOrder() int {
+	i := p.PostflopOrder()
+	if i < 0 {
+		return -1
+	}
+	return (i + len(postflopOrder) - 2) % len(postflopOrder)
+}
+
+// SortPostflop sorts positions into postflop action order
+// Unknown positions sort first.
+func SortPostflop(positions []Position) {
+	sort.SliceStable(positions, func(i, j int) bool {
+		return positions[i].PostflopOrder() < positions[j].PostflopOrder()
+	})
+}
+
 // PlayerRange represents a player's range and stack
 type PlayerRange struct {
 	Position Position
-	Range    []Combo // All possible hole card combinations
-	Stack    float64 // Stack size in big blinds
+	Range    []Combo   // All possible hole card combinations
+	Weights  []float64 // Optional per-combo frequencies parallel to Range (nil = uniform)
+	Stack    float64   // Stack size in big blinds
+
+	// Unknown marks a "??" holding: any two cards. ParsePosition fills Range with
+	// every combo not blocked by the board or another player's known hole cards.
+	Unknown bool
 }
 
 // Street represents which betting round we're on
@@ -112,6 +172,10 @@ type GameState struct {
 	// Current pot size (in big blinds)
 	Pot float64
 
+	// DeadMoney is the part of Pot neither player put in (antes, a dead straddle)
+	// Whoever wins the pot takes it, but neither player has it at stake.
+	DeadMoney float64
+
 	// Community cards (board)
 	Board []cards.Card
 
@@ -146,6 +210,7 @@ func (gs *GameState) Clone() *GameState {
 	clone := &GameState{
 		Players:       make([]PlayerRange, len(gs.Players)),
 		Pot:           gs.Pot,
+		DeadMoney:     gs.DeadMoney,
 		Board:         make([]cards.Card, len(gs.Board)),
 		ActionHistory: make([]Action, len(gs.ActionHistory)),
 		ToAct:         gs.ToAct,
@@ -157,7 +222,9 @@ func (gs *GameState) Clone() *GameState {
 		clone.Players[i] = PlayerRange{
 			Position: player.Position,
 			Range:    player.Range, // Shallow copy is ok - ranges are immutable
+			Weights:  player.Weights,
 			Stack:    player.Stack,
+			Unknown:  player.Unknown,
 		}
 	}
 
@@ -90,23 +90,51 @@ func TestStreet_String(t *testing.T) {
 	}
 }
 
+func TestPosition_Order(t *testing.T) {
+	positions := []Position{BTN, CO, BB, UTG, SB, MP}
+	SortPostflop(positions)
+	want := []Position{SB, BB, UTG, MP, CO, BTN}
+	for i := range want {
+		if positions[i] != want[i] {
+			t.Fatalf("SortPostflop() = %v, want %v", positions, want)
+		}
+	}
+
+	// Preflop the blinds act last
+	preflop := []Position{UTG, MP, CO, BTN, SB, BB}
+	for i, p := range preflop {
+		if got := p.PreflopOrder(); got != i {
+			t.Errorf("%s.PreflopOrder() = %d, want %d", p, got, i)
+		}
+	}
+
+	if Position("HJ").IsValid() || Position("HJ").PostflopOrder() != -1 || Position("HJ").PreflopOrder() != -1 {
+		t.Error("HJ should be an unknown position")
+	}
+	if !BTN.IsValid() {
+		t.Error("BTN should be a known position")
+	}
+}
+
 func TestGameState_Clone(t *testing.T) {
 	// Create an original game state
 	original := &GameState{
 		Players: []PlayerRange{
 			{
 				Position: BTN,
-				Range:    []Combo{{cards.NewCard(cards.Ace, cards.Spades), cards.NewCard(cards.King, cards.Spades)}},
+				Range:    []Combo{{Card1: cards.NewCard(cards.Ace, cards.Spades), Card2: cards.NewCard(cards.King, cards.Spades)}},
 				Stack:    100.0,
 			},
 			{
 				Position: BB,
-				Range:    []Combo{{cards.NewCard(cards.Queen, cards.Hearts), cards.NewCard(cards.Queen, cards.Diamonds)}},
+				Range:    []Combo{{Card1: cards.NewCard(cards.Queen, cards.Hearts), Card2: cards.NewCard(cards.Queen, cards.Diamonds)}},
 				Stack:    98.0,
+				Unknown:  true,
 			},
 		},
-		Pot:   3.0,
-		Board: []cards.Card{cards.NewCard(cards.King, cards.Hearts), cards.NewCard(cards.Nine, cards.Spades), cards.NewCard(cards.Four, cards.Clubs)},
+		Pot:       3.0,
+		DeadMoney: 0.5,
+		Board:     []cards.Card{cards.NewCard(cards.King, cards.Hearts), cards.NewCard(cards.Nine, cards.Spades), cards.NewCard(cards.Four, cards.Clubs)},
 		ActionHistory: []Action{
 			{Type: Bet, Amount: 2.0},
 			{Type: Call},
@@ -132,6 +160,14 @@ func TestGameState_Clone(t *testing.T) {
 		t.Errorf("Pot: got %.1f, want %.1f", clone.Pot, original.Pot)
 	}
 
+	if clone.DeadMoney != original.DeadMoney {
+		t.Errorf("DeadMoney: got %.1f, want %.1f", clone.DeadMoney, original.DeadMoney)
+	}
+
+	if clone.Players[1].Unknown != original.Players[1].Unknown {
+		t.Errorf("Unknown: got %v, want %v", clone.Players[1].Unknown, original.Players[1].Unknown)
+	}
+
 	if len(clone.Board) != len(original.Board) {
 		t.Errorf("Board size: got %d, want %d", len(clone.Board), len(original.Board))
 	}
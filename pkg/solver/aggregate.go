@@ -0,0 +1,93 @@
+package solver
+
+import (
+	"strings"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// AggregatedStrategy holds the averaged strategy for a hand type in one situation
+type AggregatedStrategy struct {
+	Player   string // Acting position (e.g., "BTN")
+	History  string // Action history of the situation
+	HandType string // Hand class like "AA" or "AKs", or a bucket like "BUCKET_3"
+	Actions  []notation.Action
+	Probs    []float64 // Average strategy, averaged over the combos
+	Count    int       // Number of combos (info sets) averaged
+}
+
+// AggregateByHandType averages the strategies of combos that share a hand type
+// and situation (e.g., the six AA combos facing a bet become one "AA" entry)
+// Keys are "player|history|handType". Info sets that aren't in the
+// "board|history|>player|cards" format are skipped.
+func (sp *StrategyProfile) AggregateByHandType() map[string]AggregatedStrategy {
+	aggregated := make(map[string]*AggregatedStrategy)
+
+	for infoSet, strat := range sp.All() {
+		parts := strings.Split(infoSet, "|")
+		if len(parts) != 4 {
+			continue
+		}
+		player := strings.TrimPrefix(parts[2], ">")
+		history := parts[1]
+		handType := handType(parts[3])
+
+		key := player + "|" + history + "|" + handType
+		agg, exists := aggregated[key]
+		if !exists {
+			agg = &AggregatedStrategy{
+				Player:   player,
+				History:  history,
+				HandType: handType,
+				Actions:  strat.Actions,
+				Probs:    make([]float64, len(strat.Actions)),
+			}
+			aggregated[key] = agg
+		}
+
+		// Add this combo's strategy to the aggregate
+		for i, prob := range strat.GetAverageStrategy() {
+			if i < len(agg.Probs) {
+				agg.Probs[i] += prob
+			}
+		}
+		agg.Count++
+	}
+
+	// Average the probabilities
+	result := make(map[string]AggregatedStrategy, len(aggregated))
+	for key, agg := range aggregated {
+		for i := range agg.Probs {
+			agg.Probs[i] /= float64(agg.Count)
+		}
+		result[key] = *agg
+	}
+
+	return result
+}
+
+// handType extracts the hand class from specific hole cards
+// e.g., "AsAh" -> "AA", "Td9d" -> "T9s", "9cTd" -> "T9o"
+// Bucketed hands ("BUCKET_3") and anything else unrecognized are returned as-is.
+func handType(cards string) string {
+	if strings.HasPrefix(cards, "BUCKET_") || len(cards) < 4 {
+		return cards
+	}
+
+	rank1, rank2 := cards[0], cards[2]
+	if rank1 == rank2 {
+		return string([]byte{rank1, rank2})
+	}
+
+	suited := "o"
+	if cards[1] == cards[3] {
+		suited = "s"
+	}
+
+	// Higher rank first
+	const ranks = "AKQJT98765432"
+	if strings.IndexByte(ranks, rank1) > strings.IndexByte(ranks, rank2) {
+		rank1, rank2 = rank2, rank1
+	}
+	return string([]byte{rank1, rank2}) + suited
+}
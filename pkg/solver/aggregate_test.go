@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestAggregateByHandType(t *testing.T) {
+	actions := []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 5}}
+	profile := NewStrategyProfile()
+
+	// Six AA combos: half always check, half always bet
+	aces, _ := notation.ParseRange("AA")
+	for i, combo := range aces {
+		strat := profile.GetOrCreate("Kh9s4c7d2s||>BTN|"+combo.String(), actions)
+		strat.StrategySum = []float64{1, 0}
+		if i%2 == 1 {
+			strat.StrategySum = []float64{0, 1}
+		}
+	}
+	// A different hand type and a different situation stay separate
+	profile.GetOrCreate("Kh9s4c7d2s||>BTN|AhKh", actions).StrategySum = []float64{0, 1}
+	profile.GetOrCreate("Kh9s4c7d2s|x|>BB|AsAh", actions).StrategySum = []float64{1, 0}
+
+	aggregated := profile.AggregateByHandType()
+	if len(aggregated) != 3 {
+		t.Fatalf("got %d aggregates, want 3: %v", len(aggregated), aggregated)
+	}
+
+	aa, ok := aggregated["BTN||AA"]
+	if !ok {
+		t.Fatal("expected a single BTN AA entry")
+	}
+	if aa.Count != 6 || aa.HandType != "AA" || aa.Player != "BTN" || aa.History != "" {
+		t.Errorf("AA aggregate = %+v, want 6 BTN combos", aa)
+	}
+	for i, want := range []float64{0.5, 0.5} {
+		if math.Abs(aa.Probs[i]-want) > 1e-9 {
+			t.Errorf("AA %s frequency = %.3f, want %.3f", aa.Actions[i], aa.Probs[i], want)
+		}
+	}
+
+	if aks := aggregated["BTN||AKs"]; aks.Count != 1 || aks.Probs[1] != 1 {
+		t.Errorf("AKs aggregate = %+v, want one always-betting combo", aks)
+	}
+	if bb := aggregated["BB|x|AA"]; bb.Count != 1 {
+		t.Errorf("BB AA after a check = %+v, want one combo", bb)
+	}
+}
+
+func TestHandType(t *testing.T) {
+	tests := map[string]string{
+		"AsAh":     "AA",
+		"AhKh":     "AKs",
+		"9cTd":     "T9o",
+		"BUCKET_3": "BUCKET_3",
+	}
+	for cards, want := range tests {
+		if got := handType(cards); got != want {
+			t.Errorf("handType(%q) = %q, want %q", cards, got, want)
+		}
+	}
+}
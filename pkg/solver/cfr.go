@@ -1,12 +1,68 @@
 package solver
 
 import (
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
 	"github.com/behrlich/poker-solver/pkg/tree"
 )
 
+// Default DCFR parameters (Brown & Sandholm, "Solving Imperfect-Information
+// Games via Discounted Regret Minimization")
+const (
+	DefaultDCFRAlpha = 1.5
+	DefaultDCFRBeta  = 0.0
+	DefaultDCFRGamma = 2.0
+)
+
 // CFR implements vanilla Counterfactual Regret Minimization
+// With discounting enabled (see NewDCFR) it runs Discounted CFR instead
 type CFR struct {
 	profile *StrategyProfile
+
+	// Discounted CFR parameters (nil for vanilla CFR)
+	discount *dcfrParams
+
+	// Number of completed iterations
+	iteration int
+
+	// profileIteration is the profile iteration being run (see StrategyProfile.nextIteration)
+	profileIteration int
+
+	// linear weights iteration t's strategy-sum contribution by t (linear averaging)
+	linear bool
+
+	// workers is the number of goroutines sharing chance-node children (<= 1 is serial)
+	workers int
+}
+
+// pendingUpdate is one info set visit's regret and strategy-sum update, held
+// back while chance children run in parallel
+type pendingUpdate struct {
+	strategy  *Strategy
+	iteration int
+	regrets   []float64
+	current   []float64
+	reach     float64
+	evs       []float64
+	evWeight  float64
+}
+
+// apply adds the update to its strategy
+func (u *pendingUpdate) apply() {
+	u.strategy.UpdateRegrets(u.regrets)
+	u.strategy.beginIteration(u.iteration)
+	u.strategy.UpdateStrategy(u.current, u.reach)
+	u.strategy.addActionEV(u.iteration, u.evs, u.evWeight)
+}
+
+// dcfrParams holds the Discounted CFR exponents
+type dcfrParams struct {
+	alpha float64 // Positive regret discount exponent
+	beta  float64 // Negative regret discount exponent
+	gamma float64 // Average strategy discount exponent
 }
 
 // NewCFR creates a new CFR solver
@@ -16,19 +72,108 @@ func NewCFR() *CFR {
 	}
 }
 
+// NewCFRFromProfile creates a CFR solver that resumes training from an existing profile
+// Accumulated regrets and strategy sums (e.g., from LoadFromFile) carry on where they
+// left off; the profile is updated in place. A nil profile starts fresh.
+func NewCFRFromProfile(profile *StrategyProfile) *CFR {
+	if profile == nil {
+		profile = NewStrategyProfile()
+	}
+	return &CFR{
+		profile: profile,
+	}
+}
+
+// NewDCFR creates a Discounted CFR solver
+// After iteration t, accumulated positive regrets are scaled by t^α/(t^α+1),
+// negative regrets by t^β/(t^β+1), and the average strategy by (t/(t+1))^γ.
+// Good defaults are α=1.5, β=0, γ=2 (see DefaultDCFRAlpha etc.)
+func NewDCFR(alpha, beta, gamma float64) *CFR {
+	return &CFR{
+		profile:  NewStrategyProfile(),
+		discount: &dcfrParams{alpha: alpha, beta: beta, gamma: gamma},
+	}
+}
+
+// SetLinearAveraging enables linear CFR averaging: iteration t's contribution to the
+// average strategy is weighted by t, so early, poor strategies fade from the average.
+// Regret accumulation is unchanged.
+func (c *CFR) SetLinearAveraging(enabled bool) {
+	c.linear = enabled
+}
+
+// SetWorkers sets how many goroutines traverse chance-node children in parallel
+// The first chance node on each path (the combo-pair root of a range tree) is
+// split across workers; everything below it runs serially in its worker.
+// Workers play the strategies as they stood when the chance node was reached
+// and hold their updates until all children are done, then the updates are
+// applied in child key order, so a run repeats exactly whatever the worker
+// count. (Serial traversal instead lets earlier children's updates steer later
+// ones, so results differ slightly from a serial run.)
+// n <= 0 uses runtime.NumCPU(); 1 traverses serially (the default).
+// With more than one worker the profile is switched to a concurrent one in place.
+func (c *CFR) SetWorkers(n int) {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	c.workers = n
+	if n > 1 {
+		c.profile.makeConcurrent()
+	}
+}
+
 // Train runs CFR for the specified number of iterations
 // Returns the strategy profile after training
-func (c *CFR) Train(root *tree.TreeNode, iterations int) *StrategyProfile {
-	for i := 0; i < iterations; i++ {
-		c.Iterate(root)
-	}
+// Optional TrainOptions add cancellation and progress reporting.
+func (c *CFR) Train(root *tree.TreeNode, iterations int, opts ...TrainOptions) *StrategyProfile {
+	runTraining(c, root, iterations, opts)
 	return c.profile
 }
 
+// TrainUntil runs CFR until exploitability is at most targetExploitability,
+// checking every checkEvery iterations, or until maxIters is reached
+// Returns the strategy profile and the number of iterations run
+func (c *CFR) TrainUntil(root *tree.TreeNode, maxIters int, targetExploitability float64, checkEvery int) (*StrategyProfile, int) {
+	return trainUntil(c, root, maxIters, targetExploitability, checkEvery)
+}
+
+// TrainFor runs CFR until the duration d has elapsed
+// Returns the strategy profile and the number of iterations completed (at least 1)
+func (c *CFR) TrainFor(root *tree.TreeNode, d time.Duration) (*StrategyProfile, int) {
+	return trainFor(c, root, d, 0)
+}
+
 // Iterate runs a single CFR iteration
 // This is useful for progress tracking in WASM/UI contexts
 func (c *CFR) Iterate(root *tree.TreeNode) {
+	c.profileIteration = c.profile.nextIteration()
 	c.cfr(root, 1.0, 1.0)
+	c.iteration++
+
+	if c.discount != nil {
+		c.applyDiscount()
+	}
+}
+
+// applyDiscount scales accumulated regrets and strategy sums for DCFR
+func (c *CFR) applyDiscount() {
+	t := float64(c.iteration)
+	posWeight := math.Pow(t, c.discount.alpha) / (math.Pow(t, c.discount.alpha) + 1)
+	negWeight := math.Pow(t, c.discount.beta) / (math.Pow(t, c.discount.beta) + 1)
+	stratWeight := math.Pow(t/(t+1), c.discount.gamma)
+
+	for _, strategy := range c.profile.All() {
+		for i, regret := range strategy.RegretSum {
+			if regret > 0 {
+				strategy.RegretSum[i] = regret * posWeight
+			} else {
+				strategy.RegretSum[i] = regret * negWeight
+			}
+		}
+		for i := range strategy.StrategySum {
+			strategy.StrategySum[i] *= stratWeight
+		}
+	}
 }
 
 // cfr recursively traverses the game tree and updates regrets
@@ -36,6 +181,12 @@ func (c *CFR) Iterate(root *tree.TreeNode) {
 // reachProb1 is the probability that player 1 reaches this node
 // Returns the expected value for each player
 func (c *CFR) cfr(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float64 {
+	return c.walk(node, reachProb0, reachProb1, nil)
+}
+
+// walk is cfr that appends its updates to pending instead of applying them
+// when pending is non-nil (inside a parallel chance node)
+func (c *CFR) walk(node *tree.TreeNode, reachProb0, reachProb1 float64, pending *[]pendingUpdate) [2]float64 {
 	// Terminal node: return payoffs
 	if node.IsTerminal {
 		return node.Payoff
@@ -43,10 +194,14 @@ func (c *CFR) cfr(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float6
 
 	// Chance node: compute expected value over all outcomes
 	if node.IsChance {
+		if pending == nil && c.workers > 1 && len(node.Children) > 1 {
+			return c.chanceParallel(node, reachProb0, reachProb1)
+		}
+
 		nodeValue := [2]float64{0, 0}
 		for childKey, child := range node.Children {
 			prob := node.ChanceProbabilities[childKey]
-			childValue := c.cfr(child, reachProb0*prob, reachProb1*prob)
+			childValue := c.walk(child, reachProb0*prob, reachProb1*prob, pending)
 			nodeValue[0] += prob * childValue[0]
 			nodeValue[1] += prob * childValue[1]
 		}
@@ -80,9 +235,9 @@ func (c *CFR) cfr(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float6
 		// Update reach probabilities based on who's acting
 		var childValue [2]float64
 		if player == 0 {
-			childValue = c.cfr(child, reachProb0*currentStrategy[i], reachProb1)
+			childValue = c.walk(child, reachProb0*currentStrategy[i], reachProb1, pending)
 		} else {
-			childValue = c.cfr(child, reachProb0, reachProb1*currentStrategy[i])
+			childValue = c.walk(child, reachProb0, reachProb1*currentStrategy[i], pending)
 		}
 
 		actionValues[i] = childValue
@@ -93,12 +248,14 @@ func (c *CFR) cfr(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float6
 
 	// Compute regrets and update strategy
 	regrets := make([]float64, numActions)
+	evs := make([]float64, numActions)
 	cfValue := nodeValue[player] // Counterfactual value at this node
 
 	for i := 0; i < numActions; i++ {
 		// Regret = value of action - value of current strategy
 		actionCFValue := actionValues[i][player]
 		regrets[i] = actionCFValue - cfValue
+		evs[i] = actionCFValue
 	}
 
 	// Update regrets weighted by opponent's reach probability
@@ -114,7 +271,6 @@ func (c *CFR) cfr(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float6
 	for i := 0; i < numActions; i++ {
 		scaledRegrets[i] = regrets[i] * cfReachProb
 	}
-	strategy.UpdateRegrets(scaledRegrets)
 
 	// Update strategy sum weighted by own reach probability
 	var ownReachProb float64
@@ -123,8 +279,58 @@ func (c *CFR) cfr(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float6
 	} else {
 		ownReachProb = reachProb1
 	}
-	strategy.UpdateStrategy(currentStrategy, ownReachProb)
+	if c.linear {
+		// Iteration count is 0-based while the pass runs
+		ownReachProb *= float64(c.iteration + 1)
+	}
+
+	update := pendingUpdate{strategy, c.profileIteration, scaledRegrets, currentStrategy, ownReachProb, evs, cfReachProb}
+	if pending != nil {
+		*pending = append(*pending, update)
+	} else {
+		update.apply()
+	}
+
+	return nodeValue
+}
 
+// chanceParallel traverses a chance node's children with a pool of c.workers goroutines
+// Child values are summed, and their held-back updates applied, in key order
+// after all workers finish.
+func (c *CFR) chanceParallel(node *tree.TreeNode, reachProb0, reachProb1 float64) [2]float64 {
+	keys := sortedChildKeys(node)
+	values := make([][2]float64, len(keys))
+	updates := make([][]pendingUpdate, len(keys))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < c.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				prob := node.ChanceProbabilities[keys[i]]
+				pending := make([]pendingUpdate, 0)
+				values[i] = c.walk(node.Children[keys[i]], reachProb0*prob, reachProb1*prob, &pending)
+				updates[i] = pending
+			}
+		}()
+	}
+	for i := range keys {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	nodeValue := [2]float64{0, 0}
+	for i, key := range keys {
+		prob := node.ChanceProbabilities[key]
+		nodeValue[0] += prob * values[i][0]
+		nodeValue[1] += prob * values[i][1]
+		for j := range updates[i] {
+			updates[i][j].apply()
+		}
+	}
 	return nodeValue
 }
 
@@ -0,0 +1,115 @@
+package solver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/abstraction"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// buildRangeTree144 builds a river range tree with 12×12 = 144 combo-pair children
+// With a bucketer, combo subtrees share info sets across chance children.
+func buildRangeTree144(tb testing.TB, bucketed bool) *tree.TreeNode {
+	tb.Helper()
+
+	gs, err := notation.ParsePosition("BTN:AA:S100/BB:KK:S100|P20|9h8s4c3d2s|>BTN")
+	if err != nil {
+		tb.Fatalf("ParsePosition() error = %v", err)
+	}
+	range0, _ := notation.ParseRange("AA,QQ")
+	range1, _ := notation.ParseRange("KK,JJ")
+
+	builder := tree.NewBuilder(tree.ActionConfig{
+		BetSizes:   []float64{0.5, 1.0},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+	})
+	if bucketed {
+		builder.SetBucketer(abstraction.NewBucketer(gs.Board, append(range0, range1...), 4))
+	}
+
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		tb.Fatalf("BuildRange() error = %v", err)
+	}
+	if len(root.Children) != 144 {
+		tb.Fatalf("expected 144 chance children, got %d", len(root.Children))
+	}
+	return root
+}
+
+// TestCFR_ParallelChance runs parallel CFR on trees with and without shared info sets
+// Run with -race to check that concurrent regret updates are guarded.
+func TestCFR_ParallelChance(t *testing.T) {
+	const iterations = 300
+
+	for _, bucketed := range []bool{false, true} {
+		name := "combos"
+		if bucketed {
+			name = "bucketed"
+		}
+		t.Run(name, func(t *testing.T) {
+			root := buildRangeTree144(t, bucketed)
+
+			parallel := NewCFR()
+			parallel.SetWorkers(4)
+			profile := parallel.Train(root, iterations)
+
+			// BTN's root strategy is accumulated with reach = chance probability, and
+			// strategies sum to 1, so each root info set's strategy mass is exactly
+			// iterations × the chance mass of its children. Lost updates would show here.
+			expectedMass := make(map[string]float64)
+			for key, child := range root.Children {
+				expectedMass[child.InfoSet] += iterations * root.ChanceProbabilities[key]
+			}
+			for infoSet, want := range expectedMass {
+				strategy, ok := profile.Get(infoSet)
+				if !ok {
+					t.Fatalf("missing root info set %s", infoSet)
+				}
+				got := 0.0
+				for _, v := range strategy.StrategySum {
+					got += v
+				}
+				if math.Abs(got-want) > 1e-9 {
+					t.Errorf("%s: strategy mass %.12f, want %.12f", infoSet, got, want)
+				}
+			}
+
+			// Update order differs from the serial walk, but both should approach the
+			// same game value. (CalculateExploitability's best response sees the
+			// opponent's cards, so it isn't a convergence measure on range trees.)
+			serialEV := EvaluateStrategy(root, NewCFR().Train(root, iterations))
+			parallelEV := EvaluateStrategy(root, profile)
+			t.Logf("BTN EV: serial %.3f, parallel %.3f", serialEV[0], parallelEV[0])
+			if math.Abs(serialEV[0]-parallelEV[0]) > 0.5 {
+				t.Errorf("parallel BTN EV %.3f differs from serial %.3f", parallelEV[0], serialEV[0])
+			}
+		})
+	}
+}
+
+// BenchmarkCFR_RangeTree compares serial and parallel iterations on the 144-child range tree
+func BenchmarkCFR_RangeTree(b *testing.B) {
+	root := buildRangeTree144(b, false)
+
+	b.Run("serial", func(b *testing.B) {
+		cfr := NewCFR()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cfr.Iterate(root)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		cfr := NewCFR()
+		cfr.SetWorkers(0)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cfr.Iterate(root)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package solver
 import (
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -151,8 +152,263 @@ func TestStrategy_AverageStrategy(t *testing.T) {
 	}
 }
 
+// TestStrategy_PathologicalValues checks that corrupt or extreme regrets and
+// strategy sums still yield a valid distribution
+func TestStrategy_PathologicalValues(t *testing.T) {
+	actions := []notation.Action{
+		{Type: notation.Check},
+		{Type: notation.Bet, Amount: 5},
+		{Type: notation.Bet, Amount: 10},
+	}
+
+	tests := []struct {
+		name   string
+		values []float64
+		want   []float64 // nil = any valid distribution
+	}{
+		{"NaN and negative", []float64{math.NaN(), -3, 2}, []float64{0, 0, 1}},
+		{"infinite", []float64{math.Inf(1), 1, math.Inf(1)}, []float64{0.5, 0, 0.5}},
+		{"overflowing sum", []float64{math.MaxFloat64, math.MaxFloat64, 0}, []float64{0.5, 0.5, 0}},
+		{"all negative", []float64{-1, math.Inf(-1), -1e-300}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		{"subnormal", []float64{5e-324, 5e-324, 0}, []float64{0.5, 0.5, 0}},
+		{"rounding", []float64{0.1, 0.2, 0.7}, nil},
+		{"thirds", []float64{1, 1, 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strat := NewStrategy("test", actions)
+			copy(strat.RegretSum, tt.values)
+			copy(strat.StrategySum, tt.values)
+
+			for _, dist := range [][]float64{strat.GetStrategy(), strat.GetAverageStrategy()} {
+				sum := 0.0
+				for _, p := range dist {
+					if p < 0 || p > 1 || math.IsNaN(p) {
+						t.Fatalf("invalid probability in %v", dist)
+					}
+					sum += p
+				}
+				if sum != 1 {
+					t.Errorf("%v sums to %v, want exactly 1", dist, sum)
+				}
+				for i := range tt.want {
+					if math.Abs(dist[i]-tt.want[i]) > 1e-12 {
+						t.Errorf("got %v, want %v", dist, tt.want)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStrategyProfile_SortedInfoSets(t *testing.T) {
+	profile := NewCFR().Train(BuildKuhnPokerTree(), 50)
+
+	keys := profile.SortedInfoSets()
+	if len(keys) != profile.NumInfoSets() {
+		t.Fatalf("expected %d keys, got %d", profile.NumInfoSets(), len(keys))
+	}
+	for i := 1; i < len(keys); i++ {
+		if keys[i-1] >= keys[i] {
+			t.Errorf("keys not in lexicographic order: %q before %q", keys[i-1], keys[i])
+		}
+	}
+
+	// Stable across calls, and ForEachSorted visits the same order
+	again := profile.SortedInfoSets()
+	var visited []string
+	profile.ForEachSorted(func(infoSet string, strategy *Strategy) {
+		if strategy.InfoSet != infoSet {
+			t.Errorf("strategy for %q has info set %q", infoSet, strategy.InfoSet)
+		}
+		visited = append(visited, infoSet)
+	})
+	for i := range keys {
+		if again[i] != keys[i] || visited[i] != keys[i] {
+			t.Errorf("position %d: got %q and %q, want %q", i, again[i], visited[i], keys[i])
+		}
+	}
+}
+
+func TestStrategyProfile_InfoSetDiagnostics(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	cfr := NewCFR()
+
+	if _, ok := cfr.GetProfile().InfoSetDiagnostics("J|"); ok {
+		t.Fatal("expected no diagnostics before training")
+	}
+
+	changeAfter := func(iterations int) Diagnostics {
+		for i := 0; i < iterations; i++ {
+			cfr.Iterate(root)
+		}
+		d, ok := cfr.GetProfile().InfoSetDiagnostics("J|")
+		if !ok {
+			t.Fatal("no diagnostics for J|")
+		}
+		return d
+	}
+
+	early := changeAfter(10)
+	late := changeAfter(1000)
+	t.Logf("J| average change: %.6f after 10 iterations, %.6f after 1010", early.AverageChange, late.AverageChange)
+
+	if late.AverageChange >= early.AverageChange {
+		t.Errorf("average strategy change should shrink: %.6f -> %.6f", early.AverageChange, late.AverageChange)
+	}
+	if late.Updates <= early.Updates || late.PreviousAverage == nil {
+		t.Errorf("expected more updates and a previous average, got %+v", late)
+	}
+	if len(late.Current) != len(late.Average) {
+		t.Errorf("current strategy has %d actions, average %d", len(late.Current), len(late.Average))
+	}
+}
+
+func TestStrategyProfile_InfoSetDiagnostics_PerIteration(t *testing.T) {
+	// In a range tree BTN's root info set is reached once per BB combo every
+	// iteration; the change still spans whole iterations
+	gs, range0, range1, config := riverValueSpot(t)
+	root, err := tree.NewBuilder(config).BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange() error = %v", err)
+	}
+	var infoSet string
+	for _, child := range root.Children {
+		infoSet = child.InfoSet
+		break
+	}
+
+	cfr := NewCFR()
+	for i := 0; i < 9; i++ {
+		cfr.Iterate(root)
+	}
+	before, _ := cfr.GetProfile().Get(infoSet)
+	want := before.GetAverageStrategy()
+
+	cfr.Iterate(root)
+	d, ok := cfr.GetProfile().InfoSetDiagnostics(infoSet)
+	if !ok {
+		t.Fatalf("no diagnostics for %s", infoSet)
+	}
+	if d.Updates != 10 {
+		t.Errorf("Updates = %d after 10 iterations, want 10", d.Updates)
+	}
+	for i := range want {
+		if math.Abs(d.PreviousAverage[i]-want[i]) > 1e-12 {
+			t.Errorf("PreviousAverage = %v, want the average after 9 iterations %v", d.PreviousAverage, want)
+			break
+		}
+	}
+}
+
+func TestStrategyProfile_Merge(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	// Two "machines" with different seeds vs one run of their combined length
+	merged := NewMCCFR(1).Train(root, 2500)
+	if err := merged.Merge(NewMCCFR(2).Train(root, 2500)); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	single := NewMCCFR(3).Train(root, 5000)
+
+	if merged.NumInfoSets() != single.NumInfoSets() {
+		t.Fatalf("merged profile has %d info sets, single run %d", merged.NumInfoSets(), single.NumInfoSets())
+	}
+	single.ForEachSorted(func(infoSet string, want *Strategy) {
+		got, ok := merged.Get(infoSet)
+		if !ok {
+			t.Errorf("merged profile missing %s", infoSet)
+			return
+		}
+		gotAvg, wantAvg := got.GetAverageStrategy(), want.GetAverageStrategy()
+		for i := range wantAvg {
+			if math.Abs(gotAvg[i]-wantAvg[i]) > 0.1 {
+				t.Errorf("%s %s: merged %.3f, single run %.3f", infoSet, want.Actions[i], gotAvg[i], wantAvg[i])
+			}
+		}
+	})
+
+	// Info sets only in other are copied, not shared
+	empty := NewStrategyProfile()
+	src := NewMCCFR(1).Train(root, 10)
+	if err := empty.Merge(src); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	s, _ := empty.Get("J|")
+	orig, _ := src.Get("J|")
+	s.StrategySum[0] += 100
+	if orig.StrategySum[0] == s.StrategySum[0] {
+		t.Error("merged strategy shares StrategySum with its source")
+	}
+
+	// Mismatched action counts are an error, and nothing is merged
+	bad := NewStrategyProfile()
+	bad.GetOrCreate("J|", []notation.Action{{Type: notation.Check}})
+	if err := bad.Merge(src); err == nil {
+		t.Error("expected error merging mismatched action counts")
+	}
+	if bad.NumInfoSets() != 1 {
+		t.Errorf("failed merge left %d info sets, want 1", bad.NumInfoSets())
+	}
+}
+
+func TestCFR_ResolveSubtree(t *testing.T) {
+	// BTN's aces value-bet the river; BB's top pair is left to fold or call
+	gs, err := notation.ParsePosition("BTN:AdAc:S100/BB:KdQd:S100|P20|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	config := tree.ActionConfig{BetSizes: []float64{0.75}, AllowCheck: true, AllowCall: true, AllowFold: true}
+	root, err := tree.NewBuilder(config).Build(gs, gs.Players[0].Range[0], gs.Players[1].Range[0])
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	full := NewCFR().Train(root, 500)
+
+	infoSet := root.Children["b15.0"].InfoSet
+	sub, ok := root.FindByInfoSet(infoSet)
+	if !ok {
+		t.Fatalf("FindByInfoSet(%q) found nothing", infoSet)
+	}
+	resolved := NewCFR().Train(sub, 500)
+
+	// Only the subtree's info sets are solved
+	if resolved.NumInfoSets() != 1 {
+		t.Errorf("re-solved subtree has %d info sets, want 1", resolved.NumInfoSets())
+	}
+
+	fullStrat, _ := full.Get(infoSet)
+	subStrat, ok := resolved.Get(infoSet)
+	if !ok {
+		t.Fatalf("re-solve has no strategy for %q", infoSet)
+	}
+	fullAvg, subAvg := fullStrat.GetAverageStrategy(), subStrat.GetAverageStrategy()
+	t.Logf("BB facing bet: full solve %v, subtree %v (actions %v)", fullAvg, subAvg, subStrat.Actions)
+	for i := range fullAvg {
+		if math.Abs(fullAvg[i]-subAvg[i]) > 0.05 {
+			t.Errorf("%s: full solve %.3f, subtree %.3f", subStrat.Actions[i], fullAvg[i], subAvg[i])
+		}
+	}
+}
+
 // BuildKuhnPokerTree builds a Kuhn poker game tree for testing
 // Simplified version with cards as info sets (exported for use in multiple test files)
+func TestKuhnTree_Stats(t *testing.T) {
+	// Decisions: J|, Q|x, J|xb1.0, Q|b1.0. Terminals: xx, xbf, xbc, bf, bc.
+	want := tree.TreeStats{
+		DecisionNodes: 4,
+		TerminalNodes: 5,
+		InfoSets:      4,
+		MaxDepth:      3,
+	}
+	if got := BuildKuhnPokerTree().Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
 func BuildKuhnPokerTree() *tree.TreeNode {
 	// Kuhn poker:
 	// - 3 cards: J, Q, K
@@ -557,3 +813,171 @@ func printTree(node *tree.TreeNode, depth int) {
 		printTree(child, depth+1)
 	}
 }
+
+// TestDCFR_KuhnConvergence compares DCFR against vanilla CFR at equal iterations
+func TestDCFR_KuhnConvergence(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	for _, iterations := range []int{10, 50, 200} {
+		cfrProfile := NewCFR().Train(root, iterations)
+		dcfrProfile := NewDCFR(DefaultDCFRAlpha, DefaultDCFRBeta, DefaultDCFRGamma).Train(root, iterations)
+
+		cfrExploit := CalculateExploitability(cfrProfile, root)
+		dcfrExploit := CalculateExploitability(dcfrProfile, root)
+
+		t.Logf("%d iterations: CFR exploitability=%.6f, DCFR exploitability=%.6f", iterations, cfrExploit, dcfrExploit)
+
+		if dcfrExploit > cfrExploit+1e-9 {
+			t.Errorf("%d iterations: expected DCFR (%.6f) to be no more exploitable than CFR (%.6f)",
+				iterations, dcfrExploit, cfrExploit)
+		}
+	}
+}
+
+// TestLinearCFR_KuhnConvergence compares linear averaging against uniform averaging
+func TestLinearCFR_KuhnConvergence(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	const iterations = 2000
+
+	uniformProfile := NewCFR().Train(root, iterations)
+
+	linear := NewCFR()
+	linear.SetLinearAveraging(true)
+	linearProfile := linear.Train(root, iterations)
+
+	uniformExploit := CalculateExploitability(uniformProfile, root)
+	linearExploit := CalculateExploitability(linearProfile, root)
+	t.Logf("%d iterations: uniform averaging=%.6f, linear averaging=%.6f", iterations, uniformExploit, linearExploit)
+
+	if linearExploit > uniformExploit+1e-9 {
+		t.Errorf("expected linear CFR (%.6f) to be no more exploitable than uniform averaging (%.6f)",
+			linearExploit, uniformExploit)
+	}
+
+	// Linear averaging still yields valid distributions
+	for infoSet, strategy := range linearProfile.All() {
+		sum := 0.0
+		for _, p := range strategy.GetAverageStrategy() {
+			sum += p
+		}
+		if math.Abs(sum-1.0) > 1e-9 {
+			t.Errorf("%s: average strategy sums to %.6f", infoSet, sum)
+		}
+	}
+}
+
+// TestDCFR_StrategiesValid checks DCFR produces valid average strategies
+func TestDCFR_StrategiesValid(t *testing.T) {
+	root := buildSimpleTestTree()
+
+	var solver Solver = NewDCFR(DefaultDCFRAlpha, DefaultDCFRBeta, DefaultDCFRGamma)
+	profile := solver.Train(root, 100)
+
+	if profile.NumInfoSets() == 0 {
+		t.Fatal("Expected strategies after training")
+	}
+
+	for infoSet, strategy := range profile.All() {
+		sum := 0.0
+		for _, p := range strategy.GetAverageStrategy() {
+			if p < 0 || p > 1 {
+				t.Errorf("Invalid probability %.4f in %s", p, infoSet)
+			}
+			sum += p
+		}
+		if math.Abs(sum-1.0) > 1e-9 {
+			t.Errorf("Strategy for %s sums to %.6f", infoSet, sum)
+		}
+	}
+}
+
+func TestCFR_TrainUntil_StopsEarly(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	// The simplified Kuhn tree's exploitability converges toward 2.0 (gross payoffs),
+	// so 2.01 is reached after a few dozen iterations
+	const target = 2.01
+	const maxIters = 10000
+
+	cfr := NewCFR()
+	profile, iterations := cfr.TrainUntil(root, maxIters, target, 10)
+
+	if iterations >= maxIters/10 {
+		t.Errorf("TrainUntil ran %d iterations, expected to stop well before %d", iterations, maxIters)
+	}
+	if iterations%10 != 0 {
+		t.Errorf("TrainUntil stopped at %d, expected a multiple of checkEvery", iterations)
+	}
+	if exploit := CalculateExploitability(profile, root); exploit > target {
+		t.Errorf("exploitability %.4f above target %.4f", exploit, target)
+	}
+
+	// An unreachable target runs all iterations
+	_, iterations = NewCFR().TrainUntil(root, 50, 0, 10)
+	if iterations != 50 {
+		t.Errorf("TrainUntil with unreachable target ran %d iterations, want 50", iterations)
+	}
+}
+
+// TestCFR_ActionEV checks recorded action values at a spot where betting dominates checking
+func TestCFR_ActionEV(t *testing.T) {
+	// P0 checks (pot split, 0) or bets; P1 calls (+2) or folds (+1) - betting always wins more
+	stacks := [2]float64{100, 100}
+	root := tree.NewDecisionNode("p0", 0, 10.0,
+		[]notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 5.0}}, nil, stacks)
+	root.Children["x"] = tree.NewTerminalNode(10.0, [2]float64{0, 0}, nil, stacks)
+
+	facingBet := tree.NewDecisionNode("p1_facing_bet", 1, 15.0,
+		[]notation.Action{{Type: notation.Call}, {Type: notation.Fold}}, nil, stacks)
+	facingBet.Children["c"] = tree.NewTerminalNode(20.0, [2]float64{2, -2}, nil, stacks)
+	facingBet.Children["f"] = tree.NewTerminalNode(15.0, [2]float64{1, -1}, nil, stacks)
+	root.Children["b5.0"] = facingBet
+
+	profile := NewCFR().Train(root, 200)
+
+	strategy, ok := profile.Get("p0")
+	if !ok {
+		t.Fatal("expected strategy for p0")
+	}
+	if len(strategy.ActionEV) != 2 {
+		t.Fatalf("ActionEV = %v, want one value per action", strategy.ActionEV)
+	}
+	checkEV, betEV := strategy.ActionEV[0], strategy.ActionEV[1]
+	if betEV <= checkEV {
+		t.Errorf("bet EV %.3f should exceed check EV %.3f", betEV, checkEV)
+	}
+	if !strings.Contains(strategy.String(), "EV: ") {
+		t.Errorf("String() should include action EVs:\n%s", strategy.String())
+	}
+}
+
+// TestCFR_ActionEV_RangeTree checks that a hand's action values average over the
+// opponent combos it meets, weighted by their reach, rather than keeping one visit
+func TestCFR_ActionEV_RangeTree(t *testing.T) {
+	// Checked down, QQ loses 10 to AA and wins 10 from JJ; AA is played half as often
+	gs, err := notation.ParsePosition("BTN:QQ:S100/BB:AA@0.5,JJ:S100|P20|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	config := tree.ActionConfig{AllowCheck: true}
+	root, err := tree.NewBuilder(config).BuildWeightedRange(gs,
+		gs.Players[0].Range, gs.Players[0].Weights, gs.Players[1].Range, gs.Players[1].Weights)
+	if err != nil {
+		t.Fatalf("BuildWeightedRange() error = %v", err)
+	}
+
+	want := (0.5*6*-10 + 6*10) / (0.5*6 + 6)
+	for _, workers := range []int{1, 4} {
+		cfr := NewCFR()
+		cfr.SetWorkers(workers)
+		profile := cfr.Train(root, 3)
+
+		strategy, ok := profile.Get("Kh9s4c7d2s||>BTN|QsQh")
+		if !ok {
+			t.Fatal("expected strategy for BTN's QsQh")
+		}
+		if got := strategy.ActionEV[0]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("workers=%d: check EV = %.4f, want %.4f", workers, got, want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package solver
+
+import (
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/equity"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// EvaluateStrategy returns each player's expected payoff when both play the profile's
+// average strategies as fixed probabilities (no training or best response)
+// Info sets missing from the profile are played uniformly. Rollout terminals
+// (flop/turn showdowns) are valued exactly by enumerating the remaining board
+// cards; preflop showdowns use sampled runouts (see expectedRolloutPayoff).
+func EvaluateStrategy(root *tree.TreeNode, profile *StrategyProfile) [2]float64 {
+	return evaluateNode(root, profile, [2]FixedPolicy{})
+}
+
+// EvaluateAgainstPolicy is EvaluateStrategy with one player's decisions made by a
+// fixed policy instead of the profile, e.g. to see what a hero strategy wins
+// against an opponent who calls every bet (CallAnyBet)
+func EvaluateAgainstPolicy(root *tree.TreeNode, profile *StrategyProfile, opponent int, policy FixedPolicy) [2]float64 {
+	var policies [2]FixedPolicy
+	policies[opponent] = policy
+	return evaluateNode(root, profile, policies)
+}
+
+// FixedPolicy picks action probabilities from the legal actions alone
+// Used in place of a profile to model simple opponents.
+type FixedPolicy interface {
+	Probabilities(actions []notation.Action) []float64
+}
+
+// FoldToAnyBet folds whenever it faces a bet or raise and checks otherwise
+type FoldToAnyBet struct{}
+
+// Probabilities puts all weight on fold, else check, else plays uniformly
+func (FoldToAnyBet) Probabilities(actions []notation.Action) []float64 {
+	return pureAction(actions, notation.Fold, notation.Check)
+}
+
+// CallAnyBet calls whenever it faces a bet or raise and checks otherwise
+type CallAnyBet struct{}
+
+// Probabilities puts all weight on call, else check, else plays uniformly
+func (CallAnyBet) Probabilities(actions []notation.Action) []float64 {
+	return pureAction(actions, notation.Call, notation.Check)
+}
+
+// pureAction plays the first available action type from prefs with probability 1
+// If none is legal every action is equally likely.
+func pureAction(actions []notation.Action, prefs ...notation.ActionType) []float64 {
+	probs := make([]float64, len(actions))
+	for _, pref := range prefs {
+		for i, action := range actions {
+			if action.Type == pref {
+				probs[i] = 1
+				return probs
+			}
+		}
+	}
+	for i := range probs {
+		probs[i] = 1.0 / float64(len(probs))
+	}
+	return probs
+}
+
+// evaluateNode computes the expected payoffs below node under the fixed profile
+// A non-nil policies[p] decides for player p instead of the profile.
+func evaluateNode(node *tree.TreeNode, profile *StrategyProfile, policies [2]FixedPolicy) [2]float64 {
+	if node.IsTerminal {
+		if node.NeedsRollout {
+			return expectedRolloutPayoff(node)
+		}
+		return node.Payoff
+	}
+
+	var value [2]float64
+
+	if node.IsChance {
+		for key, child := range node.Children {
+			prob := node.ChanceProbabilities[key]
+			childValue := evaluateNode(child, profile, policies)
+			value[0] += prob * childValue[0]
+			value[1] += prob * childValue[1]
+		}
+		return value
+	}
+
+	var probs []float64
+	if policy := policies[node.Player]; policy != nil {
+		probs = policy.Probabilities(node.Actions)
+	} else {
+		probs = averageProbabilities(profile, node)
+	}
+
+	for i, action := range node.Actions {
+		child, exists := node.Children[tree.ActionKey(action)]
+		if !exists || probs[i] == 0 {
+			continue
+		}
+		childValue := evaluateNode(child, profile, policies)
+		value[0] += probs[i] * childValue[0]
+		value[1] += probs[i] * childValue[1]
+	}
+
+	return value
+}
+
+// averageProbabilities returns the profile's average strategy at a decision node
+// Info sets missing from the profile (or with other actions) are played uniformly.
+func averageProbabilities(profile *StrategyProfile, node *tree.TreeNode) []float64 {
+	if strategy, ok := profile.Get(node.InfoSet); ok && len(strategy.Actions) == len(node.Actions) {
+		return strategy.GetAverageStrategy()
+	}
+	probs := make([]float64, len(node.Actions))
+	for i := range probs {
+		probs[i] = 1.0 / float64(len(probs))
+	}
+	return probs
+}
+
+// ReachProbability returns how likely play under the profile's average strategies
+// is to reach infoSet: the chance and action probabilities along the path from
+// root to each node in the info set, summed over those nodes. In a range tree
+// the deal of the info set's hand is part of the path, so the result is the
+// share of all deals that arrive there; divide by the deal's probability for
+// the fraction of that hand's play that does. Info sets missing from the
+// profile are played uniformly.
+func (sp *StrategyProfile) ReachProbability(root *tree.TreeNode, infoSet string) float64 {
+	var reach func(node *tree.TreeNode, prob float64) float64
+	reach = func(node *tree.TreeNode, prob float64) float64 {
+		if node.IsTerminal || prob == 0 {
+			return 0
+		}
+		if node.IsChance {
+			total := 0.0
+			for key, child := range node.Children {
+				total += reach(child, prob*node.ChanceProbabilities[key])
+			}
+			return total
+		}
+		if node.InfoSet == infoSet {
+			// Perfect recall: the info set can't recur below itself
+			return prob
+		}
+
+		total := 0.0
+		probs := averageProbabilities(sp, node)
+		for i, action := range node.Actions {
+			if child, exists := node.Children[tree.ActionKey(action)]; exists {
+				total += reach(child, prob*probs[i])
+			}
+		}
+		return total
+	}
+	return reach(root, 1)
+}
+
+// nodeEvaluator returns the evaluator a rollout node's showdowns use
+func nodeEvaluator(node *tree.TreeNode) cards.Evaluator {
+	if node.Evaluator == nil {
+		return cards.DefaultEvaluator
+	}
+	return node.Evaluator
+}
+
+// expectedRolloutPayoff averages showdown payoffs over every possible runout
+// Boards of fewer than 3 cards have too many runouts to enumerate, so the
+// equity calculator's sampled win/tie counts weight the showdown payoffs instead.
+func expectedRolloutPayoff(node *tree.TreeNode) [2]float64 {
+	combo0, combo1 := node.PlayerCombos[0], node.PlayerCombos[1]
+	evaluator := nodeEvaluator(node)
+
+	if len(node.Board) < 3 {
+		calc := equity.NewCalculator()
+		calc.SetEvaluator(evaluator)
+		result := calc.CalculateEquityDetailed(combo0.Cards(), node.Board, []notation.Combo{combo1})
+		if result.Total == 0 {
+			return node.Payoff
+		}
+
+		win := tree.DeadShowdownPayoffs(1, node.Pot, node.Dead, node.Rake)
+		tie := tree.DeadShowdownPayoffs(0, node.Pot, node.Dead, node.Rake)
+		lose := tree.DeadShowdownPayoffs(-1, node.Pot, node.Dead, node.Rake)
+		pWin := float64(result.Wins) / float64(result.Total)
+		pTie := float64(result.Ties) / float64(result.Total)
+		pLose := 1 - pWin - pTie
+
+		var payoff [2]float64
+		for p := range payoff {
+			payoff[p] = pWin*win[p] + pTie*tie[p] + pLose*lose[p]
+		}
+		return payoff
+	}
+
+	deck := cards.NewDeckFor(evaluator)
+	deck.Remove(node.Board...)
+	deck.Remove(combo0.Cards()...)
+	deck.Remove(combo1.Cards()...)
+	remaining := deck.Cards()
+
+	needed := 5 - len(node.Board)
+	if needed <= 0 || len(remaining) < needed {
+		return node.Payoff
+	}
+
+	var total [2]float64
+	count := 0
+
+	var enumerate func(start int, board []cards.Card)
+	enumerate = func(start int, board []cards.Card) {
+		if len(board) == 5 {
+			hand0 := evaluator.Evaluate(combo0.Cards(), board)
+			hand1 := evaluator.Evaluate(combo1.Cards(), board)
+
+			payoff := tree.DeadShowdownPayoffs(hand0.Compare(hand1), node.Pot, node.Dead, node.Rake)
+			total[0] += payoff[0]
+			total[1] += payoff[1]
+			count++
+			return
+		}
+		for i := start; i < len(remaining); i++ {
+			enumerate(i+1, append(board, remaining[i]))
+		}
+	}
+	enumerate(0, append([]cards.Card{}, node.Board...))
+
+	return [2]float64{total[0] / float64(count), total[1] / float64(count)}
+}
@@ -117,8 +117,36 @@ func CalculateExploitability(profile *StrategyProfile, root *tree.TreeNode) floa
 	// If P0 best-responds against P1, P0 gains p0BestEV
 	// If P1 best-responds against P0, P1 gains p1BestEV
 
-	// Exploitability is the average of these gains
-	exploitability := (p0BestEV + p1BestEV) / 2.0
+	// Exploitability is the average of these gains over what the profile itself
+	// earns. Dead money and rake stop the game being zero-sum: each terminal's
+	// payoffs add up to its dead money less its rake, so the pair's expected
+	// total under the profile is subtracted rather than assumed to be 0.
+	exploitability := (p0BestEV + p1BestEV - payoffSum(root, profile)) / 2.0
 
 	return exploitability
 }
+
+// payoffSum returns the expected total of both players' terminal payoffs when
+// both play the profile's average strategies
+// Terminals are valued by their Payoff, as in the best responses.
+func payoffSum(node *tree.TreeNode, profile *StrategyProfile) float64 {
+	if node.IsTerminal {
+		return node.Payoff[0] + node.Payoff[1]
+	}
+
+	total := 0.0
+	if node.IsChance {
+		for key, child := range node.Children {
+			total += node.ChanceProbabilities[key] * payoffSum(child, profile)
+		}
+		return total
+	}
+
+	probs := averageProbabilities(profile, node)
+	for i, action := range node.Actions {
+		if child, exists := node.Children[tree.ActionKey(action)]; exists && probs[i] > 0 {
+			total += probs[i] * payoffSum(child, profile)
+		}
+	}
+	return total
+}
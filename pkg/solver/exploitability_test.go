@@ -201,6 +201,36 @@ func TestCalculateExploitability_RiverSpot(t *testing.T) {
 	}
 }
 
+func TestCalculateExploitability_RakeAndDeadMoney(t *testing.T) {
+	// Rake comes out of every showdown and dead money goes to every winner, so
+	// the players' payoffs no longer sum to zero. One combo pair keeps the
+	// best responses comparable to the profile's own play.
+	gs, range0, range1, config := riverValueSpot(t)
+	gs.Pot += 2
+	gs.DeadMoney = 2
+	config.RakePct = 0.05
+	config.RakeCap = 3
+
+	root, err := tree.NewBuilder(config).Build(gs, range0[0], range1[0])
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	untrained := CalculateExploitability(NewStrategyProfile(), root)
+	trained := CalculateExploitability(NewCFR().Train(root, 1000), root)
+	t.Logf("Exploitability with rake and dead money: untrained %.4f, 1k iterations %.4f", untrained, trained)
+
+	if trained < -1e-9 || untrained < -1e-9 {
+		t.Errorf("exploitability should not be negative: untrained %.4f, trained %.4f", untrained, trained)
+	}
+	if trained >= untrained {
+		t.Errorf("training should reduce exploitability: %.4f vs %.4f untrained", trained, untrained)
+	}
+	if trained > 0.1 {
+		t.Errorf("exploitability after 1k iterations = %.4f, want near 0", trained)
+	}
+}
+
 func TestCalculateExploitability_DecreasingWithIterations(t *testing.T) {
 	// Verify that exploitability decreases as we run more CFR iterations
 	root := BuildKuhnPokerTree()
@@ -223,3 +253,185 @@ func TestCalculateExploitability_DecreasingWithIterations(t *testing.T) {
 			exploitabilities[0], exploitabilities[2])
 	}
 }
+
+func TestEvaluateStrategy_FixedProfile(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	profile := NewStrategyProfile()
+
+	// P0 (J) bets 25%; P1 (Q) calls 50% facing a bet and always checks behind
+	profile.GetOrCreate("J|", root.Actions).StrategySum = []float64{3, 1}
+	profile.GetOrCreate("Q|b1.0", root.Children["b1.0"].Actions).StrategySum = []float64{1, 1}
+	profile.GetOrCreate("Q|x", root.Children["x"].Actions).StrategySum = []float64{1, 0}
+
+	ev := EvaluateStrategy(root, profile)
+
+	// Check (0.75): 0/2. Bet (0.25): fold 2/0, call 0/4 -> 1/2
+	want := [2]float64{0.25 * 1, 0.75*2 + 0.25*2}
+	if math.Abs(ev[0]-want[0]) > 1e-9 || math.Abs(ev[1]-want[1]) > 1e-9 {
+		t.Errorf("EvaluateStrategy = %v, want %v", ev, want)
+	}
+
+	// Missing info sets are played uniformly: J|xb1.0 is absent, so P0 folds/calls 50/50
+	profile.GetOrCreate("Q|x", root.Children["x"].Actions).StrategySum = []float64{0, 1}
+	ev = EvaluateStrategy(root, profile)
+	want = [2]float64{0.25 * 1, 0.75*(0.5*2+0.5*4) + 0.25*2}
+	if math.Abs(ev[0]-want[0]) > 1e-9 || math.Abs(ev[1]-want[1]) > 1e-9 {
+		t.Errorf("EvaluateStrategy with uniform fallback = %v, want %v", ev, want)
+	}
+}
+
+func TestEvaluateAgainstPolicy(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AdAc:S100/BB:QdQh:S100|P20|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	config := tree.ActionConfig{BetSizes: []float64{0.75}, AllowCheck: true, AllowCall: true, AllowFold: true}
+	root, err := tree.NewBuilder(config).Build(gs, gs.Players[0].Range[0], gs.Players[1].Range[0])
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// BTN always value-bets 15 into 20
+	profile := NewStrategyProfile()
+	sums := make([]float64, len(root.Actions))
+	for i, action := range root.Actions {
+		if action.Type == notation.Bet && action.Amount == 15 {
+			sums[i] = 1
+		}
+	}
+	profile.GetOrCreate(root.InfoSet, root.Actions).StrategySum = sums
+
+	// Called: the aces win the full 50bb pot, half of it from BB
+	ev := EvaluateAgainstPolicy(root, profile, 1, CallAnyBet{})
+	if want := root.Children["b15.0"].Children["c"].Payoff; ev != want || ev[0] != 25 {
+		t.Errorf("EV vs call-any-bet = %v, want showdown payoff %v", ev, want)
+	}
+
+	// Folded to: only the 20bb pot is won
+	ev = EvaluateAgainstPolicy(root, profile, 1, FoldToAnyBet{})
+	if ev[0] != 10 || ev[1] != -10 {
+		t.Errorf("EV vs fold-to-any-bet = %v, want [10 -10]", ev)
+	}
+
+	// BTN can be the fixed player too: checking and folding the aces to BB's
+	// uniform check / bet 15 / all-in wins 10 once and loses 10 twice
+	ev = EvaluateAgainstPolicy(root, profile, 0, FoldToAnyBet{})
+	if want := -10.0 / 3; math.Abs(ev[0]-want) > 1e-9 {
+		t.Errorf("BTN fold-to-any-bet EV = %v, want %.4f", ev[0], want)
+	}
+}
+
+func TestEvaluateStrategy_KuhnGameValue(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	profile := NewCFR().Train(root, 2000)
+
+	// Q always calls a bet (4 > 0), so every line gives J nothing: the game value for P0 is 0
+	ev := EvaluateStrategy(root, profile)
+	if math.Abs(ev[0]) > 0.01 {
+		t.Errorf("P0 EV = %.4f, want ~0 (analytic value of J vs Q)", ev[0])
+	}
+	// P1 collects the antes at least, and never more than the full called pot
+	if ev[1] < 2-0.01 || ev[1] > 4+0.01 {
+		t.Errorf("P1 EV = %.4f, want within [2, 4]", ev[1])
+	}
+}
+
+func TestReachProbability_Kuhn(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	profile := NewCFR().Train(root, 2000)
+
+	opening, _ := profile.Get("J|")
+	open := opening.GetAverageStrategy() // [check, bet]
+	facingCheck, _ := profile.Get("Q|x")
+	afterCheck := facingCheck.GetAverageStrategy() // [check, bet]
+
+	// Q faces a bet exactly as often as J bets
+	if got := profile.ReachProbability(root, "Q|b1.0"); math.Abs(got-open[1]) > 1e-12 {
+		t.Errorf("reach of Q|b1.0 = %v, want J's bet frequency %v", got, open[1])
+	}
+	if got, want := profile.ReachProbability(root, "J|xb1.0"), open[0]*afterCheck[1]; math.Abs(got-want) > 1e-12 {
+		t.Errorf("reach of J|xb1.0 = %v, want %v", got, want)
+	}
+	if got := profile.ReachProbability(root, "J|"); got != 1 {
+		t.Errorf("reach of the root = %v, want 1", got)
+	}
+	if got := profile.ReachProbability(root, "K|"); got != 0 {
+		t.Errorf("reach of a missing info set = %v, want 0", got)
+	}
+}
+
+func TestEvaluateStrategy_Rollout(t *testing.T) {
+	// A turn rollout terminal is valued over all 44 rivers
+	board, _ := cards.ParseCards("Qh7d2c3s")
+	hero, _ := cards.ParseCards("AsAc")
+	villain, _ := cards.ParseCards("KsKc")
+	combos := [2]notation.Combo{
+		{Card1: hero[0], Card2: hero[1]},
+		{Card1: villain[0], Card2: villain[1]},
+	}
+	node := tree.NewRolloutNode(10.0, board, [2]float64{100, 100}, combos)
+
+	ev := EvaluateStrategy(node, NewStrategyProfile())
+
+	// Only the two remaining kings win for KK: P1 nets +5 on 2/44 rivers, -5 otherwise
+	wantP1 := 5.0*2/44 - 5.0*42/44
+	if math.Abs(ev[1]-wantP1) > 1e-9 || math.Abs(ev[0]+ev[1]) > 1e-9 {
+		t.Errorf("rollout EV = %v, want P1 %.4f and zero-sum", ev, wantP1)
+	}
+}
+
+// lowCardEvaluator is a stub where the lowest first hole card wins
+type lowCardEvaluator struct{}
+
+func (lowCardEvaluator) Evaluate(hole []cards.Card, board []cards.Card) cards.HandValue {
+	return cards.HandValue{Rank: cards.HighCard, Values: [5]cards.Rank{cards.Ace - hole[0].Rank}}
+}
+
+func TestRollout_NodeEvaluator(t *testing.T) {
+	// Under the stub KK beats AA on every river
+	board, _ := cards.ParseCards("Qh7d2c3s")
+	hero, _ := cards.ParseCards("AsAc")
+	villain, _ := cards.ParseCards("KsKc")
+	node := tree.NewRolloutNode(10.0, board, [2]float64{100, 100}, [2]notation.Combo{
+		{Card1: hero[0], Card2: hero[1]},
+		{Card1: villain[0], Card2: villain[1]},
+	})
+	node.Evaluator = lowCardEvaluator{}
+
+	want := [2]float64{-5, 5}
+	if got := expectedRolloutPayoff(node); got != want {
+		t.Errorf("expectedRolloutPayoff = %v, want %v", got, want)
+	}
+	m := NewMCCFR(1)
+	for i := 0; i < 10; i++ {
+		if got := m.rollout(node); got != want {
+			t.Fatalf("MCCFR rollout = %v, want %v", got, want)
+		}
+	}
+
+	// Preflop rollouts sample equity through the same evaluator
+	node.Board = nil
+	if got := expectedRolloutPayoff(node); got != want {
+		t.Errorf("preflop expectedRolloutPayoff = %v, want %v", got, want)
+	}
+}
+
+// TestExpectedRolloutPayoff_Preflop checks that a preflop all-in is valued by
+// sampled equity rather than enumerating every runout
+func TestExpectedRolloutPayoff_Preflop(t *testing.T) {
+	aa, _ := cards.ParseCards("AsAh")
+	kk, _ := cards.ParseCards("KdKc")
+	node := tree.NewRolloutNode(200, nil, [2]float64{0, 0}, [2]notation.Combo{
+		{Card1: aa[0], Card2: aa[1]},
+		{Card1: kk[0], Card2: kk[1]},
+	})
+
+	// AA vs KK is about 82/18: AA wins about 0.64 × 100
+	payoff := expectedRolloutPayoff(node)
+	if payoff[0] < 58 || payoff[0] > 70 {
+		t.Errorf("AA expected payoff = %.1f, want about 64", payoff[0])
+	}
+	if math.Abs(payoff[0]+payoff[1]) > 1e-9 {
+		t.Errorf("payoffs %v should be zero-sum without rake", payoff)
+	}
+}
@@ -2,6 +2,8 @@ package solver
 
 import (
 	"math/rand"
+	"sort"
+	"time"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
 	"github.com/behrlich/poker-solver/pkg/tree"
@@ -12,38 +14,139 @@ import (
 type MCCFR struct {
 	profile *StrategyProfile
 	rng     *rand.Rand
+
+	// external selects external sampling instead of outcome sampling
+	external bool
+
+	// weightedChance samples outcome-sampling chance nodes in proportion to
+	// their probabilities instead of uniformly with an importance correction
+	weightedChance bool
+
+	// Iterations run by Train, and the coverage measured after the last Train
+	iterations int
+	coverage   Coverage
+
+	// seed is the base seed that parallel workers derive their own from
+	seed int64
+
+	// workers are the parallel traversers (nil = serial; see SetWorkers)
+	workers []*MCCFR
+
+	// base is the shared profile a worker reads strategies from; a worker's own
+	// profile only collects its updates for the current batch (nil = not a worker)
+	base *StrategyProfile
+
+	// iteration is the profile iteration being run (see StrategyProfile.nextIteration)
+	iteration int
 }
 
 // NewMCCFR creates a new MCCFR solver with the given random seed
 func NewMCCFR(seed int64) *MCCFR {
 	return &MCCFR{
-		profile: NewStrategyProfile(),
-		rng:     rand.New(rand.NewSource(seed)),
+		profile:        NewStrategyProfile(),
+		rng:            rand.New(rand.NewSource(seed)),
+		weightedChance: true,
+		seed:           seed,
 	}
 }
 
+// NewMCCFRFromProfile creates an MCCFR solver that resumes training from an existing profile
+// The profile is updated in place; a nil profile starts fresh.
+func NewMCCFRFromProfile(profile *StrategyProfile, seed int64) *MCCFR {
+	m := NewMCCFR(seed)
+	if profile != nil {
+		m.profile = profile
+	}
+	return m
+}
+
+// NewMCCFRExternal creates a new MCCFR solver that uses external sampling
+// Each iteration traverses once per player: all actions of the traversing player
+// are explored, while opponent actions and chance outcomes are sampled.
+// This has much lower variance than outcome sampling at the cost of more work per iteration
+func NewMCCFRExternal(seed int64) *MCCFR {
+	m := NewMCCFR(seed)
+	m.external = true
+	return m
+}
+
+// maxMCCFRIterations is a hard limit on MCCFR training runs to prevent memory explosion
+const maxMCCFRIterations = 100000
+
+// SetWeightedChanceSampling chooses how outcome sampling draws chance outcomes.
+// Enabled (the default), outcomes are drawn in proportion to ChanceProbabilities, so
+// the sampling and true probabilities cancel and no importance correction is needed.
+// Disabled, outcomes are drawn uniformly and values are scaled by prob×outcomes.
+// Both are unbiased, including at range roots where many combo pairs chop, but the
+// correction factors inflate variance whenever probabilities are uneven (weighted
+// ranges, blocker-skewed pairs). External sampling always samples by probability.
+func (m *MCCFR) SetWeightedChanceSampling(enabled bool) {
+	m.weightedChance = enabled
+}
+
 // Train runs MCCFR for the specified number of iterations
 // Returns the strategy profile after training
+// Afterwards Coverage reports iterations per info set; a run below
+// MinIterationsPerInfoSet is reported through TrainOptions.Logf.
 // SAFETY: Maximum 100,000 iterations to prevent memory explosion
-func (m *MCCFR) Train(root *tree.TreeNode, iterations int) *StrategyProfile {
+// Optional TrainOptions add cancellation and progress reporting.
+func (m *MCCFR) Train(root *tree.TreeNode, iterations int, opts ...TrainOptions) *StrategyProfile {
 	// SAFETY: Hard limit on iterations to prevent crashes
-	const maxIterations = 100000
-	if iterations > maxIterations {
-		iterations = maxIterations
+	if iterations > maxMCCFRIterations {
+		iterations = maxMCCFRIterations
 	}
 	if iterations < 0 {
 		iterations = 0
 	}
 
-	for i := 0; i < iterations; i++ {
-		m.Iterate(root)
+	m.iterations += runTraining(m, root, iterations, opts)
+
+	// Sampling touches few info sets per iteration; flag runs that are far too short
+	m.coverage = MeasureCoverage(root, m.profile, m.iterations)
+	if m.coverage.Undertrained() && len(opts) > 0 && opts[0].Logf != nil {
+		opts[0].Logf("warning: MCCFR is undertrained: %s; want at least %d iterations per info set",
+			m.coverage, MinIterationsPerInfoSet)
 	}
 	return m.profile
 }
 
+// Coverage returns the coverage measured at the end of the last Train call
+func (m *MCCFR) Coverage() Coverage {
+	return m.coverage
+}
+
+// TrainUntil runs MCCFR until exploitability is at most targetExploitability,
+// checking every checkEvery iterations, or until maxIters is reached
+// Returns the strategy profile and the number of iterations run
+// SAFETY: maxIters is capped at 100,000 like Train
+func (m *MCCFR) TrainUntil(root *tree.TreeNode, maxIters int, targetExploitability float64, checkEvery int) (*StrategyProfile, int) {
+	if maxIters > maxMCCFRIterations {
+		maxIters = maxMCCFRIterations
+	}
+	return trainUntil(m, root, maxIters, targetExploitability, checkEvery)
+}
+
+// TrainFor runs MCCFR until the duration d has elapsed
+// Returns the strategy profile and the number of iterations completed (at least 1)
+// SAFETY: iterations are capped at 100,000 like Train
+func (m *MCCFR) TrainFor(root *tree.TreeNode, d time.Duration) (*StrategyProfile, int) {
+	return trainFor(m, root, d, maxMCCFRIterations)
+}
+
 // Iterate runs a single MCCFR iteration
 // This is useful for progress tracking in WASM/UI contexts
 func (m *MCCFR) Iterate(root *tree.TreeNode) {
+	m.iteration = m.profile.nextIteration()
+	if m.workers != nil {
+		m.iterateParallel(root)
+		return
+	}
+	if m.external {
+		for player := 0; player < 2; player++ {
+			m.externalSampling(root, player)
+		}
+		return
+	}
 	m.mccfr(root, 1.0, 1.0, 1.0)
 }
 
@@ -72,7 +175,7 @@ func (m *MCCFR) mccfr(node *tree.TreeNode, reachProb0, reachProb1, sampleProb fl
 	infoSet := node.InfoSet
 
 	// Get or create strategy for this infoset
-	strategy := m.profile.GetOrCreate(infoSet, node.Actions)
+	strategy := m.strategyFor(infoSet, node.Actions)
 
 	// Get current strategy using regret matching
 	currentStrategy := strategy.GetStrategy()
@@ -152,19 +255,123 @@ func (m *MCCFR) mccfr(node *tree.TreeNode, reachProb0, reachProb1, sampleProb fl
 	} else {
 		ownReachProb = reachProb1
 	}
+	strategy.beginIteration(m.iteration)
 	strategy.UpdateStrategy(currentStrategy, ownReachProb)
 
 	return nodeValue
 }
 
+// externalSampling traverses the tree for one player using external sampling
+// traverser is the player whose regrets are updated on this pass
+// Returns the sampled counterfactual value for the traverser
+func (m *MCCFR) externalSampling(node *tree.TreeNode, traverser int) float64 {
+	// Terminal node: return payoff (sampling future cards if needed)
+	if node.IsTerminal {
+		if node.NeedsRollout {
+			return m.rollout(node)[traverser]
+		}
+		return node.Payoff[traverser]
+	}
+
+	// Chance node: sample one outcome according to its true probability
+	if node.IsChance {
+		key, ok := m.sampleChanceOutcome(node)
+		if !ok {
+			return 0
+		}
+		return m.externalSampling(node.Children[key], traverser)
+	}
+
+	strategy := m.strategyFor(node.InfoSet, node.Actions)
+	currentStrategy := strategy.GetStrategy()
+
+	// Opponent node: sample a single action and accumulate the average strategy
+	if node.Player != traverser {
+		strategy.beginIteration(m.iteration)
+		strategy.UpdateStrategy(currentStrategy, 1.0)
+
+		actionIdx := m.sampleAction(currentStrategy)
+		child, exists := node.Children[tree.ActionKey(node.Actions[actionIdx])]
+		if !exists {
+			// Should not happen if tree is built correctly
+			return 0
+		}
+		return m.externalSampling(child, traverser)
+	}
+
+	// Traverser node: explore every action
+	numActions := len(node.Actions)
+	actionValues := make([]float64, numActions)
+	nodeValue := 0.0
+	for i, action := range node.Actions {
+		child, exists := node.Children[tree.ActionKey(action)]
+		if !exists {
+			continue
+		}
+		actionValues[i] = m.externalSampling(child, traverser)
+		nodeValue += currentStrategy[i] * actionValues[i]
+	}
+
+	// Opponent and chance were sampled according to their true probabilities,
+	// so the sampled values are already unbiased counterfactual values
+	regrets := make([]float64, numActions)
+	for i := 0; i < numActions; i++ {
+		regrets[i] = actionValues[i] - nodeValue
+	}
+	strategy.UpdateRegrets(regrets)
+	strategy.addActionEV(m.iteration, actionValues, 1)
+
+	return nodeValue
+}
+
+// sampleChanceOutcome samples a chance node child proportionally to ChanceProbabilities
+// Outcomes are visited in sorted key order so sampling is reproducible for a given seed
+// Returns false if the chance node has no children
+func (m *MCCFR) sampleChanceOutcome(node *tree.TreeNode) (string, bool) {
+	keys := sortedChildKeys(node)
+	if len(keys) == 0 {
+		return "", false
+	}
+
+	r := m.rng.Float64()
+	cumulative := 0.0
+	for _, key := range keys {
+		cumulative += node.ChanceProbabilities[key]
+		if r < cumulative {
+			return key, true
+		}
+	}
+
+	// Floating point error: fall back to the last outcome
+	return keys[len(keys)-1], true
+}
+
+// sortedChildKeys returns the child keys of a node in sorted order
+func sortedChildKeys(node *tree.TreeNode) []string {
+	keys := make([]string, 0, len(node.Children))
+	for key := range node.Children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // sampleChanceNode samples one outcome from a chance node
 func (m *MCCFR) sampleChanceNode(node *tree.TreeNode, reachProb0, reachProb1, sampleProb float64) [2]float64 {
-	// Sample one outcome uniformly (for now - could use probabilities later)
-	outcomes := make([]string, 0, len(node.Children))
-	for key := range node.Children {
-		outcomes = append(outcomes, key)
+	if m.weightedChance {
+		sampledKey, ok := m.sampleChanceOutcome(node)
+		if !ok {
+			return [2]float64{0, 0}
+		}
+		prob := node.ChanceProbabilities[sampledKey]
+
+		// Sampling probability equals the true probability, so the correction is 1
+		return m.mccfr(node.Children[sampledKey], reachProb0*prob, reachProb1*prob, sampleProb*prob)
 	}
 
+	// Sorted keys keep sampling deterministic for a given seed
+	outcomes := sortedChildKeys(node)
+
 	if len(outcomes) == 0 {
 		return [2]float64{0, 0}
 	}
@@ -189,13 +396,14 @@ func (m *MCCFR) sampleChanceNode(node *tree.TreeNode, reachProb0, reachProb1, sa
 }
 
 // rollout samples future cards and evaluates the hand
-// Handles both turn→river (4 cards) and flop→turn→river (3 cards)
+// Handles preflop (0 cards, all five dealt), flop (3), turn (4), and a complete
+// river board, which is evaluated as is
 func (m *MCCFR) rollout(node *tree.TreeNode) [2]float64 {
 	board := node.Board
 
-	// Rollout only makes sense for flop (3 cards) or turn (4 cards)
-	if len(board) != 3 && len(board) != 4 {
-		// This shouldn't happen - rollout nodes should only be created on flop/turn
+	// Any partial board is completed to five cards; a full board is just a showdown
+	if len(board) > 5 {
+		// This shouldn't happen - boards never hold more than five cards
 		return node.Payoff
 	}
 
@@ -203,83 +411,27 @@ func (m *MCCFR) rollout(node *tree.TreeNode) [2]float64 {
 	combo0 := node.PlayerCombos[0]
 	combo1 := node.PlayerCombos[1]
 
-	// Build set of used cards
-	usedCards := make(map[cards.Card]bool)
-	for _, card := range board {
-		usedCards[card] = true
-	}
-	usedCards[combo0.Card1] = true
-	usedCards[combo0.Card2] = true
-	usedCards[combo1.Card1] = true
-	usedCards[combo1.Card2] = true
-
-	// Generate all possible remaining cards
-	// SAFETY: Use explicit rank list to avoid uint8 underflow
-	possibleCards := make([]cards.Card, 0, 48)
-	ranks := []cards.Rank{cards.Ace, cards.King, cards.Queen, cards.Jack, cards.Ten,
-		cards.Nine, cards.Eight, cards.Seven, cards.Six, cards.Five,
-		cards.Four, cards.Three, cards.Two}
-	for _, rank := range ranks {
-		for suit := cards.Spades; suit <= cards.Clubs; suit++ {
-			card := cards.Card{Rank: rank, Suit: suit}
-			if !usedCards[card] {
-				possibleCards = append(possibleCards, card)
-			}
-		}
-	}
+	// Deal the remaining board cards (none on the river) from what's left in the deck
+	evaluator := nodeEvaluator(node)
+	deck := cards.NewDeckFor(evaluator)
+	deck.Remove(board...)
+	deck.Remove(combo0.Cards()...)
+	deck.Remove(combo1.Cards()...)
 
-	if len(possibleCards) < 2 {
+	needed := 5 - len(board)
+	if deck.Len() < needed {
 		// Shouldn't happen
-		return [2]float64{node.Pot / 2, node.Pot / 2}
+		return tree.DeadShowdownPayoffs(0, node.Pot, node.Dead, node.Rake)
 	}
 
-	// Build final board based on street
-	var finalBoard []cards.Card
-	if len(board) == 3 {
-		// Flop: sample turn card, then river card
-		turnCard := possibleCards[m.rng.Intn(len(possibleCards))]
-
-		// Remove turn card from available cards
-		possibleRivers := make([]cards.Card, 0, len(possibleCards)-1)
-		for _, card := range possibleCards {
-			if card != turnCard {
-				possibleRivers = append(possibleRivers, card)
-			}
-		}
-
-		riverCard := possibleRivers[m.rng.Intn(len(possibleRivers))]
-
-		// Build final board: flop + turn + river
-		finalBoard = append([]cards.Card{}, board...)
-		finalBoard = append(finalBoard, turnCard, riverCard)
-	} else {
-		// Turn: sample river card only
-		riverCard := possibleCards[m.rng.Intn(len(possibleCards))]
-
-		// Build final board: turn + river
-		finalBoard = append([]cards.Card{}, board...)
-		finalBoard = append(finalBoard, riverCard)
-	}
+	finalBoard := append([]cards.Card{}, board...)
+	finalBoard = append(finalBoard, deck.Deal(m.rng, needed)...)
 
 	// Evaluate hands with the final board (5 cards)
-	hand0 := append([]cards.Card{combo0.Card1, combo0.Card2}, finalBoard...)
-	hand1 := append([]cards.Card{combo1.Card1, combo1.Card2}, finalBoard...)
-
-	rank0 := cards.Evaluate(hand0)
-	rank1 := cards.Evaluate(hand1)
-
-	cmp := rank0.Compare(rank1)
+	rank0 := evaluator.Evaluate(combo0.Cards(), finalBoard)
+	rank1 := evaluator.Evaluate(combo1.Cards(), finalBoard)
 
-	if cmp > 0 {
-		// Player 0 wins
-		return [2]float64{node.Pot, 0}
-	} else if cmp < 0 {
-		// Player 1 wins
-		return [2]float64{0, node.Pot}
-	} else {
-		// Tie (split pot)
-		return [2]float64{node.Pot / 2, node.Pot / 2}
-	}
+	return tree.DeadShowdownPayoffs(rank0.Compare(rank1), node.Pot, node.Dead, node.Rake)
 }
 
 // sampleAction samples an action index according to the given strategy
@@ -298,8 +450,13 @@ func (m *MCCFR) sampleAction(strategy []float64) int {
 		}
 	}
 
-	// Shouldn't happen unless there's floating point error
-	return len(strategy) - 1
+	// Float error left r just past the total: take the last action that can be played
+	for i := len(strategy) - 1; i > 0; i-- {
+		if strategy[i] > 0 {
+			return i
+		}
+	}
+	return 0
 }
 
 // GetProfile returns the current strategy profile
@@ -0,0 +1,116 @@
+package solver
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// SetWorkers sets how many goroutines sample trajectories in parallel
+// With more than one worker, each iteration runs one traversal per worker
+// against the strategies as they stood when the iteration began, then adds the
+// workers' regret and strategy updates to the profile in worker order. Worker k
+// samples with its own generator seeded from the solver's seed and k, so a run
+// is reproducible bit for bit for a given seed and worker count (but differs
+// between worker counts). n <= 0 uses runtime.NumCPU(); 1 samples serially (the
+// default).
+func (m *MCCFR) SetWorkers(n int) {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	if n == 1 {
+		m.workers = nil
+		return
+	}
+
+	m.workers = make([]*MCCFR, n)
+	for k := range m.workers {
+		m.workers[k] = &MCCFR{
+			rng:            rand.New(rand.NewSource(workerSeed(m.seed, k))),
+			external:       m.external,
+			weightedChance: m.weightedChance,
+			base:           m.profile,
+		}
+	}
+}
+
+// workerSeed derives worker k's seed from the solver's seed
+func workerSeed(seed int64, k int) int64 {
+	return seed + int64(k+1)*1000003
+}
+
+// iterateParallel runs one traversal per worker and merges their updates
+func (m *MCCFR) iterateParallel(root *tree.TreeNode) {
+	var wg sync.WaitGroup
+	for _, w := range m.workers {
+		// Sampling settings may have changed since SetWorkers
+		w.profile = NewStrategyProfile()
+		w.external = m.external
+		w.weightedChance = m.weightedChance
+		w.base = m.profile
+
+		wg.Add(1)
+		go func(w *MCCFR) {
+			defer wg.Done()
+			w.Iterate(root)
+		}(w)
+	}
+	wg.Wait()
+
+	// Workers started from the shared regrets; keep only what they added. The
+	// shared profile is untouched until every delta is known.
+	for _, w := range m.workers {
+		for infoSet, local := range w.profile.strategies {
+			if shared, ok := m.profile.Get(infoSet); ok {
+				for i := range local.RegretSum {
+					local.RegretSum[i] -= shared.RegretSum[i]
+				}
+			}
+		}
+	}
+
+	// Worker order fixes the order of the floating-point additions
+	for _, w := range m.workers {
+		for infoSet, local := range w.profile.strategies {
+			m.profile.GetOrCreate(infoSet, local.Actions).merge(local, m.iteration)
+		}
+		w.profile = nil
+	}
+}
+
+// strategyFor returns the strategy to sample and update at an info set
+// A worker's copy starts with the shared profile's regrets, so it plays the
+// shared current strategy until its own updates move it.
+func (m *MCCFR) strategyFor(infoSet string, actions []notation.Action) *Strategy {
+	if m.base == nil {
+		return m.profile.GetOrCreate(infoSet, actions)
+	}
+	if s, ok := m.profile.Get(infoSet); ok {
+		return s
+	}
+
+	s := m.profile.GetOrCreate(infoSet, actions)
+	if shared, ok := m.base.Get(infoSet); ok {
+		copy(s.RegretSum, shared.RegretSum)
+	}
+	return s
+}
+
+// merge adds a worker's regret and strategy-sum updates from iteration to s
+// delta's StrategySum holds only the worker's additions; its action values join
+// s's average for the iteration with the weight the worker gave them.
+func (s *Strategy) merge(delta *Strategy, iteration int) {
+	s.UpdateRegrets(delta.RegretSum)
+	if delta.updates > 0 {
+		s.beginIteration(iteration)
+		for i := range s.StrategySum {
+			s.StrategySum[i] += delta.StrategySum[i]
+		}
+	}
+	if delta.evWeight > 0 {
+		s.addActionEV(iteration, delta.ActionEV, delta.evWeight)
+	}
+}
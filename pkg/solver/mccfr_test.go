@@ -1,6 +1,7 @@
 package solver
 
 import (
+	"math"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -209,10 +210,10 @@ func TestMCCFR_RolloutCorrectness(t *testing.T) {
 			ties++
 		}
 
-		// Payoffs should sum to pot (or pot split on tie)
+		// Net payoffs are zero-sum, with half the pot at stake
 		total := payoff[0] + payoff[1]
-		if total != pot {
-			t.Errorf("Payoffs should sum to pot: got %.2f, want %.2f", total, pot)
+		if total != 0 || (payoff[0] != 0 && math.Abs(payoff[0]) != pot/2) {
+			t.Errorf("Payoffs should be ±pot/2 and zero-sum: got [%.2f, %.2f]", payoff[0], payoff[1])
 		}
 	}
 
@@ -229,23 +230,110 @@ func TestMCCFR_RolloutCorrectness(t *testing.T) {
 	}
 }
 
+// TestMCCFR_PreflopRollout tests that rollouts deal a full board for preflop showdowns
+func TestMCCFR_PreflopRollout(t *testing.T) {
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.SB, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Street: notation.Preflop,
+	}
+	combo0 := notation.Combo{
+		Card1: cards.Card{Rank: cards.Ace, Suit: cards.Diamonds},
+		Card2: cards.Card{Rank: cards.Ace, Suit: cards.Clubs},
+	}
+	combo1 := notation.Combo{
+		Card1: cards.Card{Rank: cards.Seven, Suit: cards.Spades},
+		Card2: cards.Card{Rank: cards.Two, Suit: cards.Hearts},
+	}
+	config := tree.ActionConfig{
+		AllowCheck:          true,
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{3.0},
+		MaxRaises:           2,
+		Preflop:             true,
+	}
+	root, err := tree.NewBuilder(config).Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// Limp, check: a 2bb pot with no board cards yet
+	node := root.Children["c"].Children["x"]
+	if !node.NeedsRollout || len(node.Board) != 0 {
+		t.Fatalf("limp-check should be a preflop rollout, got rollout=%v board=%v", node.NeedsRollout, node.Board)
+	}
+
+	solver := NewMCCFR(33333)
+	wins := 0
+	numSamples := 200
+	for i := 0; i < numSamples; i++ {
+		payoff := solver.rollout(node)
+		if (payoff[0] != 0 && math.Abs(payoff[0]) != 1) || payoff[0]+payoff[1] != 0 {
+			t.Fatalf("Payoffs should be ±1 (half the 2bb pot) and zero-sum: got %v", payoff)
+		}
+		if payoff[0] > 0 {
+			wins++
+		}
+	}
+
+	// AA is about 88% against 72o; a rollout that skipped the board would tie every time
+	if rate := float64(wins) / float64(numSamples); rate < 0.7 {
+		t.Errorf("AA won %.1f%% of preflop rollouts, want about 88%%", rate*100)
+	}
+
+	// The whole tree trains
+	profile := solver.Train(root, 200)
+	if len(profile.All()) == 0 {
+		t.Error("expected info sets after training a preflop tree")
+	}
+}
+
+// TestMCCFR_PreflopAllInRollout tests that AA is about an 80% favorite over KK preflop
+func TestMCCFR_PreflopAllInRollout(t *testing.T) {
+	combo0 := notation.Combo{
+		Card1: cards.Card{Rank: cards.Ace, Suit: cards.Spades},
+		Card2: cards.Card{Rank: cards.Ace, Suit: cards.Hearts},
+	}
+	combo1 := notation.Combo{
+		Card1: cards.Card{Rank: cards.King, Suit: cards.Diamonds},
+		Card2: cards.Card{Rank: cards.King, Suit: cards.Clubs},
+	}
+	node := tree.NewRolloutNode(200, nil, [2]float64{0, 0}, [2]notation.Combo{combo0, combo1})
+
+	solver := NewMCCFR(44444)
+	numSamples := 4000
+	equity := 0.0
+	for i := 0; i < numSamples; i++ {
+		payoff := solver.rollout(node)
+		switch {
+		case payoff[0] > 0:
+			equity++
+		case payoff[0] == 0:
+			equity += 0.5
+		}
+	}
+	equity /= float64(numSamples)
+
+	// AA vs KK is about 82%; 4000 samples put the estimate within ±2% or so
+	if equity < 0.77 || equity > 0.87 {
+		t.Errorf("AA equity over %d preflop rollouts = %.3f, want about 0.82", numSamples, equity)
+	}
+
+	// A complete board is evaluated without dealing: AA wins on this river
+	river, _ := cards.ParseCards("2c7d9hTs3s")
+	riverNode := tree.NewRolloutNode(200, river, [2]float64{0, 0}, [2]notation.Combo{combo0, combo1})
+	if payoff := solver.rollout(riverNode); payoff != [2]float64{100, -100} {
+		t.Errorf("river rollout payoffs = %v, want [100 -100]", payoff)
+	}
+}
+
 // TestMCCFR_ChanceNodeSampling tests that chance nodes are sampled correctly
 // SAFETY: Uses only 200 samples to prevent memory explosion
 func TestMCCFR_ChanceNodeSampling(t *testing.T) {
-	// Create a chance node with uniform probabilities
-	root := tree.NewChanceNode(10.0, nil, [2]float64{100, 100})
-
-	// Add 3 children with different payoffs
-	child1 := tree.NewTerminalNode(10.0, [2]float64{10, 0}, nil, [2]float64{100, 100})
-	child2 := tree.NewTerminalNode(10.0, [2]float64{0, 10}, nil, [2]float64{100, 100})
-	child3 := tree.NewTerminalNode(10.0, [2]float64{5, 5}, nil, [2]float64{100, 100})
-
-	root.Children["outcome1"] = child1
-	root.Children["outcome2"] = child2
-	root.Children["outcome3"] = child3
-	root.ChanceProbabilities["outcome1"] = 0.5
-	root.ChanceProbabilities["outcome2"] = 0.3
-	root.ChanceProbabilities["outcome3"] = 0.2
+	root := buildChanceFixture()
 
 	// Sample many times and verify distribution converges to expected value
 	// SAFETY: Only 200 samples to prevent memory issues
@@ -278,3 +366,268 @@ func TestMCCFR_ChanceNodeSampling(t *testing.T) {
 		t.Logf("Note: P1 payoff %.2f (expected ~4.0, but small sample size)", avgPayoff1)
 	}
 }
+
+// buildChanceFixture builds a chance node with skewed 0.5/0.3/0.2 outcome probabilities
+// Expected payoffs: P0 = 0.5×10 + 0.3×0 + 0.2×5 = 6, P1 = 0.5×0 + 0.3×10 + 0.2×5 = 4
+func buildChanceFixture() *tree.TreeNode {
+	root := tree.NewChanceNode(10.0, nil, [2]float64{100, 100})
+
+	// Add 3 children with different payoffs
+	child1 := tree.NewTerminalNode(10.0, [2]float64{10, 0}, nil, [2]float64{100, 100})
+	child2 := tree.NewTerminalNode(10.0, [2]float64{0, 10}, nil, [2]float64{100, 100})
+	child3 := tree.NewTerminalNode(10.0, [2]float64{5, 5}, nil, [2]float64{100, 100})
+
+	root.Children["outcome1"] = child1
+	root.Children["outcome2"] = child2
+	root.Children["outcome3"] = child3
+	root.ChanceProbabilities["outcome1"] = 0.5
+	root.ChanceProbabilities["outcome2"] = 0.3
+	root.ChanceProbabilities["outcome3"] = 0.2
+
+	return root
+}
+
+// TestMCCFR_WeightedChanceSampling compares probability-weighted chance sampling
+// against uniform sampling with importance correction on the skewed fixture
+func TestMCCFR_WeightedChanceSampling(t *testing.T) {
+	root := buildChanceFixture()
+	const numSamples = 5000
+
+	// sampleStats returns the mean and variance of P0's sampled payoff
+	sampleStats := func(weighted bool) (float64, float64) {
+		solver := NewMCCFR(33333)
+		solver.SetWeightedChanceSampling(weighted)
+
+		values := make([]float64, numSamples)
+		mean := 0.0
+		for i := range values {
+			values[i] = solver.mccfr(root, 1.0, 1.0, 1.0)[0]
+			mean += values[i]
+		}
+		mean /= numSamples
+
+		variance := 0.0
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+		return mean, variance / numSamples
+	}
+
+	weightedMean, weightedVar := sampleStats(true)
+	uniformMean, uniformVar := sampleStats(false)
+
+	t.Logf("weighted: mean %.3f var %.2f; uniform+correction: mean %.3f var %.2f",
+		weightedMean, weightedVar, uniformMean, uniformVar)
+
+	// Both are unbiased; standard error is under 0.1 for either
+	if math.Abs(weightedMean-6) > 0.3 {
+		t.Errorf("weighted sampling mean %.3f, expected ~6", weightedMean)
+	}
+	if math.Abs(uniformMean-6) > 0.3 {
+		t.Errorf("uniform sampling mean %.3f, expected ~6", uniformMean)
+	}
+
+	// Theoretical variances are 19 (weighted) vs 42 (uniform with correction)
+	if weightedVar >= uniformVar {
+		t.Errorf("expected weighted sampling to have lower variance: %.2f vs %.2f", weightedVar, uniformVar)
+	}
+
+	// Same seed gives the same samples
+	again, _ := sampleStats(true)
+	if again != weightedMean {
+		t.Errorf("weighted sampling not deterministic: %.4f vs %.4f", weightedMean, again)
+	}
+}
+
+// TestMCCFR_ChopHeavyRangeValue checks sampled range-root values against the analytic
+// expectation on a board where most combo pairs chop and rake makes chops cost both players
+func TestMCCFR_ChopHeavyRangeValue(t *testing.T) {
+	// The board is a nine-high straight: only BTN's tens improve, everything else chops
+	gs, err := notation.ParsePosition("BTN:AA:S100/BB:KK:S100|P10|9h8d7c6s5h|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	range0, weights0, _ := notation.ParseWeightedRange("AA,TT@0.5")
+	range1, weights1, _ := notation.ParseWeightedRange("KK,QQ")
+
+	// Checks only, so the value is the showdown expectation over combo pairs
+	config := tree.ActionConfig{AllowCheck: true, RakePct: 0.05}
+	root, err := tree.NewBuilder(config).BuildWeightedRange(gs, range0, weights0, range1, weights1)
+	if err != nil {
+		t.Fatalf("BuildWeightedRange() error = %v", err)
+	}
+
+	// TT is 3 of 9 weighted combos. Win: +5 less 0.5 rake; chop: 9.5 split, -0.25 each
+	want := [2]float64{
+		1.0/3*4.5 + 2.0/3*-0.25,
+		1.0/3*-5 + 2.0/3*-0.25,
+	}
+
+	exact := EvaluateStrategy(root, NewStrategyProfile())
+	if math.Abs(exact[0]-want[0]) > 1e-9 || math.Abs(exact[1]-want[1]) > 1e-9 {
+		t.Errorf("exact value %v, want analytic %v", exact, want)
+	}
+
+	for _, weighted := range []bool{true, false} {
+		m := NewMCCFR(7)
+		m.SetWeightedChanceSampling(weighted)
+
+		const samples = 20000
+		var mean [2]float64
+		for i := 0; i < samples; i++ {
+			v := m.mccfr(root, 1, 1, 1)
+			mean[0] += v[0] / samples
+			mean[1] += v[1] / samples
+		}
+		t.Logf("weighted=%v: sampled %v, analytic %v", weighted, mean, want)
+		if math.Abs(mean[0]-want[0]) > 0.1 || math.Abs(mean[1]-want[1]) > 0.1 {
+			t.Errorf("weighted=%v: sampled value %v, want ~%v", weighted, mean, want)
+		}
+	}
+}
+
+// buildTurnTestTree builds the AA vs QQ turn tree used by the rollout tests
+func buildTurnTestTree(t testing.TB) *tree.TreeNode {
+	board := []cards.Card{
+		{Rank: cards.King, Suit: cards.Hearts},
+		{Rank: cards.Nine, Suit: cards.Spades},
+		{Rank: cards.Four, Suit: cards.Clubs},
+		{Rank: cards.Seven, Suit: cards.Diamonds},
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.Card{Rank: cards.Ace, Suit: cards.Diamonds},
+		Card2: cards.Card{Rank: cards.Ace, Suit: cards.Clubs},
+	}
+	combo1 := notation.Combo{
+		Card1: cards.Card{Rank: cards.Queen, Suit: cards.Diamonds},
+		Card2: cards.Card{Rank: cards.Queen, Suit: cards.Hearts},
+	}
+
+	builder := tree.NewBuilder(tree.DefaultRiverConfig())
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		Street: notation.Turn,
+		ToAct:  0,
+	}
+
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Failed to build turn tree: %v", err)
+	}
+	return root
+}
+
+// TestMCCFRExternal_KuhnPoker tests external-sampling MCCFR on Kuhn poker
+func TestMCCFRExternal_KuhnPoker(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	solver := NewMCCFRExternal(12345)
+	profile := solver.Train(root, 500)
+
+	if profile.NumInfoSets() < 4 {
+		t.Errorf("Expected at least 4 information sets, got %d", profile.NumInfoSets())
+	}
+
+	for infoSet, strategy := range profile.All() {
+		avg := strategy.GetAverageStrategy()
+		sum := 0.0
+		for _, p := range avg {
+			sum += p
+		}
+		if math.Abs(sum-1.0) > 1e-9 {
+			t.Errorf("Strategy for %s doesn't sum to 1.0, got %.6f", infoSet, sum)
+		}
+	}
+
+	// Queen always beats Jack, so Queen should call a bet
+	queen, ok := profile.Get("Q|b1.0")
+	if !ok {
+		t.Fatal("Missing strategy for Queen facing bet")
+	}
+	if call := queen.GetAverageStrategy()[1]; call < 0.9 {
+		t.Errorf("Queen facing bet should call, got call=%.2f", call)
+	}
+}
+
+// TestMCCFRExternal_TurnRollout tests external sampling on a turn tree with rollouts
+func TestMCCFRExternal_TurnRollout(t *testing.T) {
+	root := buildTurnTestTree(t)
+
+	solver := NewMCCFRExternal(67890)
+	profile := solver.Train(root, 200)
+
+	if profile.NumInfoSets() == 0 {
+		t.Fatal("No strategies found after training")
+	}
+
+	for infoSet, strategy := range profile.All() {
+		for name, probs := range map[string][]float64{
+			"average": strategy.GetAverageStrategy(),
+			"current": strategy.GetStrategy(),
+		} {
+			sum := 0.0
+			for _, p := range probs {
+				if p < 0 || p > 1 {
+					t.Errorf("%s strategy for %s has invalid probability %.4f", name, infoSet, p)
+				}
+				sum += p
+			}
+			if math.Abs(sum-1.0) > 1e-9 {
+				t.Errorf("%s strategy for %s doesn't sum to 1.0, got %.6f", name, infoSet, sum)
+			}
+		}
+	}
+}
+
+// BenchmarkMCCFR_OutcomeSampling benchmarks outcome-sampling MCCFR on the turn tree
+func BenchmarkMCCFR_OutcomeSampling(b *testing.B) {
+	root := buildTurnTestTree(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewMCCFR(42).Train(root, 200)
+	}
+}
+
+// BenchmarkMCCFR_ExternalSampling benchmarks external-sampling MCCFR on the turn tree
+func BenchmarkMCCFR_ExternalSampling(b *testing.B) {
+	root := buildTurnTestTree(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewMCCFRExternal(42).Train(root, 200)
+	}
+}
+
+func TestMCCFR_TrainUntil_StopsEarly(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	const maxIters = 20000
+	mccfr := NewMCCFRExternal(42)
+	profile, iterations := mccfr.TrainUntil(root, maxIters, 2.05, 100)
+
+	if iterations >= maxIters {
+		t.Errorf("TrainUntil ran all %d iterations, expected to stop early", iterations)
+	}
+	if exploit := CalculateExploitability(profile, root); exploit > 2.05 {
+		t.Errorf("exploitability %.4f above target 2.05", exploit)
+	}
+}
+
+// TestMCCFR_SampleActionSkipsZeroProbability checks the float-error fallback
+// never picks an action the strategy gives no weight
+func TestMCCFR_SampleActionSkipsZeroProbability(t *testing.T) {
+	m := NewMCCFR(1)
+	short := []float64{0.3, 0.3, 0} // Sums well short of 1, so the fallback runs often
+	for i := 0; i < 1000; i++ {
+		if a := m.sampleAction(short); a == 2 {
+			t.Fatalf("sampled zero-probability action from %v", short)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// MultiStreetConfig controls a street-by-street solve (see SolveMultiStreet)
+type MultiStreetConfig struct {
+	// Actions is the betting abstraction used on every street
+	Actions tree.ActionConfig
+
+	// Iterations is the number of CFR iterations for the root street
+	Iterations int
+
+	// SubgameIterations is the number of iterations for each later-street
+	// subgame (0 = Iterations)
+	SubgameIterations int
+
+	// Evaluator scores showdowns on every street (nil = cards.DefaultEvaluator)
+	Evaluator cards.Evaluator
+}
+
+// SolveMultiStreet solves a flop or turn spot one street at a time
+// Each street is its own range tree, built with BuildRange, whose betting ends
+// where the next card would be dealt. Those leaves are valued by solving the
+// next street's subgame for every card that can come (river subgames with
+// SolveRiver) and averaging each combo pair's value under the subgame's
+// equilibrium, so no single tree spans more than one street. Subgames reached
+// with the same board, pot, and stacks are solved once.
+//
+// Subgames are solved with the full ranges rather than the ranges that would
+// actually reach them, so the result approximates a full multi-street solve.
+// All-in leaves are valued exactly by enumerating the remaining board. Only the
+// root street's profile is returned; a river spot is just SolveRiver.
+func SolveMultiStreet(gs *notation.GameState, ranges [2][]notation.Combo, config MultiStreetConfig) (*StrategyProfile, error) {
+	if len(gs.Board) < 3 || len(gs.Board) > 5 {
+		return nil, fmt.Errorf("SolveMultiStreet requires a flop, turn, or river board, got %d cards", len(gs.Board))
+	}
+	if len(gs.Board) == 5 {
+		return SolveRiverWithEvaluator(gs, ranges[0], ranges[1], config.Actions, config.Evaluator, config.Iterations)
+	}
+
+	ms := &multiStreetSolver{
+		config:   config,
+		ranges:   ranges,
+		dead:     gs.DeadMoney,
+		subgames: make(map[string]map[string][2]float64),
+	}
+	if ms.config.SubgameIterations <= 0 {
+		ms.config.SubgameIterations = config.Iterations
+	}
+
+	// Later streets are opened by whoever opened this one
+	ms.opener = gs.ToAct
+	if len(gs.ActionHistory)%2 == 1 {
+		ms.opener = 1 - gs.ToAct
+	}
+
+	root, err := ms.buildStreet(gs)
+	if err != nil {
+		return nil, err
+	}
+	return NewCFR().Train(root, config.Iterations), nil
+}
+
+// multiStreetSolver holds the cached subgame values of one SolveMultiStreet call
+type multiStreetSolver struct {
+	config MultiStreetConfig
+	ranges [2][]notation.Combo
+	dead   float64
+	opener int
+
+	// subgames maps a board, pot, and stacks to each combo pair's value there,
+	// keyed "combo0:combo1" like BuildRange's chance outcomes
+	subgames map[string]map[string][2]float64
+}
+
+// builder returns a tree builder for the configured actions and evaluator
+func (ms *multiStreetSolver) builder() *tree.Builder {
+	b := tree.NewBuilder(ms.config.Actions)
+	b.SetEvaluator(ms.config.Evaluator)
+	return b
+}
+
+// buildStreet builds gs's range tree with every street-ending leaf given a fixed payoff
+func (ms *multiStreetSolver) buildStreet(gs *notation.GameState) (*tree.TreeNode, error) {
+	root, err := ms.builder().BuildRange(gs, ms.ranges[0], ms.ranges[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var resolve func(node *tree.TreeNode)
+	resolve = func(node *tree.TreeNode) {
+		if node.IsTerminal {
+			if node.NeedsRollout {
+				node.Payoff = ms.leafValue(node)
+				node.NeedsRollout = false
+			}
+			return
+		}
+		for _, child := range node.Children {
+			resolve(child)
+		}
+	}
+	resolve(root)
+	return root, nil
+}
+
+// leafValue values a leaf where the street's betting closed
+// With chips behind, it is the average over next cards of the next street's
+// subgame value for the leaf's combo pair; all-in, the board just runs out.
+func (ms *multiStreetSolver) leafValue(node *tree.TreeNode) [2]float64 {
+	if node.Stacks[0] <= 0 || node.Stacks[1] <= 0 {
+		return expectedRolloutPayoff(node)
+	}
+
+	combos := node.PlayerCombos
+	pairKey := fmt.Sprintf("%s:%s", combos[0].String(), combos[1].String())
+
+	deck := cards.NewDeckFor(ms.config.Evaluator)
+	deck.Remove(node.Board...)
+	deck.Remove(combos[0].Cards()...)
+	deck.Remove(combos[1].Cards()...)
+
+	var total [2]float64
+	count := 0
+	for _, card := range deck.Cards() {
+		board := append(append([]cards.Card{}, node.Board...), card)
+		value, ok := ms.subgame(board, node.Pot, node.Stacks)[pairKey]
+		if !ok {
+			continue
+		}
+		total[0] += value[0]
+		total[1] += value[1]
+		count++
+	}
+	if count == 0 {
+		return expectedRolloutPayoff(node)
+	}
+	return [2]float64{total[0] / float64(count), total[1] / float64(count)}
+}
+
+// subgame returns each combo pair's value in the next street's subgame, solving it once
+// The map is empty when the new card leaves no valid combo pair.
+func (ms *multiStreetSolver) subgame(board []cards.Card, pot float64, stacks [2]float64) map[string][2]float64 {
+	key := fmt.Sprintf("%v|%g|%g|%g", board, pot, stacks[0], stacks[1])
+	if values, ok := ms.subgames[key]; ok {
+		return values
+	}
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: stacks[0]},
+			{Position: notation.BB, Stack: stacks[1]},
+		},
+		Pot:       pot,
+		DeadMoney: ms.dead,
+		Board:     board,
+		ToAct:     ms.opener,
+		Street:    notation.GetStreet(len(board)),
+	}
+
+	var root *tree.TreeNode
+	var profile *StrategyProfile
+	var err error
+	if len(board) == 5 {
+		// The river solver's info sets match the range tree's, which is only
+		// needed to read off per-pair values
+		profile, err = SolveRiverWithEvaluator(gs, ms.ranges[0], ms.ranges[1], ms.config.Actions, ms.config.Evaluator, ms.config.SubgameIterations)
+		if err == nil {
+			root, err = ms.builder().BuildRange(gs, ms.ranges[0], ms.ranges[1])
+		}
+	} else {
+		root, err = ms.buildStreet(gs)
+		if err == nil {
+			profile = NewCFR().Train(root, ms.config.SubgameIterations)
+		}
+	}
+
+	values := make(map[string][2]float64)
+	if err == nil {
+		for pairKey, child := range root.Children {
+			values[pairKey] = evaluateNode(child, profile, [2]FixedPolicy{})
+		}
+	}
+	ms.subgames[key] = values
+	return values
+}
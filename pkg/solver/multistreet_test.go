@@ -0,0 +1,89 @@
+package solver
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+func TestSolveMultiStreet_TurnMatchesDirectSolve(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d")
+	range0, _ := notation.ParseRange("AA,65s")
+	range1, _ := notation.ParseRange("KQs,QQ")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 20},
+			{Position: notation.BB, Stack: 20},
+		},
+		Pot:    10,
+		Board:  board,
+		Street: notation.Turn,
+	}
+	config := tree.ActionConfig{BetSizes: []float64{1.0}, AllowCheck: true, AllowCall: true, AllowFold: true}
+
+	start := time.Now()
+	streets, err := SolveMultiStreet(gs, [2][]notation.Combo{range0, range1}, MultiStreetConfig{Actions: config, Iterations: 300, SubgameIterations: 100})
+	if err != nil {
+		t.Fatalf("SolveMultiStreet failed: %v", err)
+	}
+	streetTime := time.Since(start)
+
+	// Direct: one tree through the river, solved by sampling
+	start = time.Now()
+	builder := tree.NewBuilder(config)
+	builder.SetMultiStreet(true)
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange failed: %v", err)
+	}
+	direct := NewMCCFRExternal(1).Train(root, 30000)
+	directTime := time.Since(start)
+
+	// Solving street by street must beat the direct solve it matches
+	if streetTime >= directTime {
+		t.Errorf("street-by-street took %v, direct %v; want it faster", streetTime, directTime)
+	}
+
+	// Compare each turn node's full strategy, averaged over the acting range
+	for _, prefix := range []string{"Kh9s4c7d||>BTN|", "Kh9s4c7d|x|>BB|", "Kh9s4c7d|b10.0|>BB|", "Kh9s4c7d|xb10.0|>BTN|"} {
+		got, want := meanStrategy(streets, prefix), meanStrategy(direct, prefix)
+		if len(got) == 0 || len(got) != len(want) {
+			t.Fatalf("%s: got %v, direct %v", prefix, got, want)
+		}
+		for a := range got {
+			if math.Abs(got[a]-want[a]) > 0.1 {
+				t.Errorf("%s: street-by-street %v, direct %v", prefix, got, want)
+				break
+			}
+		}
+	}
+}
+
+// meanStrategy averages the average strategies of every info set starting with prefix
+func meanStrategy(profile *StrategyProfile, prefix string) []float64 {
+	var mean []float64
+	n := 0
+	for _, infoSet := range profile.SortedInfoSets() {
+		if !strings.HasPrefix(infoSet, prefix) {
+			continue
+		}
+		strategy, _ := profile.Get(infoSet)
+		avg := strategy.GetAverageStrategy()
+		if mean == nil {
+			mean = make([]float64, len(avg))
+		}
+		for a, p := range avg {
+			mean[a] += p
+		}
+		n++
+	}
+	for a := range mean {
+		mean[a] /= float64(n)
+	}
+	return mean
+}
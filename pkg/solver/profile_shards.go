@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"sync"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// numProfileShards is the number of independently locked maps in a concurrent profile
+const numProfileShards = 64
+
+// profileShard is one locked slice of a concurrent profile's info sets
+type profileShard struct {
+	mu         sync.RWMutex
+	strategies map[string]*Strategy
+}
+
+// NewConcurrentStrategyProfile creates a profile that goroutines can share
+// GetOrCreate, Get, All, and NumInfoSets are safe to call concurrently; info sets
+// are spread over numProfileShards maps so lookups rarely contend. Only the map
+// is guarded: callers still serialize updates to a single Strategy's slices.
+func NewConcurrentStrategyProfile() *StrategyProfile {
+	sp := &StrategyProfile{}
+	sp.makeConcurrent()
+	return sp
+}
+
+// makeConcurrent moves a plain profile's strategies into shards in place
+// Must not run while other goroutines use the profile.
+func (sp *StrategyProfile) makeConcurrent() {
+	if sp.shards != nil {
+		return
+	}
+
+	sp.shards = make([]profileShard, numProfileShards)
+	for i := range sp.shards {
+		sp.shards[i].strategies = make(map[string]*Strategy)
+	}
+	for infoSet, s := range sp.strategies {
+		sp.shard(infoSet).strategies[infoSet] = s
+	}
+	sp.strategies = nil
+}
+
+// shard returns the shard holding an info set (FNV-1a hash of the key)
+func (sp *StrategyProfile) shard(infoSet string) *profileShard {
+	h := uint32(2166136261)
+	for i := 0; i < len(infoSet); i++ {
+		h ^= uint32(infoSet[i])
+		h *= 16777619
+	}
+	return &sp.shards[h%uint32(len(sp.shards))]
+}
+
+// snapshot copies every shard into one map, holding all shard locks so the copy
+// reflects a single moment
+func (sp *StrategyProfile) snapshot() map[string]*Strategy {
+	for i := range sp.shards {
+		sp.shards[i].mu.RLock()
+	}
+	defer func() {
+		for i := range sp.shards {
+			sp.shards[i].mu.RUnlock()
+		}
+	}()
+
+	n := 0
+	for i := range sp.shards {
+		n += len(sp.shards[i].strategies)
+	}
+	all := make(map[string]*Strategy, n)
+	for i := range sp.shards {
+		for infoSet, s := range sp.shards[i].strategies {
+			all[infoSet] = s
+		}
+	}
+	return all
+}
+
+// numSharded counts the info sets across all shards
+func (sp *StrategyProfile) numSharded() int {
+	n := 0
+	for i := range sp.shards {
+		sp.shards[i].mu.RLock()
+		n += len(sp.shards[i].strategies)
+		sp.shards[i].mu.RUnlock()
+	}
+	return n
+}
+
+func (ps *profileShard) get(infoSet string) (*Strategy, bool) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	s, exists := ps.strategies[infoSet]
+	return s, exists
+}
+
+func (ps *profileShard) getOrCreate(infoSet string, actions []notation.Action) *Strategy {
+	if s, exists := ps.get(infoSet); exists {
+		return s
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	// Another goroutine may have created it between the two locks
+	if s, exists := ps.strategies[infoSet]; exists {
+		return s
+	}
+	s := NewStrategy(infoSet, actions)
+	ps.strategies[infoSet] = s
+	return s
+}
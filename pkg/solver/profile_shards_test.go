@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// Run with -race: goroutines create, look up, and snapshot overlapping info sets
+func TestConcurrentStrategyProfile_GetOrCreate(t *testing.T) {
+	const (
+		goroutines = 16
+		keys       = 500
+	)
+	actions := []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 5}}
+	sp := NewConcurrentStrategyProfile()
+
+	results := make([][]*Strategy, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			results[g] = make([]*Strategy, keys)
+			for i := 0; i < keys; i++ {
+				// Each goroutine walks the keys from a different starting point
+				k := (i + g*37) % keys
+				results[g][k] = sp.GetOrCreate(fmt.Sprintf("Kh9s4c|b%d|>BB|QdJd", k), actions)
+				if _, ok := sp.Get(fmt.Sprintf("Kh9s4c|b%d|>BB|QdJd", k)); !ok {
+					t.Errorf("Get missed an info set this goroutine just created")
+				}
+				if i%100 == 0 {
+					_ = sp.All()
+					_ = sp.NumInfoSets()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if n := sp.NumInfoSets(); n != keys {
+		t.Errorf("NumInfoSets = %d, want %d", n, keys)
+	}
+	if n := len(sp.All()); n != keys {
+		t.Errorf("All() has %d strategies, want %d", n, keys)
+	}
+
+	// Every goroutine got the same Strategy for a key
+	for g := 1; g < goroutines; g++ {
+		for k := 0; k < keys; k++ {
+			if results[g][k] != results[0][k] {
+				t.Fatalf("goroutines 0 and %d got different strategies for key %d", g, k)
+			}
+		}
+	}
+}
+
+func TestStrategyProfile_MakeConcurrent(t *testing.T) {
+	profile := NewCFR().Train(BuildKuhnPokerTree(), 20)
+	before := profile.All()
+	want := profile.SortedInfoSets()
+
+	// Switching a trained CFR solver to parallel keeps its strategies
+	cfr := NewCFRFromProfile(profile)
+	cfr.SetWorkers(4)
+
+	got := profile.SortedInfoSets()
+	if len(got) != len(want) {
+		t.Fatalf("concurrent profile has %d info sets, want %d", len(got), len(want))
+	}
+	for _, infoSet := range want {
+		s, ok := profile.Get(infoSet)
+		if !ok || s != before[infoSet] {
+			t.Errorf("info set %q not carried over", infoSet)
+		}
+	}
+
+	// Training continues on the same strategies
+	cfr.Train(BuildKuhnPokerTree(), 20)
+	if profile.NumInfoSets() != len(want) {
+		t.Errorf("training added info sets: %d, want %d", profile.NumInfoSets(), len(want))
+	}
+}
@@ -0,0 +1,317 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// SolveRiver solves a river range-vs-range spot on a single public action tree
+// Instead of building a subtree per combo pair (BuildRange), each decision node
+// holds one strategy per hand in the acting player's range, keyed with
+// tree.GetInfoSet exactly as the builder would. CFR walks the tree once per
+// iteration carrying a reach probability for every hand; showdown values are
+// each hand's payoff against the opponent's reach-weighted, card-compatible combos.
+// Combos that conflict with the board are dropped. Each strategy's ActionEV is
+// its hand's value per action against the opponent combos it doesn't block,
+// renormalized over their reach.
+func SolveRiver(gs *notation.GameState, range0, range1 []notation.Combo, config tree.ActionConfig, iterations int, opts ...TrainOptions) (*StrategyProfile, error) {
+	return SolveRiverWithEvaluator(gs, range0, range1, config, nil, iterations, opts...)
+}
+
+// SolveRiverWithEvaluator is SolveRiver with showdowns scored by evaluator
+// (nil = cards.DefaultEvaluator)
+func SolveRiverWithEvaluator(gs *notation.GameState, range0, range1 []notation.Combo, config tree.ActionConfig, evaluator cards.Evaluator, iterations int, opts ...TrainOptions) (*StrategyProfile, error) {
+	if len(gs.Board) != 5 {
+		return nil, fmt.Errorf("SolveRiver requires a 5-card board, got %d cards", len(gs.Board))
+	}
+
+	rs, err := newRiverSolver(gs, range0, range1, evaluator)
+	if err != nil {
+		return nil, err
+	}
+
+	// The public tree comes from any compatible combo pair; only its action
+	// structure, pots, and fold payoffs are used
+	builder := tree.NewBuilder(config)
+	builder.SetEvaluator(evaluator)
+	root, err := builder.Build(gs, rs.hands[0][0].combo, rs.hands[1][rs.firstCompatible].combo)
+	if err != nil {
+		return nil, fmt.Errorf("building public tree: %w", err)
+	}
+
+	return rs.Train(root, iterations, opts...), nil
+}
+
+// riverHand is one combo in a player's river range
+type riverHand struct {
+	combo notation.Combo
+	hole  []cards.Card
+}
+
+// riverSolver runs vector-form CFR over a public river tree
+// It satisfies Solver so it can share the training loop.
+type riverSolver struct {
+	profile *StrategyProfile
+
+	board   []cards.Card
+	history []notation.Action // Actions before the tree root
+
+	hands [2][]riverHand
+
+	// showdown[i][j] is +1/0/-1 for player 0 hand i vs player 1 hand j
+	// compatible[i][j] is false when the two combos share a card
+	showdown   [][]int8
+	compatible [][]bool
+
+	// firstCompatible is a player 1 hand index that doesn't conflict with player 0's first hand
+	firstCompatible int
+
+	// iteration is the profile iteration being run (see StrategyProfile.nextIteration)
+	iteration int
+}
+
+func newRiverSolver(gs *notation.GameState, range0, range1 []notation.Combo, evaluator cards.Evaluator) (*riverSolver, error) {
+	if evaluator == nil {
+		evaluator = cards.DefaultEvaluator
+	}
+
+	rs := &riverSolver{
+		profile: NewStrategyProfile(),
+		board:   gs.Board,
+		history: gs.ActionHistory,
+	}
+
+	// Cards outside the evaluator's deck (e.g., below Six in short deck) block
+	// combos like board cards do, and may not be on the board
+	offDeck := cards.NewDeck()
+	offDeck.Remove(cards.NewDeckFor(evaluator).Cards()...)
+	if cards.NewCardMask(offDeck.Cards()...).Overlaps(cards.NewCardMask(gs.Board...)) {
+		return nil, fmt.Errorf("board %v has a card not in the deck", gs.Board)
+	}
+	dead := append(append([]cards.Card{}, gs.Board...), offDeck.Cards()...)
+
+	for p, r := range [2][]notation.Combo{range0, range1} {
+		for _, combo := range notation.RemoveBlockers(r, dead) {
+			rs.hands[p] = append(rs.hands[p], riverHand{
+				combo: combo,
+				hole:  combo.Cards(),
+			})
+		}
+		if len(rs.hands[p]) == 0 {
+			return nil, fmt.Errorf("player %d range is empty after removing board cards", p)
+		}
+	}
+
+	values := [2][]cards.HandValue{}
+	for p := range rs.hands {
+		for _, h := range rs.hands[p] {
+			values[p] = append(values[p], evaluator.Evaluate(h.hole, rs.board))
+		}
+	}
+
+	rs.firstCompatible = -1
+	rs.showdown = make([][]int8, len(rs.hands[0]))
+	rs.compatible = make([][]bool, len(rs.hands[0]))
+	for i, h0 := range rs.hands[0] {
+		rs.showdown[i] = make([]int8, len(rs.hands[1]))
+		rs.compatible[i] = make([]bool, len(rs.hands[1]))
+		for j, h1 := range rs.hands[1] {
+			if h0.combo.Conflicts(h1.hole) {
+				continue
+			}
+			rs.compatible[i][j] = true
+			rs.showdown[i][j] = int8(values[0][i].Compare(values[1][j]))
+			if i == 0 && rs.firstCompatible < 0 {
+				rs.firstCompatible = j
+			}
+		}
+	}
+	if rs.firstCompatible < 0 {
+		return nil, fmt.Errorf("no valid combo pairs (all conflict with board or each other)")
+	}
+
+	return rs, nil
+}
+
+// Train runs vector CFR on the public tree
+func (rs *riverSolver) Train(root *tree.TreeNode, iterations int, opts ...TrainOptions) *StrategyProfile {
+	runTraining(rs, root, iterations, opts)
+	return rs.profile
+}
+
+// Iterate runs one full-range CFR iteration
+func (rs *riverSolver) Iterate(root *tree.TreeNode) {
+	var reach [2][]float64
+	for p := range reach {
+		reach[p] = make([]float64, len(rs.hands[p]))
+		for i := range reach[p] {
+			reach[p][i] = 1.0
+		}
+	}
+	rs.iteration = rs.profile.nextIteration()
+	rs.cfr(root, rs.history, reach)
+}
+
+// GetProfile returns the current strategy profile
+func (rs *riverSolver) GetProfile() *StrategyProfile {
+	return rs.profile
+}
+
+// cfr returns each player's counterfactual value for every hand in their range
+// Values are weighted by the opponent's reach, so regrets need no further scaling.
+func (rs *riverSolver) cfr(node *tree.TreeNode, history []notation.Action, reach [2][]float64) [2][]float64 {
+	if node.IsTerminal {
+		return rs.terminalValues(node, history, reach)
+	}
+
+	player := node.Player
+	opp := 1 - player
+	pos := []notation.Position{notation.BTN, notation.BB}[player]
+
+	// Current strategy for each of the acting player's hands
+	strategies := make([]*Strategy, len(rs.hands[player]))
+	current := make([][]float64, len(rs.hands[player]))
+	for h, hand := range rs.hands[player] {
+		infoSet := tree.GetInfoSet(rs.board, history, pos, hand.hole)
+		strategies[h] = rs.profile.GetOrCreate(infoSet, node.Actions)
+		current[h] = strategies[h].GetStrategy()
+	}
+
+	var nodeValue [2][]float64
+	nodeValue[player] = make([]float64, len(rs.hands[player]))
+	nodeValue[opp] = make([]float64, len(rs.hands[opp]))
+	actionValues := make([][]float64, len(node.Actions))
+
+	for a, action := range node.Actions {
+		child, exists := node.Children[tree.ActionKey(action)]
+		if !exists {
+			continue
+		}
+
+		var childReach [2][]float64
+		childReach[opp] = reach[opp]
+		childReach[player] = make([]float64, len(rs.hands[player]))
+		for h := range childReach[player] {
+			childReach[player][h] = reach[player][h] * current[h][a]
+		}
+
+		childHistory := append(append([]notation.Action{}, history...), action)
+		childValue := rs.cfr(child, childHistory, childReach)
+
+		actionValues[a] = childValue[player]
+		for h := range nodeValue[player] {
+			nodeValue[player][h] += current[h][a] * childValue[player][h]
+		}
+		// The opponent's values already carry this player's action probabilities
+		for h := range nodeValue[opp] {
+			nodeValue[opp][h] += childValue[opp][h]
+		}
+	}
+
+	for h, strategy := range strategies {
+		regrets := make([]float64, len(node.Actions))
+		for a := range node.Actions {
+			if actionValues[a] != nil {
+				regrets[a] = actionValues[a][h] - nodeValue[player][h]
+			}
+		}
+		strategy.UpdateRegrets(regrets)
+		strategy.beginIteration(rs.iteration)
+		strategy.UpdateStrategy(current[h], reach[player][h])
+
+		// Action values are sums over the opponent combos this hand doesn't
+		// block; dividing by their reach gives the hand's own EV per action
+		if weight := rs.compatibleReach(player, h, reach[opp]); weight > 0 {
+			evs := make([]float64, len(node.Actions))
+			for a := range node.Actions {
+				if actionValues[a] != nil {
+					evs[a] = actionValues[a][h] / weight
+				}
+			}
+			strategy.SetActionEV(evs)
+		}
+	}
+
+	return nodeValue
+}
+
+// terminalValues computes fold or showdown values for every hand
+// Each hand's value is its payoff against the opponent's reach-weighted,
+// card-compatible combos, so opponent hands that folded earlier carry no weight.
+func (rs *riverSolver) terminalValues(node *tree.TreeNode, history []notation.Action, reach [2][]float64) [2][]float64 {
+	values := [2][]float64{
+		make([]float64, len(rs.hands[0])),
+		make([]float64, len(rs.hands[1])),
+	}
+
+	// Fold payoffs don't depend on the cards, only on compatible opponent reach
+	last := tree.GetLastAction(history)
+	isFold := last != nil && last.Type == notation.Fold
+
+	// Showdown payoffs seen from player 0 winning, tying, and losing
+	win := tree.DeadShowdownPayoffs(1, node.Pot, node.Dead, node.Rake)
+	tie := tree.DeadShowdownPayoffs(0, node.Pot, node.Dead, node.Rake)
+	lose := tree.DeadShowdownPayoffs(-1, node.Pot, node.Dead, node.Rake)
+
+	for p := range values {
+		// Outcomes are stored from player 0's side; player 1's are mirrored
+		pWin, pLose := win, lose
+		if p == 1 {
+			pWin, pLose = lose, win
+		}
+		for h := range rs.hands[p] {
+			if isFold {
+				values[p][h] = rs.compatibleReach(p, h, reach[1-p]) * node.Payoff[p]
+				continue
+			}
+			w, t, l := rs.showdownOdds(p, h, reach[1-p])
+			values[p][h] = w*pWin[p] + t*tie[p] + l*pLose[p]
+		}
+	}
+
+	return values
+}
+
+// showdownOdds returns the opponent reach that hand h of player beats, ties, and
+// loses to at showdown. Combos sharing a card with the hand are excluded.
+// Dividing by the sum gives the hand's equity against the opponent's range as
+// it stands at this point in the tree.
+func (rs *riverSolver) showdownOdds(player, h int, oppReach []float64) (win, tie, lose float64) {
+	for j, r := range oppReach {
+		if r == 0 {
+			continue
+		}
+		i, k := h, j
+		if player == 1 {
+			i, k = j, h
+		}
+		if !rs.compatible[i][k] {
+			continue
+		}
+
+		cmp := rs.showdown[i][k]
+		if player == 1 {
+			cmp = -cmp
+		}
+		switch {
+		case cmp > 0:
+			win += r
+		case cmp < 0:
+			lose += r
+		default:
+			tie += r
+		}
+	}
+	return win, tie, lose
+}
+
+// compatibleReach returns the opponent reach that doesn't share a card with
+// hand h of player, the weight its values are summed over. A hand that blocks
+// the opponent's strong combos faces proportionally more of the rest.
+func (rs *riverSolver) compatibleReach(player, h int, oppReach []float64) float64 {
+	win, tie, lose := rs.showdownOdds(player, h, oppReach)
+	return win + tie + lose
+}
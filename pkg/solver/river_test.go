@@ -0,0 +1,323 @@
+package solver
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// riverValueSpot is BTN with AA (value) and 55 (air) vs BB's bluff-catching kings
+func riverValueSpot(t *testing.T) (*notation.GameState, []notation.Combo, []notation.Combo, tree.ActionConfig) {
+	t.Helper()
+
+	gs, err := notation.ParsePosition("BTN:AA:S100/BB:KQs:S100|P20|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+
+	range0, err := notation.ParseRange("AA,55")
+	if err != nil {
+		t.Fatalf("ParseRange() error = %v", err)
+	}
+	range1, err := notation.ParseRange("KQs,KJs")
+	if err != nil {
+		t.Fatalf("ParseRange() error = %v", err)
+	}
+
+	config := tree.ActionConfig{
+		BetSizes:   []float64{0.75},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+	}
+	return gs, notation.RemoveBlockers(range0, gs.Board), notation.RemoveBlockers(range1, gs.Board), config
+}
+
+// btnBetFrequency averages BTN's root bet frequency over combos with the given hole-card prefix
+func btnBetFrequency(profile *StrategyProfile, prefix string) float64 {
+	total, n := 0.0, 0
+	for infoSet, strategy := range profile.All() {
+		parts := strings.Split(infoSet, "|")
+		if len(parts) != 4 || parts[1] != "" || parts[2] != ">BTN" || !strings.HasPrefix(parts[3], prefix) {
+			continue
+		}
+		avg := strategy.GetAverageStrategy()
+		for i, action := range strategy.Actions {
+			if action.Type == notation.Bet {
+				total += avg[i]
+			}
+		}
+		n++
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return total / float64(n)
+}
+
+func TestSolveRiver_MatchesComboTree(t *testing.T) {
+	gs, range0, range1, config := riverValueSpot(t)
+
+	riverProfile, err := SolveRiver(gs, range0, range1, config, 500)
+	if err != nil {
+		t.Fatalf("SolveRiver() error = %v", err)
+	}
+
+	// Same game as a combo-pair chance tree
+	builder := tree.NewBuilder(config)
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange() error = %v", err)
+	}
+	exactProfile := NewCFR().Train(root, 500)
+	sampledProfile := NewMCCFRExternal(42).Train(root, 5000)
+
+	// Same info set keys as the combo tree, so the profiles are interchangeable
+	if riverProfile.NumInfoSets() != exactProfile.NumInfoSets() {
+		t.Errorf("SolveRiver has %d info sets, combo tree has %d", riverProfile.NumInfoSets(), exactProfile.NumInfoSets())
+	}
+
+	river := btnBetFrequency(riverProfile, "A")
+	exact := btnBetFrequency(exactProfile, "A")
+	sampled := btnBetFrequency(sampledProfile, "A")
+	riverExpl := CalculateExploitability(riverProfile, root)
+	sampledExpl := CalculateExploitability(sampledProfile, root)
+	t.Logf("BTN AA bet frequency: SolveRiver %.3f, CFR combo tree %.3f, MCCFR combo sampling %.3f", river, exact, sampled)
+	t.Logf("Exploitability: SolveRiver %.3f (500 iterations), MCCFR combo sampling %.3f (5000 iterations)", riverExpl, sampledExpl)
+
+	// Full enumeration of the combo tree is the same computation, just slower
+	if math.Abs(river-exact) > 0.02 {
+		t.Errorf("SolveRiver AA bet frequency %.3f differs from combo-tree CFR %.3f", river, exact)
+	}
+
+	// Chance sampling is noisier but lands in the same place
+	if math.Abs(river-sampled) > 0.1 {
+		t.Errorf("SolveRiver AA bet frequency %.3f far from combo-sampled MCCFR %.3f", river, sampled)
+	}
+
+	// Full-range iterations converge faster than sampling one combo pair at a time
+	if riverExpl >= sampledExpl {
+		t.Errorf("expected SolveRiver to be less exploitable: %.3f vs %.3f", riverExpl, sampledExpl)
+	}
+}
+
+func TestSolveRiver_Errors(t *testing.T) {
+	gs, range0, range1, config := riverValueSpot(t)
+
+	turn := *gs
+	turn.Board = gs.Board[:4]
+	if _, err := SolveRiver(&turn, range0, range1, config, 10); err == nil {
+		t.Error("expected error for a turn board")
+	}
+
+	// Every combo holds a board card
+	blocked := []notation.Combo{{Card1: cards.NewCard(cards.King, cards.Hearts), Card2: cards.NewCard(cards.Ace, cards.Spades)}}
+	if _, err := SolveRiver(gs, blocked, range1, config, 10); err == nil {
+		t.Error("expected error when a range is empty after board removal")
+	}
+}
+
+func TestSolveRiverWithEvaluator(t *testing.T) {
+	gs, range0, range1, config := riverValueSpot(t)
+
+	// Under the stub BTN's aces lose to every king and the fives beat them all
+	rs, err := newRiverSolver(gs, range0, range1, lowCardEvaluator{})
+	if err != nil {
+		t.Fatalf("newRiverSolver() error = %v", err)
+	}
+	for i, hand := range rs.hands[0] {
+		want := int8(1)
+		if hand.combo.Card1.Rank == cards.Ace {
+			want = -1
+		}
+		for j := range rs.hands[1] {
+			if rs.compatible[i][j] && rs.showdown[i][j] != want {
+				t.Errorf("%s vs %s showdown = %d, want %d", hand.combo, rs.hands[1][j].combo, rs.showdown[i][j], want)
+			}
+		}
+	}
+
+	// The vector solve and a combo tree built with the same evaluator agree
+	riverProfile, err := SolveRiverWithEvaluator(gs, range0, range1, config, lowCardEvaluator{}, 500)
+	if err != nil {
+		t.Fatalf("SolveRiverWithEvaluator() error = %v", err)
+	}
+	builder := tree.NewBuilder(config)
+	builder.SetEvaluator(lowCardEvaluator{})
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange() error = %v", err)
+	}
+	exactProfile := NewCFR().Train(root, 500)
+
+	river, exact := btnBetFrequency(riverProfile, "5"), btnBetFrequency(exactProfile, "5")
+	if math.Abs(river-exact) > 0.02 {
+		t.Errorf("BTN 55 bet frequency %.3f differs from combo-tree CFR %.3f", river, exact)
+	}
+}
+
+func TestSolveRiverWithEvaluator_Omaha(t *testing.T) {
+	// BTN holds the nut flush or a busted QQ87; BB's set of kings is a bluff-catcher
+	gs, err := notation.ParsePosition("BTN:As8d8c4s,QdQc8h7h:S100/BB:KdKcJcJh:S100|P20|2s5s9sJdKh|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	range0, range1 := gs.Players[0].Range, gs.Players[1].Range
+	config := tree.ActionConfig{BetSizes: []float64{0.75}, AllowCheck: true, AllowCall: true, AllowFold: true}
+
+	rs, err := newRiverSolver(gs, range0, range1, cards.OmahaEvaluator{})
+	if err != nil {
+		t.Fatalf("newRiverSolver() error = %v", err)
+	}
+	if rs.showdown[0][0] != 1 || rs.showdown[1][0] != -1 {
+		t.Errorf("showdowns = %d, %d; want the flush to win and QQ87 to lose", rs.showdown[0][0], rs.showdown[1][0])
+	}
+
+	// The vector solve and a combo tree built with the same evaluator agree
+	riverProfile, err := SolveRiverWithEvaluator(gs, range0, range1, config, cards.OmahaEvaluator{}, 5000)
+	if err != nil {
+		t.Fatalf("SolveRiverWithEvaluator() error = %v", err)
+	}
+	builder := tree.NewBuilder(config)
+	builder.SetEvaluator(cards.OmahaEvaluator{})
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange() error = %v", err)
+	}
+	exactProfile := NewCFR().Train(root, 5000)
+
+	if bet := btnBetFrequency(riverProfile, "As8d8c4s"); bet < 0.9 {
+		t.Errorf("nut flush bet frequency = %.3f, want about 1", bet)
+	}
+	river, exact := btnBetFrequency(riverProfile, "Qd"), btnBetFrequency(exactProfile, "Qd")
+	if math.Abs(river-exact) > 0.02 {
+		t.Errorf("QQ87 bluff frequency %.3f differs from combo-tree CFR %.3f", river, exact)
+	}
+}
+
+// forceAction makes every hand's current strategy at an info set pick one action
+func forceAction(profile *StrategyProfile, infoSet string, actions []notation.Action, pick int) {
+	regrets := make([]float64, len(actions))
+	regrets[pick] = 1
+	profile.GetOrCreate(infoSet, actions).UpdateRegrets(regrets)
+}
+
+func TestSolveRiver_ReachWeightedShowdown(t *testing.T) {
+	// BTN's top pair is a bluff-catcher: it beats BB's 33 but loses to 99
+	gs, err := notation.ParsePosition("BTN:KJs:S100/BB:99:S100|P20|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	range0, _ := notation.ParseRange("KJs")
+	range1, _ := notation.ParseRange("99,33")
+	config := tree.ActionConfig{BetSizes: []float64{0.75}, AllowCheck: true, AllowCall: true, AllowFold: true}
+
+	rs, err := newRiverSolver(gs, range0, range1, nil)
+	if err != nil {
+		t.Fatalf("newRiverSolver() error = %v", err)
+	}
+	root, err := tree.NewBuilder(config).Build(gs, rs.hands[0][0].combo, rs.hands[1][rs.firstCompatible].combo)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// BTN always bets 15 into 20; BB folds 33 and calls with 99
+	bet := notation.Action{Type: notation.Bet, Amount: 15}
+	betNode := root.Children[tree.ActionKey(bet)]
+	if betNode == nil {
+		t.Fatalf("no child for %v", bet)
+	}
+	for _, hand := range rs.hands[0] {
+		forceAction(rs.profile, tree.GetInfoSet(rs.board, nil, notation.BTN, hand.hole), root.Actions, 1)
+	}
+	strong := make([]float64, len(rs.hands[1]))
+	for j, hand := range rs.hands[1] {
+		pick := 0 // Fold
+		if hand.combo.Card1.Rank == cards.Nine {
+			pick = 1 // Call
+			strong[j] = 1
+		}
+		infoSet := tree.GetInfoSet(rs.board, []notation.Action{bet}, notation.BB, hand.hole)
+		forceAction(rs.profile, infoSet, betNode.Actions, pick)
+	}
+
+	// After the call only the nines remain, so the bluff-catcher never wins
+	win, tie, lose := rs.showdownOdds(0, 0, strong)
+	if win != 0 || tie != 0 || lose != 3 {
+		t.Errorf("showdown vs calling range = win %.0f tie %.0f lose %.0f, want 0/0/3", win, tie, lose)
+	}
+
+	var reach [2][]float64
+	for p := range reach {
+		reach[p] = make([]float64, len(rs.hands[p]))
+		for i := range reach[p] {
+			reach[p][i] = 1
+		}
+	}
+	values := rs.cfr(root, rs.history, reach)
+
+	// Six 33 combos fold (+10 each), three 99 combos call and win the 50 pot (-25 each).
+	// Treating BB's range uniformly at showdown would let the 33s pay off the bet.
+	for h, v := range values[0] {
+		if math.Abs(v-(-15)) > 1e-9 {
+			t.Errorf("BTN %s value = %.3f, want -15", rs.hands[0][h].combo, v)
+		}
+	}
+}
+
+func TestSolveRiver_BlockerRenormalizedEV(t *testing.T) {
+	// BTN bets two top-pair value combos and two queen-high bluffs; BB's
+	// ace-high catches bluffs, and the As in AsTd blocks AsKs
+	gs, err := notation.ParsePosition("BTN:AKs:S100/BB:ATo:S100|P20|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	range0, _ := notation.ParseRange("AsKs,AdKd,QsJs,QdJd")
+	range1, _ := notation.ParseRange("AsTd,AcTc")
+	config := tree.ActionConfig{BetSizes: []float64{0.75}, AllowCheck: true, AllowCall: true, AllowFold: true}
+
+	rs, err := newRiverSolver(gs, range0, range1, nil)
+	if err != nil {
+		t.Fatalf("newRiverSolver() error = %v", err)
+	}
+	root, err := tree.NewBuilder(config).Build(gs, rs.hands[0][0].combo, rs.hands[1][rs.firstCompatible].combo)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// BTN always bets 15 into 20
+	bet := notation.Action{Type: notation.Bet, Amount: 15}
+	betNode := root.Children[tree.ActionKey(bet)]
+	if betNode == nil {
+		t.Fatalf("no child for %v", bet)
+	}
+	for _, hand := range rs.hands[0] {
+		forceAction(rs.profile, tree.GetInfoSet(rs.board, nil, notation.BTN, hand.hole), root.Actions, 1)
+	}
+	rs.Iterate(root)
+
+	// A call wins or loses 25 of the 50 pot. AcTc faces 2 value and 2 bluffs
+	// (EV 0); AsTd faces 1 value and 2 bluffs (EV 25/3). The raw values are sums
+	// over four and three combos, so they only compare once renormalized.
+	want := map[string]float64{"AcTc": 0, "AsTd": 25.0 / 3}
+	for _, hand := range rs.hands[1] {
+		infoSet := tree.GetInfoSet(rs.board, []notation.Action{bet}, notation.BB, hand.hole)
+		strategy, ok := rs.profile.Get(infoSet)
+		if !ok || strategy.ActionEV == nil {
+			t.Fatalf("no action EVs for %s", infoSet)
+		}
+		for a, action := range strategy.Actions {
+			if action.Type != notation.Call {
+				continue
+			}
+			got := strategy.ActionEV[a]
+			if math.Abs(got-want[hand.combo.String()]) > 1e-9 {
+				t.Errorf("%s call EV = %.3f, want %.3f", hand.combo, got, want[hand.combo.String()])
+			}
+		}
+	}
+}
@@ -1,15 +1,19 @@
 package solver
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/behrlich/poker-solver/pkg/notation"
 )
 
 // SerializableStrategy is a JSON-friendly representation of a Strategy
 type SerializableStrategy struct {
-	InfoSet     string                  `json:"infoset"`
+	InfoSet     string                  `json:"infoset,omitempty"`
 	Actions     []SerializableAction    `json:"actions"`
 	RegretSum   []float64               `json:"regret_sum"`
 	StrategySum []float64               `json:"strategy_sum"`
@@ -21,10 +25,20 @@ type SerializableAction struct {
 	Amount float64 `json:"amount,omitempty"`
 }
 
+// profileFormatVersion is bumped when the ToJSON format changes
+const profileFormatVersion = 1
+
 // SerializableProfile is a JSON-friendly representation of a StrategyProfile
+// Strategies are keyed by info set, so their InfoSet fields are left empty.
 type SerializableProfile struct {
+	Version    int                             `json:"version"`
+	Strategies map[string]SerializableStrategy `json:"strategies"`
+}
+
+// legacyProfile is the version 0 format: a strategy list with no version, or
+// with the string version "1.0" that was written but never checked
+type legacyProfile struct {
 	Strategies []SerializableStrategy `json:"strategies"`
-	Version    string                 `json:"version"` // For future compatibility
 }
 
 // actionTypeToString converts ActionType to string for JSON
@@ -65,65 +79,148 @@ func stringToActionType(s string) notation.ActionType {
 
 // ToJSON serializes the StrategyProfile to JSON bytes
 func (sp *StrategyProfile) ToJSON() ([]byte, error) {
+	all := sp.All()
 	profile := SerializableProfile{
-		Version:    "1.0",
-		Strategies: make([]SerializableStrategy, 0, len(sp.strategies)),
-	}
-
-	for infoSet, strat := range sp.strategies {
-		// Convert actions
-		actions := make([]SerializableAction, len(strat.Actions))
-		for i, action := range strat.Actions {
-			actions[i] = SerializableAction{
-				Type:   actionTypeToString(action.Type),
-				Amount: action.Amount,
-			}
-		}
+		Version:    profileFormatVersion,
+		Strategies: make(map[string]SerializableStrategy, len(all)),
+	}
 
-		// Add strategy
-		profile.Strategies = append(profile.Strategies, SerializableStrategy{
-			InfoSet:     infoSet,
-			Actions:     actions,
-			RegretSum:   strat.RegretSum,
-			StrategySum: strat.StrategySum,
-		})
+	for infoSet, strat := range all {
+		serStrat := toSerializableStrategy(infoSet, strat)
+		serStrat.InfoSet = ""
+		profile.Strategies[infoSet] = serStrat
 	}
 
 	return json.MarshalIndent(profile, "", "  ")
 }
 
 // FromJSON deserializes JSON bytes into a StrategyProfile
+// Profiles without a numeric version are read as the legacy version 0 format.
+// Versions newer than this build understands are an error.
 func FromJSON(data []byte) (*StrategyProfile, error) {
-	var profile SerializableProfile
-	if err := json.Unmarshal(data, &profile); err != nil {
+	var header struct {
+		Version json.RawMessage `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
 		return nil, err
 	}
 
+	version := 0
+	if len(header.Version) > 0 && header.Version[0] != '"' && string(header.Version) != "null" {
+		if err := json.Unmarshal(header.Version, &version); err != nil {
+			return nil, fmt.Errorf("invalid strategy profile version %s", header.Version)
+		}
+	}
+
 	sp := NewStrategyProfile()
+	switch version {
+	case 0:
+		var legacy legacyProfile
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, err
+		}
+		for _, serStrat := range legacy.Strategies {
+			sp.strategies[serStrat.InfoSet] = fromSerializableStrategy(serStrat)
+		}
+	case profileFormatVersion:
+		var profile SerializableProfile
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return nil, err
+		}
+		for infoSet, serStrat := range profile.Strategies {
+			serStrat.InfoSet = infoSet
+			sp.strategies[infoSet] = fromSerializableStrategy(serStrat)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported strategy profile version %d", version)
+	}
 
-	for _, serStrat := range profile.Strategies {
-		// Convert actions back
-		actions := make([]notation.Action, len(serStrat.Actions))
-		for i, serAction := range serStrat.Actions {
-			actions[i] = notation.Action{
-				Type:   stringToActionType(serAction.Type),
-				Amount: serAction.Amount,
-			}
+	return sp, nil
+}
+
+// WriteJSON streams the profile as NDJSON: one SerializableStrategy object per line
+// Strategies are encoded one at a time, so large profiles aren't copied in memory
+// the way ToJSON copies them. Line order is unspecified. Read back with ReadJSONStream.
+func (sp *StrategyProfile) WriteJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	for infoSet, strat := range sp.All() {
+		if err := enc.Encode(toSerializableStrategy(infoSet, strat)); err != nil {
+			return fmt.Errorf("encoding %s: %w", infoSet, err)
 		}
+	}
 
-		// Create strategy
-		strat := NewStrategy(serStrat.InfoSet, actions)
-		strat.RegretSum = serStrat.RegretSum
-		strat.StrategySum = serStrat.StrategySum
+	return bw.Flush()
+}
 
-		sp.strategies[serStrat.InfoSet] = strat
+// ReadJSONStream reads an NDJSON profile written by WriteJSON
+func ReadJSONStream(r io.Reader) (*StrategyProfile, error) {
+	sp := NewStrategyProfile()
+	dec := json.NewDecoder(bufio.NewReader(r))
+
+	for line := 1; ; line++ {
+		var serStrat SerializableStrategy
+		err := dec.Decode(&serStrat)
+		if err == io.EOF {
+			return sp, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("strategy %d: %w", line, err)
+		}
+		sp.strategies[serStrat.InfoSet] = fromSerializableStrategy(serStrat)
 	}
+}
 
-	return sp, nil
+// toSerializableStrategy converts a strategy to its JSON form
+func toSerializableStrategy(infoSet string, strat *Strategy) SerializableStrategy {
+	actions := make([]SerializableAction, len(strat.Actions))
+	for i, action := range strat.Actions {
+		actions[i] = SerializableAction{
+			Type:   actionTypeToString(action.Type),
+			Amount: action.Amount,
+		}
+	}
+
+	return SerializableStrategy{
+		InfoSet:     infoSet,
+		Actions:     actions,
+		RegretSum:   strat.RegretSum,
+		StrategySum: strat.StrategySum,
+	}
+}
+
+// fromSerializableStrategy converts a strategy back from its JSON form
+func fromSerializableStrategy(serStrat SerializableStrategy) *Strategy {
+	actions := make([]notation.Action, len(serStrat.Actions))
+	for i, serAction := range serStrat.Actions {
+		actions[i] = notation.Action{
+			Type:   stringToActionType(serAction.Type),
+			Amount: serAction.Amount,
+		}
+	}
+
+	strat := NewStrategy(serStrat.InfoSet, actions)
+	strat.RegretSum = serStrat.RegretSum
+	strat.StrategySum = serStrat.StrategySum
+	return strat
 }
 
 // SaveToFile saves the StrategyProfile to a JSON file
+// Filenames ending in ".ndjson" are streamed with WriteJSON instead.
 func (sp *StrategyProfile) SaveToFile(filename string) error {
+	if strings.HasSuffix(filename, ".ndjson") {
+		f, err := os.Create(filename)
+		if err != nil {
+			return err
+		}
+		if err := sp.WriteJSON(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
 	data, err := sp.ToJSON()
 	if err != nil {
 		return err
@@ -133,7 +230,17 @@ func (sp *StrategyProfile) SaveToFile(filename string) error {
 }
 
 // LoadFromFile loads a StrategyProfile from a JSON file
+// Filenames ending in ".ndjson" are read with ReadJSONStream.
 func LoadFromFile(filename string) (*StrategyProfile, error) {
+	if strings.HasSuffix(filename, ".ndjson") {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ReadJSONStream(f)
+	}
+
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
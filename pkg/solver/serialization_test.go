@@ -1,11 +1,18 @@
 package solver
 
 import (
+	"bytes"
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/behrlich/poker-solver/pkg/cards"
 	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
 )
 
 func TestStrategyProfile_ToJSON(t *testing.T) {
@@ -120,6 +127,59 @@ func TestStrategyProfile_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestStrategyProfile_RoundTrip_CompactInfoSets(t *testing.T) {
+	gs, err := notation.ParsePosition("BTN:AA:S100/BB:QQ:S100|P10|Kh9s4c7d2s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	combo0 := notation.Combo{Card1: cards.NewCard(cards.Ace, cards.Spades), Card2: cards.NewCard(cards.Ace, cards.Hearts)}
+	combo1 := notation.Combo{Card1: cards.NewCard(cards.Queen, cards.Spades), Card2: cards.NewCard(cards.Queen, cards.Hearts)}
+
+	// Large sizes put bet amounts above one varint byte
+	builder := tree.NewBuilder(tree.ActionConfig{BetSizes: []float64{0.5, 2, 5}, AllowCheck: true, AllowCall: true, AllowFold: true})
+	builder.SetInfoSetFormat(tree.CompactInfoSets)
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	original := NewCFR().Train(root, 20)
+
+	data, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() error = %v", err)
+	}
+	restored, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := original.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	streamed, err := ReadJSONStream(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSONStream() error = %v", err)
+	}
+
+	for name, profile := range map[string]*StrategyProfile{"FromJSON": restored, "ReadJSONStream": streamed} {
+		if profile.NumInfoSets() != original.NumInfoSets() {
+			t.Errorf("%s: %d info sets, want %d", name, profile.NumInfoSets(), original.NumInfoSets())
+		}
+		original.ForEachSorted(func(infoSet string, want *Strategy) {
+			got, ok := profile.Get(infoSet)
+			if !ok {
+				readable, _ := tree.ReadableInfoSet(infoSet)
+				t.Errorf("%s: lost info set %s", name, readable)
+				return
+			}
+			if !reflect.DeepEqual(got.StrategySum, want.StrategySum) {
+				t.Errorf("%s: %q strategy sum %v, want %v", name, infoSet, got.StrategySum, want.StrategySum)
+			}
+		})
+	}
+}
+
 func TestStrategyProfile_SaveAndLoad(t *testing.T) {
 	// Create a temporary file
 	tmpDir := t.TempDir()
@@ -176,6 +236,110 @@ func TestStrategyProfile_SaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestNewCFRFromProfile_ResumesTraining(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	filename := filepath.Join(t.TempDir(), "kuhn.json")
+
+	if err := NewCFR().Train(root, 5000).SaveToFile(filename); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	saved, err := LoadFromFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	resumed := NewCFRFromProfile(saved).Train(root, 5000)
+
+	fresh := NewCFR().Train(root, 5000)
+	resumedExpl := CalculateExploitability(resumed, root)
+	freshExpl := CalculateExploitability(fresh, root)
+	if resumedExpl >= freshExpl {
+		t.Errorf("resumed 5000+5000 exploitability %.6f should be below fresh 5000 %.6f", resumedExpl, freshExpl)
+	}
+
+	// Resuming continues the same regrets, so it matches an uninterrupted run
+	straightExpl := CalculateExploitability(NewCFR().Train(root, 10000), root)
+	if math.Abs(resumedExpl-straightExpl) > 1e-9 {
+		t.Errorf("resumed exploitability %.9f, uninterrupted 10000 iterations %.9f", resumedExpl, straightExpl)
+	}
+}
+
+func TestStrategyProfile_WriteJSONStream(t *testing.T) {
+	original := NewStrategyProfile()
+	actionSets := [][]notation.Action{
+		{{Type: notation.Check}, {Type: notation.Bet, Amount: 5.0}},
+		{{Type: notation.Fold}, {Type: notation.Call}, {Type: notation.Raise, Amount: 15.0}},
+	}
+
+	const numInfoSets = 500
+	for i := 0; i < numInfoSets; i++ {
+		actions := actionSets[i%len(actionSets)]
+		strat := original.GetOrCreate(fmt.Sprintf("Kh9s4c|x|>BB|SYNTH_%d", i), actions)
+		for a := range actions {
+			strat.RegretSum[a] = float64(i*len(actions)+a) - 250.5
+			strat.StrategySum[a] = float64(i+a) * 0.125
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := original.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	// One JSON object per line
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != numInfoSets {
+		t.Fatalf("expected %d lines, got %d", numInfoSets, len(lines))
+	}
+
+	loaded, err := ReadJSONStream(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSONStream() error = %v", err)
+	}
+	if loaded.NumInfoSets() != numInfoSets {
+		t.Fatalf("expected %d info sets, got %d", numInfoSets, loaded.NumInfoSets())
+	}
+
+	for infoSet, want := range original.All() {
+		got, ok := loaded.Get(infoSet)
+		if !ok {
+			t.Fatalf("missing info set %s", infoSet)
+		}
+		if !reflect.DeepEqual(got.Actions, want.Actions) ||
+			!reflect.DeepEqual(got.RegretSum, want.RegretSum) ||
+			!reflect.DeepEqual(got.StrategySum, want.StrategySum) {
+			t.Errorf("%s: round trip mismatch: got %+v, want %+v", infoSet, got, want)
+		}
+	}
+
+	// .ndjson files go through the streaming path
+	filename := filepath.Join(t.TempDir(), "strategy.ndjson")
+	if err := original.SaveToFile(filename); err != nil {
+		t.Fatalf("SaveToFile(.ndjson) error = %v", err)
+	}
+	fromFile, err := LoadFromFile(filename)
+	if err != nil {
+		t.Fatalf("LoadFromFile(.ndjson) error = %v", err)
+	}
+	if fromFile.NumInfoSets() != numInfoSets {
+		t.Errorf("expected %d info sets from file, got %d", numInfoSets, fromFile.NumInfoSets())
+	}
+}
+
+func TestReadJSONStream_Invalid(t *testing.T) {
+	input := `{"infoset":"a","actions":[{"type":"check"}],"regret_sum":[0],"strategy_sum":[1]}
+{not json}
+`
+	if _, err := ReadJSONStream(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), "strategy 2") {
+		t.Errorf("expected error on strategy 2, got %v", err)
+	}
+
+	// Empty input is an empty profile
+	sp, err := ReadJSONStream(strings.NewReader(""))
+	if err != nil || sp.NumInfoSets() != 0 {
+		t.Errorf("empty stream: got %v, %v", sp, err)
+	}
+}
+
 func TestLoadFromFile_NonExistent(t *testing.T) {
 	_, err := LoadFromFile("/nonexistent/path/to/file.json")
 	if err == nil {
@@ -190,3 +354,115 @@ func TestFromJSON_InvalidJSON(t *testing.T) {
 		t.Error("Expected error when deserializing invalid JSON")
 	}
 }
+
+func TestFromJSON_Versions(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		// Version 0: a strategy list, unversioned or with the old "1.0" string
+		{"legacy unversioned", `{"strategies": [{"infoset": "a", "actions": [{"type": "check"}, {"type": "bet", "amount": 5}], "regret_sum": [1, -1], "strategy_sum": [3, 1]}]}`},
+		{"legacy 1.0", `{"version": "1.0", "strategies": [{"infoset": "a", "actions": [{"type": "check"}, {"type": "bet", "amount": 5}], "regret_sum": [1, -1], "strategy_sum": [3, 1]}]}`},
+		{"v1", `{"version": 1, "strategies": {"a": {"actions": [{"type": "check"}, {"type": "bet", "amount": 5}], "regret_sum": [1, -1], "strategy_sum": [3, 1]}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := FromJSON([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("FromJSON() failed: %v", err)
+			}
+			strat, ok := sp.Get("a")
+			if !ok || sp.NumInfoSets() != 1 {
+				t.Fatalf("expected only info set a, got %d info sets", sp.NumInfoSets())
+			}
+			want := []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 5}}
+			if !reflect.DeepEqual(strat.Actions, want) || strat.InfoSet != "a" {
+				t.Errorf("info set %q actions %v, want a and %v", strat.InfoSet, strat.Actions, want)
+			}
+			if avg := strat.GetAverageStrategy(); avg[0] != 0.75 || avg[1] != 0.25 {
+				t.Errorf("average strategy = %v, want [0.75 0.25]", avg)
+			}
+		})
+	}
+
+	// ToJSON writes the current version
+	sp, _ := FromJSON([]byte(tests[0].data))
+	data, err := sp.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"version": 1`) {
+		t.Errorf("expected version 1 in output, got:\n%s", data)
+	}
+
+	if _, err := FromJSON([]byte(`{"version": 2, "strategies": {}}`)); err == nil || !strings.Contains(err.Error(), "version 2") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestTreeCache_SolveMatches(t *testing.T) {
+	// Build a river tree, cache it to disk, and verify the reloaded
+	// tree solves to the same strategies as the original
+	board, err := cards.ParseCards("Kh9s4c7d2s")
+	if err != nil {
+		t.Fatalf("Failed to parse board: %v", err)
+	}
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board,
+		ToAct: 0,
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	builder := tree.NewBuilder(tree.DefaultRiverConfig())
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "river_tree.json")
+	if err := root.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := tree.LoadTree(path)
+	if err != nil {
+		t.Fatalf("LoadTree failed: %v", err)
+	}
+
+	original := NewCFR().Train(root, 1000)
+	reloaded := NewCFR().Train(loaded, 1000)
+
+	if original.NumInfoSets() != reloaded.NumInfoSets() {
+		t.Fatalf("info set count mismatch: %d vs %d", original.NumInfoSets(), reloaded.NumInfoSets())
+	}
+
+	for infoSet, strat := range original.All() {
+		other, ok := reloaded.Get(infoSet)
+		if !ok {
+			t.Errorf("reloaded profile missing info set %s", infoSet)
+			continue
+		}
+
+		avg := strat.GetAverageStrategy()
+		otherAvg := other.GetAverageStrategy()
+		for i := range avg {
+			if math.Abs(avg[i]-otherAvg[i]) > 1e-12 {
+				t.Errorf("%s action %d: original %.6f, reloaded %.6f", infoSet, i, avg[i], otherAvg[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/behrlich/poker-solver/pkg/abstraction"
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// DefaultSolveIterations is the iteration count Solve uses when none is given
+const DefaultSolveIterations = 10000
+
+// SolveOptions configures Solve and its steps (BuildPositionTree, NewPositionSolver)
+type SolveOptions struct {
+	// Iterations is the number of CFR/MCCFR iterations (0 = DefaultSolveIterations)
+	Iterations int
+
+	// Actions is the betting abstraction. nil uses geometric sizing when
+	// TargetPot is set and tree.DefaultRiverConfig otherwise.
+	Actions *tree.ActionConfig
+
+	// TargetPot, when Actions is nil, sizes bets geometrically to reach this
+	// pot in bb by the river, capped by the players' stacks (0 = off)
+	TargetPot float64
+
+	// NumSizes is the number of geometric bet sizes (0 = 1)
+	NumSizes int
+
+	// Bucketer, if set, abstracts hands into buckets in info set keys
+	Bucketer abstraction.HandBucketer
+
+	// Buckets, when Bucketer is nil, buckets hands by equity against the
+	// range of the player not to act (0 = no card abstraction)
+	Buckets int
+
+	// Evaluator scores showdowns and rollouts, and the Buckets bucketer's
+	// equities (nil = cards.DefaultEvaluator)
+	Evaluator cards.Evaluator
+
+	// Threads is the number of goroutines for building the tree and solving
+	// (<= 1 solves serially). A solve repeats exactly for a given thread count.
+	Threads int
+
+	// Seed seeds MCCFR's sampling
+	Seed int64
+
+	// SampleRiverRanges solves range-vs-range river trees with MCCFR instead of
+	// CFR: far less work per iteration, at the cost of sampling noise
+	SampleRiverRanges bool
+
+	// MaxTreeNodes refuses trees larger than this before solving (0 = no limit)
+	MaxTreeNodes int
+
+	// Train adds cancellation and progress reporting to training
+	Train TrainOptions
+}
+
+// Solve parses a position, builds its game tree, and trains the solver suited
+// to its street, returning the average strategies. It is the whole pipeline
+// the CLI runs: BuildPositionTree, then NewPositionSolver's Train.
+func Solve(positionStr string, opts SolveOptions) (*StrategyProfile, error) {
+	gs, err := notation.ParsePositionWithOptions(positionStr, notation.ParseOptions{RemoveBlockers: true, StrictHistory: true, LooseBoard: true})
+	if err != nil {
+		return nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	root, err := BuildPositionTree(gs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewPositionSolver(gs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := opts.Iterations
+	if iterations <= 0 {
+		iterations = DefaultSolveIterations
+	}
+	return s.Train(root, iterations, opts.Train), nil
+}
+
+// BuildPositionTree builds gs's game tree as Solve does: a combo-vs-combo tree,
+// or a range tree when either player holds more than one combo
+func BuildPositionTree(gs *notation.GameState, opts SolveOptions) (*tree.TreeNode, error) {
+	if len(gs.Players) != 2 {
+		return nil, fmt.Errorf("only 2-player games supported")
+	}
+
+	config, err := positionActions(gs, opts)
+	if err != nil {
+		return nil, err
+	}
+	builder := tree.NewBuilder(config)
+	builder.SetWorkers(opts.Threads)
+	builder.SetEvaluator(opts.Evaluator)
+
+	if opts.Bucketer != nil {
+		builder.SetBucketer(opts.Bucketer)
+	} else if opts.Buckets > 0 {
+		oppRange := gs.Players[1-gs.ToAct].Range
+		bucketer := abstraction.NewBucketer(gs.Board, oppRange, opts.Buckets)
+		bucketer.SetEvaluator(opts.Evaluator)
+		builder.SetBucketer(bucketer)
+	}
+
+	var root *tree.TreeNode
+	if isRangeVsRange(gs) {
+		root, err = builder.BuildWeightedRange(gs, gs.Players[0].Range, gs.Players[0].Weights, gs.Players[1].Range, gs.Players[1].Weights)
+	} else {
+		root, err = builder.Build(gs, gs.Players[0].Range[0], gs.Players[1].Range[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tree build error: %w", err)
+	}
+
+	if opts.MaxTreeNodes > 0 {
+		if stats := root.Stats(); stats.TotalNodes() > opts.MaxTreeNodes {
+			return nil, fmt.Errorf("tree too large: %s (limit %d nodes)", stats, opts.MaxTreeNodes)
+		}
+	}
+	return root, nil
+}
+
+// NewPositionSolver returns the solver Solve trains gs's tree with
+// Preflop, flop, and turn trees end in rollouts, so they get MCCFR (seeded with
+// opts.Seed); river trees get vanilla CFR unless opts.SampleRiverRanges picks
+// MCCFR for a range tree. Threads above 1 are given to the solver.
+func NewPositionSolver(gs *notation.GameState, opts SolveOptions) (Solver, error) {
+	mccfr := func() Solver {
+		m := NewMCCFR(opts.Seed)
+		if opts.Threads > 1 {
+			m.SetWorkers(opts.Threads)
+		}
+		return m
+	}
+
+	switch len(gs.Board) {
+	case 0, 3, 4:
+		return mccfr(), nil
+	case 5:
+		if opts.SampleRiverRanges && isRangeVsRange(gs) {
+			return mccfr(), nil
+		}
+		c := NewCFR()
+		if opts.Threads > 1 {
+			c.SetWorkers(opts.Threads)
+		}
+		return c, nil
+	default:
+		return nil, fmt.Errorf("unsupported street (board has %d cards)", len(gs.Board))
+	}
+}
+
+// positionActions returns opts.Actions, or the sizing Solve picks when it is nil
+func positionActions(gs *notation.GameState, opts SolveOptions) (tree.ActionConfig, error) {
+	if opts.Actions != nil {
+		return *opts.Actions, nil
+	}
+	if opts.TargetPot <= 0 {
+		return tree.DefaultRiverConfig(), nil
+	}
+
+	numStreets := 0
+	switch len(gs.Board) {
+	case 3:
+		numStreets = 3 // flop, turn, river
+	case 4:
+		numStreets = 2 // turn, river
+	case 5:
+		numStreets = 1 // river only
+	default:
+		return tree.ActionConfig{}, fmt.Errorf("geometric sizing needs a flop, turn, or river board, got %d cards", len(gs.Board))
+	}
+
+	// Street commitments are in action order; the opener is the player to act
+	// after an even number of actions
+	committed := tree.StreetCommitments(gs.ActionHistory)
+	opener := gs.ToAct
+	if len(gs.ActionHistory)%2 == 1 {
+		opener = 1 - gs.ToAct
+	}
+	var invested [2]float64
+	invested[opener] = committed[0]
+	invested[1-opener] = committed[1]
+
+	numSizes := opts.NumSizes
+	if numSizes <= 0 {
+		numSizes = 1
+	}
+	stacks := [2]float64{gs.Players[0].Stack, gs.Players[1].Stack}
+	return tree.ActionConfig{
+		GeometricSizing:   tree.NewGeometricSizingForStacks(opts.TargetPot, numStreets, gs.Pot, stacks, invested),
+		NumGeometricSizes: numSizes,
+		AllowCheck:        true,
+		AllowCall:         true,
+		AllowFold:         true,
+	}, nil
+}
+
+// isRangeVsRange reports whether either player holds more than one combo
+func isRangeVsRange(gs *notation.GameState) bool {
+	return len(gs.Players[0].Range) > 1 || len(gs.Players[1].Range) > 1
+}
@@ -0,0 +1,60 @@
+package solver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// TestSolve_River checks that Solve returns distributions for every info set the
+// step-by-step parse, build, and CFR pipeline finds
+func TestSolve_River(t *testing.T) {
+	position := "BTN:AA,KK,AKs:S100/BB:QQ,JJ,AQs:S100|P10|Kh9s4c7d2s|>BTN"
+
+	profile, err := Solve(position, SolveOptions{Iterations: 100})
+	if err != nil {
+		t.Fatalf("Solve() failed: %v", err)
+	}
+
+	gs, err := notation.ParsePositionWithOptions(position, notation.ParseOptions{RemoveBlockers: true})
+	if err != nil {
+		t.Fatalf("ParsePositionWithOptions() failed: %v", err)
+	}
+	root, err := tree.NewBuilder(tree.DefaultRiverConfig()).BuildRange(gs, gs.Players[0].Range, gs.Players[1].Range)
+	if err != nil {
+		t.Fatalf("BuildRange() failed: %v", err)
+	}
+	manual := NewCFR().Train(root, 100)
+
+	if profile.NumInfoSets() == 0 || profile.NumInfoSets() != manual.NumInfoSets() {
+		t.Fatalf("Solve found %d info sets, manual pipeline %d", profile.NumInfoSets(), manual.NumInfoSets())
+	}
+	for infoSet, s := range profile.All() {
+		if _, ok := manual.Get(infoSet); !ok {
+			t.Errorf("%s missing from the manual pipeline", infoSet)
+		}
+		sum := 0.0
+		for _, p := range s.GetAverageStrategy() {
+			if p < 0 {
+				t.Errorf("%s: negative probability %v", infoSet, p)
+			}
+			sum += p
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s: strategy sums to %v", infoSet, sum)
+		}
+	}
+}
+
+func TestSolve_Errors(t *testing.T) {
+	if _, err := Solve("not a position", SolveOptions{}); err == nil {
+		t.Error("expected a parse error")
+	}
+
+	_, err := Solve("BTN:AA,KK:S100/BB:QQ,JJ:S100|P10|Kh9s4c7d2s|>BTN", SolveOptions{MaxTreeNodes: 10})
+	if err == nil {
+		t.Error("expected a tree size error")
+	}
+}
@@ -0,0 +1,218 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+// Solver is the common interface implemented by CFR, DCFR, and MCCFR
+type Solver interface {
+	// Train runs the given number of iterations and returns the strategy profile
+	Train(root *tree.TreeNode, iterations int, opts ...TrainOptions) *StrategyProfile
+
+	// Iterate runs a single iteration (useful for progress reporting)
+	Iterate(root *tree.TreeNode)
+
+	// GetProfile returns the current strategy profile
+	GetProfile() *StrategyProfile
+}
+
+var (
+	_ Solver = (*CFR)(nil)
+	_ Solver = (*MCCFR)(nil)
+)
+
+// TrainOptions adds cancellation and progress reporting to Train
+type TrainOptions struct {
+	// Context stops training early when cancelled (nil = never cancelled)
+	// The profile trained so far is returned.
+	Context context.Context
+
+	// Progress is called with (done, total) every ProgressEvery iterations
+	// and once more when training finishes or is cancelled
+	Progress func(done, total int)
+
+	// ProgressEvery is the reporting interval (0 = every 5% of total)
+	ProgressEvery int
+
+	// OnConvergence is called with the current exploitability every
+	// ConvergenceEvery iterations and once more when training stops.
+	// Each point costs a full best-response pass over the tree.
+	OnConvergence func(point ConvergencePoint)
+
+	// ConvergenceEvery is the exploitability sampling interval (0 = every 5% of total)
+	ConvergenceEvery int
+
+	// Logf receives warnings about the run, such as an MCCFR solve with too few
+	// iterations for the tree (see Coverage). nil discards them.
+	Logf func(format string, args ...any)
+}
+
+// MinIterationsPerInfoSet is the sampled-iteration budget per info set below which
+// a Coverage is considered undertrained. It is a rule of thumb, not a guarantee.
+const MinIterationsPerInfoSet = 10
+
+// Coverage is a rough measure of how thoroughly a sampling solver explored a tree
+type Coverage struct {
+	Iterations      int // Iterations run so far
+	TreeInfoSets    int // Distinct info sets in the tree
+	VisitedInfoSets int // Info sets the profile has a strategy for
+
+	// IterationsPerInfoSet is Iterations / TreeInfoSets
+	IterationsPerInfoSet float64
+}
+
+// MeasureCoverage compares the iterations run and info sets visited against the tree size
+func MeasureCoverage(root *tree.TreeNode, profile *StrategyProfile, iterations int) Coverage {
+	c := Coverage{
+		Iterations:      iterations,
+		TreeInfoSets:    root.Stats().InfoSets,
+		VisitedInfoSets: profile.NumInfoSets(),
+	}
+	if c.TreeInfoSets > 0 {
+		c.IterationsPerInfoSet = float64(iterations) / float64(c.TreeInfoSets)
+	}
+	return c
+}
+
+// VisitedFraction returns the share of the tree's info sets that were visited
+func (c Coverage) VisitedFraction() float64 {
+	if c.TreeInfoSets == 0 {
+		return 1
+	}
+	return float64(c.VisitedInfoSets) / float64(c.TreeInfoSets)
+}
+
+// Undertrained reports whether the run had fewer than MinIterationsPerInfoSet
+// iterations per info set, so many strategies are barely past uniform
+func (c Coverage) Undertrained() bool {
+	return c.TreeInfoSets > 0 && c.IterationsPerInfoSet < MinIterationsPerInfoSet
+}
+
+// String summarizes the coverage, e.g. "200 iterations over 1234 info sets (0.2 per info set, 45% visited)"
+func (c Coverage) String() string {
+	return fmt.Sprintf("%d iterations over %d info sets (%.1f per info set, %.0f%% visited)",
+		c.Iterations, c.TreeInfoSets, c.IterationsPerInfoSet, 100*c.VisitedFraction())
+}
+
+// ConvergencePoint is one sample of a convergence curve
+type ConvergencePoint struct {
+	Iteration      int
+	Exploitability float64
+}
+
+// TrainWithConvergence trains s and records exploitability every `every` iterations
+// Returns the profile and the convergence curve, ending at the final iteration.
+func TrainWithConvergence(s Solver, root *tree.TreeNode, iterations int, every int, opts ...TrainOptions) (*StrategyProfile, []ConvergencePoint) {
+	var opt TrainOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	var curve []ConvergencePoint
+	opt.ConvergenceEvery = every
+	opt.OnConvergence = func(point ConvergencePoint) {
+		curve = append(curve, point)
+	}
+
+	profile := s.Train(root, iterations, opt)
+	return profile, curve
+}
+
+// runTraining runs up to iterations of s, honoring the first TrainOptions if given
+// Returns the number of iterations actually run.
+func runTraining(s Solver, root *tree.TreeNode, iterations int, opts []TrainOptions) int {
+	var opt TrainOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	every := reportInterval(opt.ProgressEvery, iterations)
+	convergenceEvery := reportInterval(opt.ConvergenceEvery, iterations)
+	recordConvergence := func(done int) {
+		opt.OnConvergence(ConvergencePoint{
+			Iteration:      done,
+			Exploitability: CalculateExploitability(s.GetProfile(), root),
+		})
+	}
+
+	done := 0
+	for done < iterations {
+		if opt.Context != nil && opt.Context.Err() != nil {
+			break
+		}
+
+		s.Iterate(root)
+		done++
+
+		if opt.OnConvergence != nil && done%convergenceEvery == 0 && done < iterations {
+			recordConvergence(done)
+		}
+		if opt.Progress != nil && done%every == 0 && done < iterations {
+			opt.Progress(done, iterations)
+		}
+	}
+
+	if opt.OnConvergence != nil {
+		recordConvergence(done)
+	}
+	if opt.Progress != nil {
+		opt.Progress(done, iterations)
+	}
+
+	return done
+}
+
+// reportInterval returns every, defaulting to 5% of iterations (at least 1)
+func reportInterval(every, iterations int) int {
+	if every > 0 {
+		return every
+	}
+	every = iterations / 20
+	if every < 1 {
+		every = 1
+	}
+	return every
+}
+
+// trainUntil runs iterations until exploitability drops to target or maxIters is reached
+// Exploitability is checked every checkEvery iterations (every iteration if checkEvery <= 0).
+// Returns the profile and the number of iterations actually run.
+func trainUntil(s Solver, root *tree.TreeNode, maxIters int, targetExploitability float64, checkEvery int) (*StrategyProfile, int) {
+	if checkEvery <= 0 {
+		checkEvery = 1
+	}
+
+	iterations := 0
+	for iterations < maxIters {
+		s.Iterate(root)
+		iterations++
+
+		if iterations%checkEvery == 0 && CalculateExploitability(s.GetProfile(), root) <= targetExploitability {
+			break
+		}
+	}
+
+	return s.GetProfile(), iterations
+}
+
+// trainFor iterates until d has elapsed or maxIters is reached (maxIters <= 0 = no cap)
+// At least one iteration always runs. Returns the profile and the iterations completed.
+func trainFor(s Solver, root *tree.TreeNode, d time.Duration, maxIters int) (*StrategyProfile, int) {
+	deadline := time.Now().Add(d)
+
+	iterations := 0
+	for {
+		s.Iterate(root)
+		iterations++
+
+		if (maxIters > 0 && iterations >= maxIters) || !time.Now().Before(deadline) {
+			break
+		}
+	}
+
+	return s.GetProfile(), iterations
+}
@@ -0,0 +1,207 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+	"github.com/behrlich/poker-solver/pkg/tree"
+)
+
+func TestTrain_CancelReturnsPartialProfile(t *testing.T) {
+	solvers := map[string]Solver{
+		"CFR":   NewCFR(),
+		"MCCFR": NewMCCFR(42),
+	}
+
+	for name, s := range solvers {
+		t.Run(name, func(t *testing.T) {
+			root := BuildKuhnPokerTree()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Cancel from the progress callback partway through
+			lastDone := 0
+			opts := TrainOptions{
+				Context:       ctx,
+				ProgressEvery: 10,
+				Progress: func(done, total int) {
+					lastDone = done
+					if done >= 50 {
+						cancel()
+					}
+				},
+			}
+
+			profile := s.Train(root, 10000, opts)
+
+			if lastDone != 50 {
+				t.Errorf("expected training to stop after 50 iterations, stopped at %d", lastDone)
+			}
+			if profile.NumInfoSets() == 0 {
+				t.Fatal("expected a partial profile with info sets")
+			}
+
+			for infoSet, strat := range profile.All() {
+				sum := 0.0
+				for _, p := range strat.GetAverageStrategy() {
+					if p < 0 || math.IsNaN(p) {
+						t.Errorf("%s: invalid probability %v", infoSet, p)
+					}
+					sum += p
+				}
+				if math.Abs(sum-1) > 1e-9 {
+					t.Errorf("%s: strategy sums to %v", infoSet, sum)
+				}
+			}
+		})
+	}
+}
+
+func TestTrain_Progress(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	var reports []int
+	NewCFR().Train(root, 100, TrainOptions{
+		Progress: func(done, total int) {
+			if total != 100 {
+				t.Errorf("expected total 100, got %d", total)
+			}
+			reports = append(reports, done)
+		},
+	})
+
+	// Default interval is 5% of the total, with a final report at completion
+	if len(reports) != 20 {
+		t.Fatalf("expected 20 progress reports, got %d: %v", len(reports), reports)
+	}
+	if reports[0] != 5 || reports[len(reports)-1] != 100 {
+		t.Errorf("unexpected progress reports %v", reports)
+	}
+}
+
+func TestTrain_PreCancelledContext(t *testing.T) {
+	root := BuildKuhnPokerTree()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	profile := NewCFR().Train(root, 1000, TrainOptions{Context: ctx})
+	if profile.NumInfoSets() != 0 {
+		t.Errorf("expected no training with a cancelled context, got %d info sets", profile.NumInfoSets())
+	}
+}
+
+func TestTrainWithConvergence(t *testing.T) {
+	root := BuildKuhnPokerTree()
+
+	// DCFR stands in for CFR+ (regret discounting with quadratic averaging)
+	dcfr := NewDCFR(DefaultDCFRAlpha, DefaultDCFRBeta, DefaultDCFRGamma)
+	profile, curve := TrainWithConvergence(dcfr, root, 500, 50)
+
+	if len(curve) != 10 {
+		t.Fatalf("expected 10 convergence points, got %d: %v", len(curve), curve)
+	}
+	if curve[0].Iteration != 50 || curve[len(curve)-1].Iteration != 500 {
+		t.Errorf("unexpected iterations in curve %v", curve)
+	}
+	if final := CalculateExploitability(profile, root); math.Abs(curve[len(curve)-1].Exploitability-final) > 1e-9 {
+		t.Errorf("last point %.6f doesn't match final exploitability %.6f", curve[len(curve)-1].Exploitability, final)
+	}
+
+	// Roughly non-increasing: no point rises noticeably above an earlier one
+	const tolerance = 0.01
+	best := curve[0].Exploitability
+	for _, point := range curve[1:] {
+		t.Logf("iteration %d: exploitability %.6f", point.Iteration, point.Exploitability)
+		if point.Exploitability > best+tolerance {
+			t.Errorf("exploitability rose to %.6f at iteration %d (previous best %.6f)", point.Exploitability, point.Iteration, best)
+		}
+		best = math.Min(best, point.Exploitability)
+	}
+
+	// Train with OnConvergence samples every ConvergenceEvery iterations plus the end
+	var points int
+	NewCFR().Train(root, 10, TrainOptions{OnConvergence: func(ConvergencePoint) { points++ }, ConvergenceEvery: 5})
+	if points != 2 {
+		t.Errorf("expected 2 convergence callbacks, got %d", points)
+	}
+}
+
+func TestTrainFor(t *testing.T) {
+	solvers := map[string]interface {
+		TrainFor(root *tree.TreeNode, d time.Duration) (*StrategyProfile, int)
+	}{
+		"CFR":   NewCFR(),
+		"MCCFR": NewMCCFR(42),
+	}
+
+	for name, s := range solvers {
+		t.Run(name, func(t *testing.T) {
+			root := BuildKuhnPokerTree()
+
+			start := time.Now()
+			profile, iterations := s.TrainFor(root, 50*time.Millisecond)
+			elapsed := time.Since(start)
+
+			if elapsed > time.Second {
+				t.Errorf("TrainFor(50ms) took %v", elapsed)
+			}
+			if iterations <= 0 {
+				t.Errorf("TrainFor ran %d iterations, want a positive count", iterations)
+			}
+			if profile.NumInfoSets() == 0 {
+				t.Error("expected a non-empty profile")
+			}
+		})
+	}
+}
+
+func TestMCCFR_CoverageWarnsWhenUndertrained(t *testing.T) {
+	// 12 × 12 combos on the flop: too many info sets for 200 iterations to cover
+	gs, err := notation.ParsePosition("BTN:AA:S100/BB:QQ:S100|P10|Kh9s4c|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	range0, _ := notation.ParseRange("AA,KK")
+	range1, _ := notation.ParseRange("QQ,JJ")
+	config := tree.ActionConfig{BetSizes: []float64{0.5}, AllowCheck: true, AllowCall: true, AllowFold: true}
+	root, err := tree.NewBuilder(config).BuildRange(gs, notation.RemoveBlockers(range0, gs.Board), notation.RemoveBlockers(range1, gs.Board))
+	if err != nil {
+		t.Fatalf("BuildRange() error = %v", err)
+	}
+
+	var warnings []string
+	opts := TrainOptions{Logf: func(format string, args ...any) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}}
+
+	m := NewMCCFR(42)
+	m.Train(root, 200, opts)
+	cov := m.Coverage()
+	t.Logf("coverage: %s", cov)
+
+	if cov.Iterations != 200 || cov.TreeInfoSets == 0 {
+		t.Fatalf("coverage not reported: %+v", cov)
+	}
+	if !cov.Undertrained() {
+		t.Errorf("expected low coverage for 200 iterations, got %s", cov)
+	}
+	if cov.VisitedInfoSets > cov.TreeInfoSets {
+		t.Errorf("visited %d of %d info sets", cov.VisitedInfoSets, cov.TreeInfoSets)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "undertrained") {
+		t.Errorf("expected one undertrained warning, got %q", warnings)
+	}
+
+	// Kuhn's 4 info sets are well covered by 1000 iterations: no warning
+	warnings = nil
+	kuhn := NewMCCFR(42)
+	kuhn.Train(BuildKuhnPokerTree(), 1000, opts)
+	if kuhn.Coverage().Undertrained() || len(warnings) != 0 {
+		t.Errorf("Kuhn coverage %s flagged, warnings %q", kuhn.Coverage(), warnings)
+	}
+}
@@ -3,6 +3,7 @@ package solver
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/behrlich/poker-solver/pkg/notation"
 )
@@ -15,6 +16,25 @@ type Strategy struct {
 	// CFR algorithm state
 	RegretSum   []float64 // Cumulative regret for each action
 	StrategySum []float64 // Cumulative strategy (for averaging)
+
+	// Value of each action to the acting player over the solver's latest iteration
+	// (nil until recorded): in range trees the info set is visited once per opponent
+	// combo, and the visits are averaged weighted by the opponent's reach. Close
+	// values mark near-indifferent choices.
+	ActionEV []float64
+
+	// StrategySum as it stood before the current iteration's first update, for
+	// InfoSetDiagnostics; iteration is the profile iteration that took it and
+	// updates counts the iterations that updated this info set
+	prevStrategySum []float64
+	iteration       int
+	updates         int
+
+	// evSum and evWeight accumulate evIteration's reach-weighted action values
+	// for ActionEV
+	evSum       []float64
+	evWeight    float64
+	evIteration int
 }
 
 // NewStrategy creates a new strategy for an information set
@@ -31,60 +51,88 @@ func NewStrategy(infoSet string, actions []notation.Action) *Strategy {
 // GetStrategy computes the current strategy using regret matching
 // Returns probability distribution over actions
 func (s *Strategy) GetStrategy() []float64 {
-	n := len(s.Actions)
-	strategy := make([]float64, n)
-
-	// Sum positive regrets
-	normalizingSum := 0.0
-	for i := 0; i < n; i++ {
+	// Only positive regrets get weight; with none, normalize plays uniformly
+	strategy := make([]float64, len(s.Actions))
+	for i := range strategy {
 		if s.RegretSum[i] > 0 {
 			strategy[i] = s.RegretSum[i]
-			normalizingSum += s.RegretSum[i]
-		}
-	}
-
-	// Normalize to probability distribution
-	if normalizingSum > 0 {
-		for i := 0; i < n; i++ {
-			strategy[i] /= normalizingSum
-		}
-	} else {
-		// If no positive regrets, use uniform distribution
-		uniform := 1.0 / float64(n)
-		for i := 0; i < n; i++ {
-			strategy[i] = uniform
 		}
 	}
-
-	return strategy
+	return normalizeDistribution(strategy)
 }
 
 // GetAverageStrategy returns the average strategy over all iterations
 // This converges to the Nash equilibrium
 func (s *Strategy) GetAverageStrategy() []float64 {
-	n := len(s.Actions)
-	avgStrategy := make([]float64, n)
+	avgStrategy := make([]float64, len(s.Actions))
+	copy(avgStrategy, s.StrategySum)
+	return normalizeDistribution(avgStrategy)
+}
 
-	// Sum all strategy values
-	normalizingSum := 0.0
-	for i := 0; i < n; i++ {
-		normalizingSum += s.StrategySum[i]
+// normalizeDistribution scales weights in place into a probability distribution
+// The result is never negative and sums to exactly 1, whatever float error or
+// corrupt input went in: NaN and negative weights count as zero, infinite
+// weights share all the probability, and if nothing is left every entry gets
+// an equal share. Any rounding residue goes to the largest entry.
+func normalizeDistribution(weights []float64) []float64 {
+	n := len(weights)
+	if n == 0 {
+		return weights
 	}
 
-	// Normalize
-	if normalizingSum > 0 {
-		for i := 0; i < n; i++ {
-			avgStrategy[i] = s.StrategySum[i] / normalizingSum
+	infinite := 0
+	for i, w := range weights {
+		switch {
+		case math.IsNaN(w) || w < 0:
+			weights[i] = 0
+		case math.IsInf(w, 1):
+			infinite++
 		}
-	} else {
-		// If no data, return uniform
-		uniform := 1.0 / float64(n)
-		for i := 0; i < n; i++ {
-			avgStrategy[i] = uniform
+	}
+	if infinite > 0 {
+		for i, w := range weights {
+			weights[i] = 0
+			if math.IsInf(w, 1) {
+				weights[i] = 1
+			}
 		}
 	}
 
-	return avgStrategy
+	largest := 0
+	for i, w := range weights {
+		if w > weights[largest] {
+			largest = i
+		}
+	}
+
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	if math.IsInf(sum, 1) {
+		// Finite weights too big to add up: scale them down first
+		max := weights[largest]
+		sum = 0
+		for i := range weights {
+			weights[i] /= max
+			sum += weights[i]
+		}
+	}
+	if sum <= 0 {
+		for i := range weights {
+			weights[i] = 1.0 / float64(n)
+		}
+	} else {
+		for i := range weights {
+			weights[i] /= sum
+		}
+	}
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	weights[largest] = math.Max(0, weights[largest]+(1-total))
+	return weights
 }
 
 // UpdateRegrets adds regrets for each action
@@ -102,11 +150,63 @@ func (s *Strategy) UpdateStrategy(strategy []float64, reachProb float64) {
 	}
 }
 
+// beginIteration snapshots StrategySum on the info set's first update in a
+// profile iteration (see StrategyProfile.nextIteration), so later visits in the
+// same iteration don't move the diagnostics' baseline
+func (s *Strategy) beginIteration(iteration int) {
+	if s.iteration == iteration {
+		return
+	}
+	if s.prevStrategySum == nil {
+		s.prevStrategySum = make([]float64, len(s.Actions))
+	}
+	copy(s.prevStrategySum, s.StrategySum)
+	s.iteration = iteration
+	s.updates++
+}
+
+// SetActionEV records the latest per-action values for the acting player
+func (s *Strategy) SetActionEV(values []float64) {
+	if s.ActionEV == nil {
+		s.ActionEV = make([]float64, len(s.Actions))
+	}
+	copy(s.ActionEV, values)
+}
+
+// addActionEV adds one visit's action values, weighted by the opponent's reach,
+// to iteration's average and records the average as ActionEV
+// The first visit of a new iteration starts a fresh average.
+func (s *Strategy) addActionEV(iteration int, values []float64, weight float64) {
+	if s.evSum == nil || s.evIteration != iteration {
+		s.evSum = make([]float64, len(s.Actions))
+		s.evWeight = 0
+		s.evIteration = iteration
+	}
+	if weight <= 0 {
+		return
+	}
+
+	s.evWeight += weight
+	for i, v := range values {
+		s.evSum[i] += weight * v
+	}
+	average := make([]float64, len(s.Actions))
+	for i := range average {
+		average[i] = s.evSum[i] / s.evWeight
+	}
+	s.SetActionEV(average)
+}
+
 // String returns a human-readable representation
 func (s *Strategy) String() string {
 	avgStrat := s.GetAverageStrategy()
 	result := fmt.Sprintf("InfoSet: %s\n", s.InfoSet)
 	for i, action := range s.Actions {
+		if s.ActionEV != nil {
+			result += fmt.Sprintf("  %s: %.1f%% (regret: %.2f, EV: %.2f)\n",
+				action.String(), avgStrat[i]*100, s.RegretSum[i], s.ActionEV[i])
+			continue
+		}
 		result += fmt.Sprintf("  %s: %.1f%% (regret: %.2f)\n",
 			action.String(), avgStrat[i]*100, s.RegretSum[i])
 	}
@@ -114,8 +214,16 @@ func (s *Strategy) String() string {
 }
 
 // StrategyProfile stores strategies for all information sets
+// A plain profile is for one goroutine at a time. A concurrent profile (see
+// NewConcurrentStrategyProfile) keeps its strategies in locked shards instead.
 type StrategyProfile struct {
 	strategies map[string]*Strategy
+
+	// shards replaces strategies in a concurrent profile (nil otherwise)
+	shards []profileShard
+
+	// iterations counts solver iterations run on the profile (see nextIteration)
+	iterations int
 }
 
 // NewStrategyProfile creates a new strategy profile
@@ -125,8 +233,19 @@ func NewStrategyProfile() *StrategyProfile {
 	}
 }
 
+// nextIteration starts a solver iteration and returns its number
+// Solvers call it once per iteration, before any update, and pass the number
+// to beginIteration; numbers keep rising across solvers sharing the profile.
+func (sp *StrategyProfile) nextIteration() int {
+	sp.iterations++
+	return sp.iterations
+}
+
 // GetOrCreate gets an existing strategy or creates a new one
 func (sp *StrategyProfile) GetOrCreate(infoSet string, actions []notation.Action) *Strategy {
+	if sp.shards != nil {
+		return sp.shard(infoSet).getOrCreate(infoSet, actions)
+	}
 	if s, exists := sp.strategies[infoSet]; exists {
 		return s
 	}
@@ -138,24 +257,125 @@ func (sp *StrategyProfile) GetOrCreate(infoSet string, actions []notation.Action
 
 // Get retrieves a strategy by infoset key
 func (sp *StrategyProfile) Get(infoSet string) (*Strategy, bool) {
+	if sp.shards != nil {
+		return sp.shard(infoSet).get(infoSet)
+	}
 	s, exists := sp.strategies[infoSet]
 	return s, exists
 }
 
 // All returns all strategies
+// A concurrent profile returns a snapshot copy taken with every shard locked;
+// a plain profile returns its own map.
 func (sp *StrategyProfile) All() map[string]*Strategy {
+	if sp.shards != nil {
+		return sp.snapshot()
+	}
 	return sp.strategies
 }
 
+// Diagnostics is a snapshot of one info set's solver state, for debugging solves
+type Diagnostics struct {
+	Current         []float64 // Regret-matching strategy the next iteration will play
+	Average         []float64 // Average strategy (the solver's output)
+	PreviousAverage []float64 // Average strategy before the last update (nil until updated)
+
+	// AverageChange is the L1 distance between PreviousAverage and Average.
+	// It shrinks toward 0 as the info set converges; a value that stays large
+	// points at an info set the solver is still moving.
+	AverageChange float64
+
+	Updates int // Iterations that updated the info set since the profile was created or loaded
+}
+
+// InfoSetDiagnostics returns the current, average, and previous average strategies
+// for an info set. The previous average is the one before the last iteration
+// that updated the info set, however many times chance nodes led there in it.
+func (sp *StrategyProfile) InfoSetDiagnostics(infoSet string) (Diagnostics, bool) {
+	s, exists := sp.Get(infoSet)
+	if !exists {
+		return Diagnostics{}, false
+	}
+
+	d := Diagnostics{
+		Current: s.GetStrategy(),
+		Average: s.GetAverageStrategy(),
+		Updates: s.updates,
+	}
+	if s.prevStrategySum != nil {
+		prev := &Strategy{Actions: s.Actions, StrategySum: s.prevStrategySum}
+		d.PreviousAverage = prev.GetAverageStrategy()
+		for i := range d.Average {
+			d.AverageChange += math.Abs(d.Average[i] - d.PreviousAverage[i])
+		}
+	}
+	return d, true
+}
+
+// SortedInfoSets returns all info set keys in lexicographic order
+// Use it instead of ranging over All() when output must be reproducible.
+func (sp *StrategyProfile) SortedInfoSets() []string {
+	all := sp.All()
+	keys := make([]string, 0, len(all))
+	for infoSet := range all {
+		keys = append(keys, infoSet)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ForEachSorted calls fn for every strategy in info set key order
+func (sp *StrategyProfile) ForEachSorted(fn func(infoSet string, strategy *Strategy)) {
+	all := sp.All()
+	for _, infoSet := range sp.SortedInfoSets() {
+		fn(infoSet, all[infoSet])
+	}
+}
+
 // NumInfoSets returns the number of information sets
 func (sp *StrategyProfile) NumInfoSets() int {
+	if sp.shards != nil {
+		return sp.numSharded()
+	}
 	return len(sp.strategies)
 }
 
+// Merge adds other's cumulative regrets and strategy sums into sp
+// Info sets only in other are copied in. Merging profiles trained separately on
+// the same tree (e.g., on different machines with different seeds) gives
+// average strategies close to one run of their combined iterations. Both
+// profiles must come from the same tree: if an info set's action count differs
+// between them, Merge returns an error and leaves sp unchanged. sp must not be
+// in use by a solver while merging.
+func (sp *StrategyProfile) Merge(other *StrategyProfile) error {
+	var err error
+	other.ForEachSorted(func(infoSet string, src *Strategy) {
+		if dst, ok := sp.Get(infoSet); ok && err == nil && len(dst.Actions) != len(src.Actions) {
+			err = fmt.Errorf("merge %q: %d actions vs %d", infoSet, len(dst.Actions), len(src.Actions))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	other.ForEachSorted(func(infoSet string, src *Strategy) {
+		dst := sp.GetOrCreate(infoSet, src.Actions)
+		for i := range src.Actions {
+			dst.RegretSum[i] += src.RegretSum[i]
+			dst.StrategySum[i] += src.StrategySum[i]
+		}
+		dst.updates += src.updates
+		if dst.ActionEV == nil && src.ActionEV != nil {
+			dst.ActionEV = append([]float64(nil), src.ActionEV...)
+		}
+	})
+	return nil
+}
+
 // GetAverageStrategies returns the average strategy for all infosets
 func (sp *StrategyProfile) GetAverageStrategies() map[string][]float64 {
 	result := make(map[string][]float64)
-	for infoSet, strat := range sp.strategies {
+	for infoSet, strat := range sp.All() {
 		result[infoSet] = strat.GetAverageStrategy()
 	}
 	return result
@@ -168,7 +388,7 @@ func (sp *StrategyProfile) Exploitability() float64 {
 	// For now, return a metric based on regret magnitude
 	totalAbsRegret := 0.0
 	count := 0
-	for _, strat := range sp.strategies {
+	for _, strat := range sp.All() {
 		for _, regret := range strat.RegretSum {
 			totalAbsRegret += math.Abs(regret)
 			count++
@@ -1,6 +1,8 @@
 package tree
 
 import (
+	"math"
+
 	"github.com/behrlich/poker-solver/pkg/notation"
 )
 
@@ -11,6 +13,11 @@ type ActionConfig struct {
 	// Ignored if GeometricSizing is set
 	BetSizes []float64
 
+	// AbsoluteBetSizes are bet amounts in bb offered regardless of pot size
+	// (e.g., 2 and 5 = always allow 2bb and 5bb bets). They are added alongside
+	// BetSizes (or geometric sizes) and clamped the same way.
+	AbsoluteBetSizes []float64
+
 	// GeometricSizing, if set, calculates bet sizes geometrically to achieve target pot
 	// When set, overrides BetSizes
 	// Optional - if nil, uses BetSizes instead
@@ -29,14 +36,91 @@ type ActionConfig struct {
 
 	// AllowFold is true if folding is a legal action (facing a bet)
 	AllowFold bool
+
+	// RaiseSizesFacingBet are raise sizes as multiples of the bet faced
+	// (e.g., 3.0 = raise to 3× the opponent's bet). Raise amounts are "raise to"
+	// totals for the street, so the chips added are the call plus the raise.
+	// Sizes that raise by less than the largest bet or raise increment so far
+	// are illegal and dropped; all-in is always offered.
+	// Empty slice means no raising (facing a bet offers only fold/call)
+	RaiseSizesFacingBet []float64
+
+	// MaxRaises caps the bets and raises in a single betting round. Once the
+	// street history holds MaxRaises aggressive actions, facing players may only
+	// fold or call. Zero means no cap.
+	MaxRaises int
+
+	// MinBet is the table minimum bet in bb. Bet sizes below it are rounded up
+	// to MinBet (or all-in if the stack is smaller). Zero means no minimum.
+	MinBet float64
+
+	// MaxBet caps bet sizes below all-in. When set under the stack, bets are
+	// clamped to MaxBet and no all-in bet is added. Zero means no cap.
+	MaxBet float64
+
+	// AllowDonk lets the first player on a new street lead out with a bet when the
+	// opponent made the last bet or raise of the previous street. When false that
+	// player may only check until facing a bet. Applies only to streets dealt by a
+	// multi-street builder, where the previous street's aggressor is known.
+	AllowDonk bool
+
+	// RakePct is the fraction of the pot taken as rake when a hand ends (e.g., 0.05).
+	// The winner's take is reduced by min(pot*RakePct, RakeCap). Zero means no rake.
+	RakePct float64
+
+	// RakeCap is the most rake taken from one pot in bb. Zero means uncapped.
+	RakeCap float64
+
+	// RakePreflop also rakes hands that end before the flop. By default they are
+	// rake-free ("no flop, no drop").
+	RakePreflop bool
+
+	// Runouts controls which turn and river cards a multi-street builder deals
+	// (see RunoutMode). The zero value deals every remaining card.
+	Runouts RunoutMode
+
+	// ShoveOnly restricts the tree to push/fold: check or all-in when not facing
+	// a bet, fold or call when facing one. Bet sizes, raises, and the Allow*
+	// flags are ignored.
+	ShoveOnly bool
+
+	// Preflop builds a heads-up preflop tree from an empty board. The builder
+	// posts the blinds from the game state's stacks into its pot (which then holds
+	// only antes or dead money), the small blind (player 0) acts first and may
+	// fold, limp, or raise, and a completed round ends in a rollout dealing all
+	// five board cards. Raises use RaiseSizesFacingBet as multiples of the big
+	// blind or of the raise faced.
+	Preflop bool
+
+	// SmallBlind and BigBlind are the blinds posted in preflop trees
+	// Zero means the usual 0.5 and 1 bb.
+	SmallBlind float64
+	BigBlind   float64
 }
 
 // GenerateActions generates all legal actions for a given game state
 // This is the action abstraction - we choose which bet sizes to include
+// Assumes the acting player has nothing committed this street; use
+// GenerateActionsForHistory when facing a raise after betting.
 func GenerateActions(pot float64, stack float64, lastAction *notation.Action, config ActionConfig) []notation.Action {
+	var history []notation.Action
+	if lastAction != nil {
+		history = []notation.Action{*lastAction}
+	}
+	return GenerateActionsForHistory(pot, stack, history, config)
+}
+
+// GenerateActionsForHistory generates all legal actions given this street's action history
+// The history determines how much each player has committed, which sets raise amounts
+func GenerateActionsForHistory(pot float64, stack float64, history []notation.Action, config ActionConfig) []notation.Action {
 	var actions []notation.Action
+	lastAction := GetLastAction(history)
+
+	if config.ShoveOnly {
+		return generateShoveOnly(stack, lastAction)
+	}
 
-	// If facing a bet/raise, can fold or call
+	// If facing a bet/raise, can fold, call, or raise
 	if lastAction != nil && (lastAction.Type == notation.Bet || lastAction.Type == notation.Raise) {
 		if config.AllowFold {
 			actions = append(actions, notation.Action{Type: notation.Fold})
@@ -44,8 +128,14 @@ func GenerateActions(pot float64, stack float64, lastAction *notation.Action, co
 		if config.AllowCall {
 			actions = append(actions, notation.Action{Type: notation.Call})
 		}
-		// Note: We don't implement raises in v0.1 river solver (keep tree small)
-		// Will add in v0.2
+
+		committed := StreetCommitments(history)
+		own := committed[len(history)%2]
+		facing := committed[(len(history)+1)%2]
+		if config.MaxRaises <= 0 || countAggressive(history) < config.MaxRaises {
+			minRaiseTo := facing + minRaiseIncrement([2]float64{}, 0, history)
+			actions = append(actions, generateRaises(own, facing, stack, minRaiseTo, config.RaiseSizesFacingBet)...)
+		}
 		return actions
 	}
 
@@ -68,13 +158,30 @@ func GenerateActions(pot float64, stack float64, lastAction *notation.Action, co
 		betSizeFractions = config.BetSizes
 	}
 
-	// Generate bet actions based on calculated sizes
+	// Largest allowed bet: all-in, or MaxBet if that's smaller
+	maxBet := stack
+	if config.MaxBet > 0 && config.MaxBet < stack {
+		maxBet = config.MaxBet
+	}
+
+	// Pot-relative sizes first, then fixed amounts
+	betAmounts := make([]float64, 0, len(betSizeFractions)+len(config.AbsoluteBetSizes))
 	for _, sizeFraction := range betSizeFractions {
-		betAmount := pot * sizeFraction
+		betAmounts = append(betAmounts, pot*sizeFraction)
+	}
+	betAmounts = append(betAmounts, config.AbsoluteBetSizes...)
+
+	// Generate bet actions based on calculated sizes
+	for _, betAmount := range betAmounts {
 
-		// Cap bet at remaining stack (all-in)
-		if betAmount >= stack {
-			betAmount = stack
+		// Round sub-minimum bets up to the table minimum
+		if betAmount < config.MinBet {
+			betAmount = config.MinBet
+		}
+
+		// Cap bet at the maximum (all-in unless MaxBet is lower)
+		if betAmount >= maxBet {
+			betAmount = maxBet
 		}
 
 		// Skip if this bet size is too small (< 0.01 bb)
@@ -82,6 +189,11 @@ func GenerateActions(pot float64, stack float64, lastAction *notation.Action, co
 			continue
 		}
 
+		// Clamping can collapse several sizes onto one amount
+		if hasBetAmount(actions, betAmount) {
+			continue
+		}
+
 		actions = append(actions, notation.Action{
 			Type:   notation.Bet,
 			Amount: betAmount,
@@ -89,7 +201,8 @@ func GenerateActions(pot float64, stack float64, lastAction *notation.Action, co
 	}
 
 	// Always include all-in as an option if stack > 0 and we have bet sizes
-	if stack > 0.01 && len(betSizeFractions) > 0 {
+	// (unless MaxBet keeps bets below all-in)
+	if stack > 0.01 && len(betAmounts) > 0 && maxBet == stack {
 		// Check if all-in is already included (avoid duplicate)
 		hasAllIn := false
 		for _, action := range actions {
@@ -110,6 +223,149 @@ func GenerateActions(pot float64, stack float64, lastAction *notation.Action, co
 	return actions
 }
 
+// hasBetAmount reports whether actions already holds a bet of (nearly) this amount
+func hasBetAmount(actions []notation.Action, amount float64) bool {
+	for _, action := range actions {
+		if action.Type == notation.Bet && math.Abs(action.Amount-amount) < 0.01 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateShoveOnly returns push/fold actions: fold/call facing a bet, else check/all-in
+func generateShoveOnly(stack float64, lastAction *notation.Action) []notation.Action {
+	if lastAction != nil && (lastAction.Type == notation.Bet || lastAction.Type == notation.Raise) {
+		return []notation.Action{{Type: notation.Fold}, {Type: notation.Call}}
+	}
+
+	actions := []notation.Action{{Type: notation.Check}}
+	if stack > 0.01 {
+		actions = append(actions, notation.Action{Type: notation.Bet, Amount: stack})
+	}
+	return actions
+}
+
+// generateRaises builds raise actions when facing a bet
+// own/facing are the street commitments of the actor and the opponent
+// Raise amounts are "raise to" totals: multiple × facing, capped at all-in.
+// Totals below minRaiseTo are dropped unless they are the all-in.
+func generateRaises(own, facing, stack, minRaiseTo float64, multiples []float64) []notation.Action {
+	if len(multiples) == 0 {
+		return nil
+	}
+
+	// Can't raise if calling already puts us all-in
+	allInTo := own + stack
+	if allInTo <= facing+0.01 {
+		return nil
+	}
+
+	var raises []notation.Action
+	hasAllIn := false
+	for _, multiple := range multiples {
+		raiseTo := facing * multiple
+
+		// Cap raise at remaining stack (all-in)
+		if raiseTo >= allInTo {
+			raiseTo = allInTo
+		}
+
+		// Must actually raise by a meaningful amount
+		if raiseTo < facing+0.01 {
+			continue
+		}
+
+		// Short of a min-raise is only legal all-in
+		if raiseTo < minRaiseTo-0.01 && raiseTo < allInTo-0.01 {
+			continue
+		}
+
+		// Avoid duplicate all-ins from several large multiples
+		if raiseTo >= allInTo-0.01 {
+			if hasAllIn {
+				continue
+			}
+			hasAllIn = true
+		}
+
+		raises = append(raises, notation.Action{
+			Type:   notation.Raise,
+			Amount: raiseTo,
+		})
+	}
+
+	// Always include all-in as a raise option
+	if !hasAllIn {
+		raises = append(raises, notation.Action{
+			Type:   notation.Raise,
+			Amount: allInTo,
+		})
+	}
+
+	return raises
+}
+
+// countAggressive returns the number of bets and raises in this street's history
+func countAggressive(history []notation.Action) int {
+	n := 0
+	for _, action := range history {
+		if action.Type == notation.Bet || action.Type == notation.Raise {
+			n++
+		}
+	}
+	return n
+}
+
+// minRaiseIncrement returns the smallest increment a raise after history may add
+// No-limit raises must add at least the largest bet or raise increment made this
+// round; a short all-in does not lower it. start holds the commitments going into
+// history (the blinds preflop), and increment the minimum before any action (the
+// big blind preflop).
+func minRaiseIncrement(start [2]float64, increment float64, history []notation.Action) float64 {
+	committed := start
+	for i, action := range history {
+		p := i % 2
+		var to float64
+		switch action.Type {
+		case notation.Bet:
+			to = committed[p] + action.Amount
+		case notation.Raise:
+			to = action.Amount
+		case notation.Call:
+			committed[p] = committed[1-p]
+			continue
+		default:
+			continue
+		}
+		if inc := to - math.Max(committed[0], committed[1]); inc > increment {
+			increment = inc
+		}
+		committed[p] = to
+	}
+	return increment
+}
+
+// StreetCommitments returns the chips each player has put in during this street
+// Index 0 is the player who acted first in the history, index 1 the other player.
+// Bets add their amount, raises set the total to the "raise to" amount, and
+// calls match the opponent's commitment.
+func StreetCommitments(history []notation.Action) [2]float64 {
+	var committed [2]float64
+	for i, action := range history {
+		p := i % 2
+		switch action.Type {
+		case notation.Bet:
+			committed[p] += action.Amount
+		case notation.Raise:
+			committed[p] = action.Amount
+		case notation.Call:
+			committed[p] = committed[1-p]
+		}
+	}
+	return committed
+}
+
 // DefaultRiverConfig returns a reasonable default action config for river play
 // Allows check or bet with 2-3 standard sizes
 func DefaultRiverConfig() ActionConfig {
@@ -121,6 +377,43 @@ func DefaultRiverConfig() ActionConfig {
 	}
 }
 
+// SPR returns the stack-to-pot ratio using the effective (smaller) stack
+// A pot of zero or less gives +Inf.
+func SPR(stacks [2]float64, pot float64) float64 {
+	if pot <= 0 {
+		return math.Inf(1)
+	}
+	return math.Min(stacks[0], stacks[1]) / pot
+}
+
+// ConfigForSPR returns a preset action config with bet sizes suited to the SPR
+// Shallow stacks get fewer, bigger options since any sizable bet commits the
+// bettor; deep stacks get smaller sizes to build the pot over several streets.
+// All-in is always available (see GenerateActionsForHistory).
+//   - SPR <= 1: push/fold (check or all-in)
+//   - SPR <= 3: 50% and 100% pot
+//   - SPR <= 8: 33%, 75%, and 125% pot
+//   - deeper:   25%, 50%, and 75% pot
+func ConfigForSPR(spr float64) ActionConfig {
+	config := ActionConfig{
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+	}
+
+	switch {
+	case spr <= 1:
+		config.ShoveOnly = true
+	case spr <= 3:
+		config.BetSizes = []float64{0.5, 1.0}
+	case spr <= 8:
+		config.BetSizes = []float64{0.33, 0.75, 1.25}
+	default:
+		config.BetSizes = []float64{0.25, 0.5, 0.75}
+	}
+	return config
+}
+
 // GetLastAction returns the last action from action history, or nil if empty
 func GetLastAction(history []notation.Action) *notation.Action {
 	if len(history) == 0 {
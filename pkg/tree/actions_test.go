@@ -1,6 +1,7 @@
 package tree
 
 import (
+	"math"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/notation"
@@ -181,6 +182,56 @@ func TestDefaultRiverConfig(t *testing.T) {
 	}
 }
 
+func TestSPR(t *testing.T) {
+	if got := SPR([2]float64{100, 40}, 20); got != 2 {
+		t.Errorf("SPR = %v, want 2 (effective stack 40 / pot 20)", got)
+	}
+	if got := SPR([2]float64{100, 100}, 0); !math.IsInf(got, 1) {
+		t.Errorf("SPR with empty pot = %v, want +Inf", got)
+	}
+}
+
+func TestConfigForSPR(t *testing.T) {
+	hasAllIn := func(actions []notation.Action, stack float64) bool {
+		for _, a := range actions {
+			if a.Type == notation.Bet && a.Amount == stack {
+				return true
+			}
+		}
+		return false
+	}
+
+	// SPR 0.8: check or shove
+	low := GenerateActions(10, 8, nil, ConfigForSPR(SPR([2]float64{8, 50}, 10)))
+	if len(low) != 2 || low[0].Type != notation.Check || !hasAllIn(low, 8) {
+		t.Errorf("low SPR actions = %v, want check and all-in", low)
+	}
+
+	// SPR 20: sizes well below pot, plus all-in
+	high := GenerateActions(10, 200, nil, ConfigForSPR(SPR([2]float64{200, 200}, 10)))
+	smallest := math.Inf(1)
+	for _, a := range high {
+		if a.Type == notation.Bet {
+			smallest = math.Min(smallest, a.Amount)
+		}
+	}
+	if smallest > 5 {
+		t.Errorf("high SPR smallest bet = %v, want at most half pot: %v", smallest, high)
+	}
+	if !hasAllIn(high, 200) {
+		t.Errorf("high SPR actions should still include all-in: %v", high)
+	}
+
+	// Facing a bet, every band can fold or call
+	for _, spr := range []float64{0.5, 2, 5, 20} {
+		config := ConfigForSPR(spr)
+		facing := GenerateActions(10, 100, &notation.Action{Type: notation.Bet, Amount: 5}, config)
+		if len(facing) < 2 || facing[0].Type != notation.Fold || facing[1].Type != notation.Call {
+			t.Errorf("SPR %v facing a bet: %v, want fold and call first", spr, facing)
+		}
+	}
+}
+
 func TestGetLastAction(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -262,3 +313,365 @@ func TestGenerateActions_SmallBetsFiltered(t *testing.T) {
 		t.Error("expected Check action")
 	}
 }
+
+func TestGenerateActions_MinMaxBet(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ActionConfig
+		pot      float64
+		stack    float64
+		expected []notation.Action
+	}{
+		{
+			name:   "1% pot on 0.5bb pot rounds up to 1bb min bet",
+			config: ActionConfig{BetSizes: []float64{0.01}, AllowCheck: true, MinBet: 1},
+			pot:    0.5,
+			stack:  100,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 1},
+				{Type: notation.Bet, Amount: 100},
+			},
+		},
+		{
+			name:   "sizes rounded up to the minimum are deduped",
+			config: ActionConfig{BetSizes: []float64{0.5, 1.0, 2.0}, AllowCheck: true, MinBet: 2},
+			pot:    1,
+			stack:  100,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 2},
+				{Type: notation.Bet, Amount: 100},
+			},
+		},
+		{
+			name:   "max bet clamps sizes and drops the all-in",
+			config: ActionConfig{BetSizes: []float64{0.5, 1.0, 2.0}, AllowCheck: true, MaxBet: 15},
+			pot:    10,
+			stack:  100,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 5},
+				{Type: notation.Bet, Amount: 10},
+				{Type: notation.Bet, Amount: 15},
+			},
+		},
+		{
+			name:   "min bet above the stack becomes all-in",
+			config: ActionConfig{BetSizes: []float64{0.5}, AllowCheck: true, MinBet: 5},
+			pot:    2,
+			stack:  3,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := GenerateActions(tt.pot, tt.stack, nil, tt.config)
+			if len(actions) != len(tt.expected) {
+				t.Fatalf("expected %d actions, got %d: %v", len(tt.expected), len(actions), actions)
+			}
+			for i, want := range tt.expected {
+				if actions[i] != want {
+					t.Errorf("action %d: expected %v, got %v", i, want, actions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateActions_AbsoluteBetSizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ActionConfig
+		pot      float64
+		stack    float64
+		expected []notation.Action
+	}{
+		{
+			name:   "half pot and a fixed 3bb bet on a 10bb pot",
+			config: ActionConfig{BetSizes: []float64{0.5}, AbsoluteBetSizes: []float64{3}, AllowCheck: true},
+			pot:    10,
+			stack:  100,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 5},
+				{Type: notation.Bet, Amount: 3},
+				{Type: notation.Bet, Amount: 100},
+			},
+		},
+		{
+			name:   "fixed size matching a pot fraction is deduped",
+			config: ActionConfig{BetSizes: []float64{0.5}, AbsoluteBetSizes: []float64{5}, AllowCheck: true},
+			pot:    10,
+			stack:  100,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 5},
+				{Type: notation.Bet, Amount: 100},
+			},
+		},
+		{
+			name:   "fixed sizes alone, clamped to the stack",
+			config: ActionConfig{AbsoluteBetSizes: []float64{2, 50}, AllowCheck: true},
+			pot:    10,
+			stack:  20,
+			expected: []notation.Action{
+				{Type: notation.Check},
+				{Type: notation.Bet, Amount: 2},
+				{Type: notation.Bet, Amount: 20},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := GenerateActions(tt.pot, tt.stack, nil, tt.config)
+			if len(actions) != len(tt.expected) {
+				t.Fatalf("expected %d actions, got %d: %v", len(tt.expected), len(actions), actions)
+			}
+			for i, want := range tt.expected {
+				if actions[i] != want {
+					t.Errorf("action %d: expected %v, got %v", i, want, actions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateActions_RaiseSizesFacingBet(t *testing.T) {
+	config := ActionConfig{
+		BetSizes:            []float64{0.5, 1.0},
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{2.5, 4.0},
+	}
+
+	lastAction := notation.Action{Type: notation.Bet, Amount: 10}
+	actions := GenerateActions(30, 100, &lastAction, config)
+
+	// Fold, call, raise to 25 (2.5×), raise to 40 (4×), raise all-in to 100
+	expected := []notation.Action{
+		{Type: notation.Fold},
+		{Type: notation.Call},
+		{Type: notation.Raise, Amount: 25},
+		{Type: notation.Raise, Amount: 40},
+		{Type: notation.Raise, Amount: 100},
+	}
+
+	if len(actions) != len(expected) {
+		t.Fatalf("expected %d actions, got %d: %v", len(expected), len(actions), actions)
+	}
+	for i, want := range expected {
+		if actions[i] != want {
+			t.Errorf("action %d: expected %v, got %v", i, want, actions[i])
+		}
+	}
+}
+
+func TestGenerateActionsForHistory_Reraise(t *testing.T) {
+	config := ActionConfig{
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{3.0},
+	}
+
+	// We bet 10, got raised to 30; 90 left behind (10 already in)
+	history := []notation.Action{
+		{Type: notation.Bet, Amount: 10},
+		{Type: notation.Raise, Amount: 30},
+	}
+	actions := GenerateActionsForHistory(60, 90, history, config)
+
+	// 3× the raise is 90 total; all-in is 10 committed + 90 stack = 100 total
+	expected := []notation.Action{
+		{Type: notation.Fold},
+		{Type: notation.Call},
+		{Type: notation.Raise, Amount: 90},
+		{Type: notation.Raise, Amount: 100},
+	}
+
+	if len(actions) != len(expected) {
+		t.Fatalf("expected %d actions, got %d: %v", len(expected), len(actions), actions)
+	}
+	for i, want := range expected {
+		if actions[i] != want {
+			t.Errorf("action %d: expected %v, got %v", i, want, actions[i])
+		}
+	}
+}
+
+func TestGenerateActionsForHistory_MinRaise(t *testing.T) {
+	config := ActionConfig{
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{1.5, 2.0},
+	}
+
+	// Facing a 40 bet: raising to 60 adds only 20, short of a min-raise to 80
+	history := []notation.Action{{Type: notation.Bet, Amount: 40}}
+	actions := GenerateActionsForHistory(60, 100, history, config)
+	expected := []notation.Action{
+		{Type: notation.Fold},
+		{Type: notation.Call},
+		{Type: notation.Raise, Amount: 80},
+		{Type: notation.Raise, Amount: 100},
+	}
+	if len(actions) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actions)
+	}
+	for i, want := range expected {
+		if actions[i] != want {
+			t.Errorf("action %d: expected %v, got %v", i, want, actions[i])
+		}
+	}
+
+	// With 50 behind, the min-raise is out of reach but all-in to 50 remains
+	actions = GenerateActionsForHistory(60, 50, history, config)
+	expected = []notation.Action{
+		{Type: notation.Fold},
+		{Type: notation.Call},
+		{Type: notation.Raise, Amount: 50},
+	}
+	if len(actions) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actions)
+	}
+	for i, want := range expected {
+		if actions[i] != want {
+			t.Errorf("action %d: expected %v, got %v", i, want, actions[i])
+		}
+	}
+}
+
+func TestMinRaiseIncrement(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     [2]float64
+		increment float64
+		history   []notation.Action
+		want      float64
+	}{
+		{"bet", [2]float64{}, 0, []notation.Action{{Type: notation.Bet, Amount: 10}}, 10},
+		{"bet raise", [2]float64{}, 0, []notation.Action{{Type: notation.Bet, Amount: 10}, {Type: notation.Raise, Amount: 35}}, 25},
+		// A short all-in raise does not lower the minimum
+		{"short all-in", [2]float64{}, 0, []notation.Action{{Type: notation.Bet, Amount: 10}, {Type: notation.Raise, Amount: 30}, {Type: notation.Raise, Amount: 35}}, 20},
+		{"check bet", [2]float64{}, 0, []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 5}}, 5},
+		{"preflop open", [2]float64{0.5, 1}, 1, nil, 1},
+		{"preflop raise", [2]float64{0.5, 1}, 1, []notation.Action{{Type: notation.Raise, Amount: 3}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minRaiseIncrement(tt.start, tt.increment, tt.history); got != tt.want {
+				t.Errorf("minRaiseIncrement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateActionsForHistory_MaxRaises(t *testing.T) {
+	config := ActionConfig{
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{3.0},
+		MaxRaises:           2,
+	}
+
+	// Facing the opening bet: one aggressive action so far, raising allowed
+	history := []notation.Action{{Type: notation.Bet, Amount: 10}}
+	actions := GenerateActionsForHistory(20, 190, history, config)
+	if !hasActionType(actions, notation.Raise) {
+		t.Errorf("expected raises facing first bet, got %v", actions)
+	}
+
+	// Facing bet + raise: cap reached, only fold/call
+	history = append(history, notation.Action{Type: notation.Raise, Amount: 30})
+	actions = GenerateActionsForHistory(60, 190, history, config)
+	want := []notation.Action{{Type: notation.Fold}, {Type: notation.Call}}
+	if len(actions) != len(want) || actions[0] != want[0] || actions[1] != want[1] {
+		t.Errorf("expected %v at raise cap, got %v", want, actions)
+	}
+
+	// Without a cap the same node can re-raise
+	config.MaxRaises = 0
+	actions = GenerateActionsForHistory(60, 190, history, config)
+	if !hasActionType(actions, notation.Raise) {
+		t.Errorf("expected re-raises without a cap, got %v", actions)
+	}
+}
+
+func hasActionType(actions []notation.Action, actionType notation.ActionType) bool {
+	for _, action := range actions {
+		if action.Type == actionType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGenerateActions_ShoveOnly(t *testing.T) {
+	config := ActionConfig{
+		BetSizes:            []float64{0.33, 0.75},
+		RaiseSizesFacingBet: []float64{3.0},
+		ShoveOnly:           true,
+	}
+
+	// Not facing a bet: check or all-in, fractional sizes ignored
+	actions := GenerateActions(10, 90, nil, config)
+	want := []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 90}}
+	if len(actions) != len(want) {
+		t.Fatalf("expected %v, got %v", want, actions)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("action %d: expected %v, got %v", i, want[i], actions[i])
+		}
+	}
+
+	// Facing the shove: fold or call, no raises
+	lastAction := notation.Action{Type: notation.Bet, Amount: 90}
+	actions = GenerateActions(100, 90, &lastAction, config)
+	if len(actions) != 2 || actions[0].Type != notation.Fold || actions[1].Type != notation.Call {
+		t.Errorf("expected [fold call], got %v", actions)
+	}
+}
+
+func TestGenerateActions_NoRaiseWhenCallIsAllIn(t *testing.T) {
+	config := ActionConfig{
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{3.0},
+	}
+
+	lastAction := notation.Action{Type: notation.Bet, Amount: 50}
+	actions := GenerateActions(70, 40, &lastAction, config)
+
+	for _, action := range actions {
+		if action.Type == notation.Raise {
+			t.Errorf("expected no raises when calling puts us all-in, got %v", action)
+		}
+	}
+}
+
+func TestStreetCommitments(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []notation.Action
+		want    [2]float64
+	}{
+		{"empty", nil, [2]float64{0, 0}},
+		{"check bet", []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 5}}, [2]float64{0, 5}},
+		{"bet raise", []notation.Action{{Type: notation.Bet, Amount: 10}, {Type: notation.Raise, Amount: 30}}, [2]float64{10, 30}},
+		{"bet raise call", []notation.Action{{Type: notation.Bet, Amount: 10}, {Type: notation.Raise, Amount: 30}, {Type: notation.Call}}, [2]float64{30, 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StreetCommitments(tt.history); got != tt.want {
+				t.Errorf("StreetCommitments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,10 @@ package tree
 
 import (
 	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/behrlich/poker-solver/pkg/abstraction"
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -15,7 +19,33 @@ type Builder struct {
 	// Optional: Bucketer for card abstraction
 	// If set, info sets will use bucket IDs instead of specific cards
 	// This dramatically reduces tree size for flop/turn solving
-	Bucketer *abstraction.Bucketer
+	Bucketer abstraction.HandBucketer
+
+	// Optional: collapse suit-isomorphic hands into the same info set
+	// Board and hole cards in info set keys are replaced by their canonical forms
+	SuitIsomorphism bool
+
+	// Workers is the number of goroutines used to build combo-pair subtrees
+	// in range-vs-range trees. 0 means runtime.NumCPU(); 1 builds serially.
+	Workers int
+
+	// Optional: hand evaluator for showdown payoffs, recorded on rollout nodes
+	// nil uses cards.DefaultEvaluator (Hold'em)
+	Evaluator cards.Evaluator
+
+	// Optional: play flop and turn out street by street
+	// When set, a completed flop/turn betting round deals the next card at a
+	// chance node and betting continues; otherwise it ends in a rollout node.
+	// A call that puts a player all-in always ends in a rollout.
+	MultiStreet bool
+
+	// InfoSetFormat selects readable (default) or compact info set keys
+	// Solvers treat keys as opaque; decode compact ones with ReadableInfoSet.
+	InfoSetFormat InfoSetFormat
+
+	// dead is the game state's dead money for the tree being built (see
+	// GameState.DeadMoney); it is recorded on every terminal
+	dead float64
 }
 
 // NewBuilder creates a new tree builder with the given action config
@@ -25,10 +55,38 @@ func NewBuilder(config ActionConfig) *Builder {
 
 // SetBucketer sets the bucketer for card abstraction
 // When a bucketer is set, info sets will use bucket IDs instead of specific cards
-func (b *Builder) SetBucketer(bucketer *abstraction.Bucketer) {
+func (b *Builder) SetBucketer(bucketer abstraction.HandBucketer) {
 	b.Bucketer = bucketer
 }
 
+// SetSuitIsomorphism enables or disables suit-isomorphic info sets
+// When enabled, hands like AsKs and AdKd on a heart-club board share an info set
+func (b *Builder) SetSuitIsomorphism(enabled bool) {
+	b.SuitIsomorphism = enabled
+}
+
+// SetWorkers sets the number of goroutines used by BuildRange/BuildWeightedRange
+// n <= 0 uses runtime.NumCPU()
+func (b *Builder) SetWorkers(n int) {
+	b.Workers = n
+}
+
+// SetEvaluator sets the hand evaluator used for showdown payoffs
+func (b *Builder) SetEvaluator(evaluator cards.Evaluator) {
+	b.Evaluator = evaluator
+}
+
+// SetMultiStreet enables or disables street-by-street betting after the flop and turn
+// Trees grow by roughly the number of remaining cards per street dealt.
+func (b *Builder) SetMultiStreet(enabled bool) {
+	b.MultiStreet = enabled
+}
+
+// SetInfoSetFormat selects how decision nodes' info set keys are written
+func (b *Builder) SetInfoSetFormat(format InfoSetFormat) {
+	b.InfoSetFormat = format
+}
+
 // Build constructs a game tree for a specific combo vs combo matchup
 // This builds the full tree for these two specific hands
 func (b *Builder) Build(gs *notation.GameState, combo0 notation.Combo, combo1 notation.Combo) (*TreeNode, error) {
@@ -37,75 +95,197 @@ func (b *Builder) Build(gs *notation.GameState, combo0 notation.Combo, combo1 no
 		return nil, fmt.Errorf("only 2-player games supported")
 	}
 
-	if len(gs.Board) != 5 && len(gs.Board) != 4 && len(gs.Board) != 3 {
-		return nil, fmt.Errorf("only postflop (3-5 board cards) supported")
+	if err := b.validateStreet(gs); err != nil {
+		return nil, err
 	}
 
 	// Check for card conflicts
 	if err := b.validateCards(gs.Board, combo0, combo1); err != nil {
 		return nil, err
 	}
+	deck := b.deckMask()
+	if err := checkInDeck(deck, gs.Board); err != nil {
+		return nil, err
+	}
+	if err := checkInDeck(deck, append(combo0.Cards(), combo1.Cards()...)); err != nil {
+		return nil, err
+	}
 
 	// Build tree recursively
-	stacks := [2]float64{gs.Players[0].Stack, gs.Players[1].Stack}
+	b.dead = gs.DeadMoney
+	pot, stacks := b.startingPot(gs)
 	combos := [2]notation.Combo{combo0, combo1}
 
-	return b.buildNode(gs.Board, gs.ActionHistory, gs.Pot, stacks, gs.ToAct, combos), nil
+	return b.buildRoot(gs, pot, stacks, combos), nil
 }
 
 // BuildRange constructs a game tree for range-vs-range solving
 // The root is a chance node that samples combo pairs from the ranges
 // Returns a tree where each child of the root represents a specific combo matchup
 func (b *Builder) BuildRange(gs *notation.GameState, range0 []notation.Combo, range1 []notation.Combo) (*TreeNode, error) {
+	return b.BuildWeightedRange(gs, range0, nil, range1, nil)
+}
+
+// BuildWeightedRange constructs a range-vs-range game tree where each combo carries a weight
+// weights0/weights1 are parallel to range0/range1; nil means every combo has weight 1
+// Chance probabilities are proportional to weight0 × weight1 for each valid combo pair
+func (b *Builder) BuildWeightedRange(gs *notation.GameState, range0 []notation.Combo, weights0 []float64, range1 []notation.Combo, weights1 []float64) (*TreeNode, error) {
 	// Validate inputs
 	if len(gs.Players) != 2 {
 		return nil, fmt.Errorf("only 2-player games supported")
 	}
 
-	if len(gs.Board) != 5 && len(gs.Board) != 4 && len(gs.Board) != 3 {
-		return nil, fmt.Errorf("only postflop (3-5 board cards) supported")
+	if err := b.validateStreet(gs); err != nil {
+		return nil, err
+	}
+
+	if weights0 != nil && len(weights0) != len(range0) {
+		return nil, fmt.Errorf("weights0 has %d entries, range0 has %d combos", len(weights0), len(range0))
+	}
+	if weights1 != nil && len(weights1) != len(range1) {
+		return nil, fmt.Errorf("weights1 has %d entries, range1 has %d combos", len(weights1), len(range1))
 	}
 
 	// Create root chance node
-	stacks := [2]float64{gs.Players[0].Stack, gs.Players[1].Stack}
-	root := NewChanceNode(gs.Pot, gs.Board, stacks)
+	b.dead = gs.DeadMoney
+	pot, stacks := b.startingPot(gs)
+	root := NewChanceNode(pot, gs.Board, stacks)
+
+	// Collect each valid combo pair; subtrees are built below
+	type comboPair struct {
+		key    string
+		combos [2]notation.Combo
+	}
+	var pairs []comboPair
+
+	// Combos using a card the evaluator's deck lacks are skipped like conflicts
+	deck := b.deckMask()
+	if err := checkInDeck(deck, gs.Board); err != nil {
+		return nil, err
+	}
+
+	totalWeight := 0.0
+	for i, combo0 := range range0 {
+		w0 := comboWeight(weights0, i)
+		if w0 <= 0 {
+			continue
+		}
+
+		for j, combo1 := range range1 {
+			w1 := comboWeight(weights1, j)
+			if w1 <= 0 {
+				continue
+			}
 
-	// Build game tree for each valid combo pair
-	validPairs := 0
-	for _, combo0 := range range0 {
-		for _, combo1 := range range1 {
 			// Check for card conflicts
+			if (combo0.Mask()|combo1.Mask())&^deck != 0 {
+				continue
+			}
 			if err := b.validateCards(gs.Board, combo0, combo1); err != nil {
 				// Skip invalid pairs (cards conflict with board or each other)
 				continue
 			}
 
-			// Build tree for this combo pair
-			combos := [2]notation.Combo{combo0, combo1}
-			child := b.buildNode(gs.Board, gs.ActionHistory, gs.Pot, stacks, gs.ToAct, combos)
-
-			// Add as child with key "combo0:combo1"
+			// Key children as "combo0:combo1"
 			comboKey := fmt.Sprintf("%s:%s", combo0.String(), combo1.String())
-			root.Children[comboKey] = child
+			pairs = append(pairs, comboPair{key: comboKey, combos: [2]notation.Combo{combo0, combo1}})
 
-			validPairs++
+			// Store unnormalized weight for now
+			root.ChanceProbabilities[comboKey] += w0 * w1
+			totalWeight += w0 * w1
 		}
 	}
 
-	if validPairs == 0 {
+	// Build the subtree for each combo pair with a pool of workers
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan comboPair)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pair := range jobs {
+				child := b.buildRoot(gs, pot, stacks, pair.combos)
+
+				mu.Lock()
+				root.Children[pair.key] = child
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, pair := range pairs {
+		jobs <- pair
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(root.Children) == 0 {
 		return nil, fmt.Errorf("no valid combo pairs (all conflict with board or each other)")
 	}
 
-	// Set uniform probability for each outcome
-	prob := 1.0 / float64(validPairs)
-	for key := range root.Children {
-		root.ChanceProbabilities[key] = prob
+	// Normalize pair weights into probabilities
+	for key := range root.ChanceProbabilities {
+		root.ChanceProbabilities[key] /= totalWeight
 	}
 
 	return root, nil
 }
 
+// validateStreet checks that gs is a spot the builder can start from: a flop,
+// turn, or river board, or with Config.Preflop an empty board and no action yet
+func (b *Builder) validateStreet(gs *notation.GameState) error {
+	if b.Config.Preflop {
+		if len(gs.Board) != 0 {
+			return fmt.Errorf("preflop trees need an empty board, got %d cards", len(gs.Board))
+		}
+		if len(gs.ActionHistory) != 0 {
+			return fmt.Errorf("preflop trees start before any action, got history %q", HistoryString(gs.ActionHistory))
+		}
+		return nil
+	}
+	if len(gs.Board) != 5 && len(gs.Board) != 4 && len(gs.Board) != 3 {
+		return fmt.Errorf("only postflop (3-5 board cards) supported")
+	}
+	return nil
+}
+
+// startingPot returns the root's pot and stacks: gs's own, or with Config.Preflop
+// gs's pot and stacks before the builder posts the blinds
+func (b *Builder) startingPot(gs *notation.GameState) (float64, [2]float64) {
+	stacks := [2]float64{gs.Players[0].Stack, gs.Players[1].Stack}
+	if b.Config.Preflop {
+		return b.postBlinds(gs.Pot, stacks)
+	}
+	return gs.Pot, stacks
+}
+
+// buildRoot builds the betting tree for one combo pair from gs
+// Preflop trees always open with the small blind (player 0).
+func (b *Builder) buildRoot(gs *notation.GameState, pot float64, stacks [2]float64, combos [2]notation.Combo) *TreeNode {
+	if b.Config.Preflop {
+		return b.buildPreflopNode(nil, pot, stacks, 0, combos)
+	}
+	return b.buildNode(gs.Board, gs.ActionHistory, pot, stacks, gs.ToAct, combos, "")
+}
+
+// comboWeight returns the weight of combo i, treating a nil weight slice as uniform
+func comboWeight(weights []float64, i int) float64 {
+	if weights == nil {
+		return 1.0
+	}
+	return weights[i]
+}
+
 // buildNode recursively builds a node in the game tree
+// history holds the current street's actions; prior is the history string of
+// earlier streets dealt by the builder, each followed by "/" (empty on the first street)
 func (b *Builder) buildNode(
 	board []cards.Card,
 	history []notation.Action,
@@ -113,57 +293,55 @@ func (b *Builder) buildNode(
 	stacks [2]float64,
 	toAct int,
 	combos [2]notation.Combo,
+	prior string,
 ) *TreeNode {
 	// Check if we've reached a terminal node
 	lastAction := GetLastAction(history)
 
 	// Terminal: fold
 	if lastAction != nil && lastAction.Type == notation.Fold {
-		// Player who didn't fold wins the pot
-		payoffs := [2]float64{0, 0}
-		if toAct == 0 {
-			// Player 1 folded, player 0 wins
-			payoffs[0] = pot
-		} else {
-			// Player 0 folded, player 1 wins
-			payoffs[1] = pot
-		}
-		return NewTerminalNode(pot, payoffs, board, stacks)
+		// Player who didn't fold (the one to act) wins; their uncalled bet is refunded
+		committed := StreetCommitments(history)
+		uncalled := math.Abs(committed[0] - committed[1])
+		rake := b.rake(board, pot-uncalled)
+		node := NewTerminalNode(pot, DeadFoldPayoffs(toAct, pot, uncalled, b.dead, rake), board, stacks)
+		node.Rake = rake
+		node.Dead = b.dead
+		return node
 	}
 
 	// Terminal: showdown (both players checked or someone called)
 	if b.isShowdown(history) {
-		// If we're on the flop (3 cards), create a rollout node that will sample turn+river
-		if len(board) == 3 {
-			return NewRolloutNode(pot, board, stacks, combos)
+		// A call that leaves either player all-in closes the action for the rest of
+		// the hand, so the board just runs out. Any other completed flop or turn
+		// round deals the next street and betting continues (MultiStreet).
+		closed := isAllIn(stacks[0]) || isAllIn(stacks[1])
+		if b.MultiStreet && len(board) < 5 && !closed {
+			return b.buildStreetDeal(board, history, pot, stacks, toAct, combos, prior)
 		}
+		rake := b.rake(board, pot)
+		// If we're on the flop (3 cards), create a rollout node that will sample turn+river
 		// If we're on the turn (4 cards), create a rollout node that will sample river cards
-		if len(board) == 4 {
-			return NewRolloutNode(pot, board, stacks, combos)
+		if len(board) == 3 || len(board) == 4 {
+			return b.rolloutNode(pot, board, stacks, combos, rake)
 		}
 		// River (5 cards): evaluate immediately
 		payoffs := b.calculateShowdownPayoffs(board, combos, pot)
-		return NewTerminalNode(pot, payoffs, board, stacks)
+		node := NewTerminalNode(pot, payoffs, board, stacks)
+		node.Rake = rake
+		node.Dead = b.dead
+		return node
 	}
 
 	// Decision node: current player must act
-	playerCombo := combos[toAct]
-	holeCards := []cards.Card{playerCombo.Card1, playerCombo.Card2}
-
-	// Generate info set key for this player
 	playerPos := []notation.Position{notation.BTN, notation.BB}[toAct]
-	var infoSet string
-	if b.Bucketer != nil {
-		// Use card abstraction: bucket the hand and use bucket ID
-		bucketID := b.Bucketer.BucketCombo(playerCombo)
-		infoSet = GetInfoSetBucketed(board, history, playerPos, bucketID)
-	} else {
-		// No abstraction: use specific cards
-		infoSet = GetInfoSet(board, history, playerPos, holeCards)
-	}
+	infoSet := b.infoSetKey(board, prior, history, playerPos, combos[toAct])
 
-	// Generate legal actions
-	actions := GenerateActions(pot, stacks[toAct], lastAction, b.Config)
+	// Generate legal actions; bets and raises are capped at what the opponent can call
+	actions := GenerateActionsForHistory(pot, effectiveStack(stacks, toAct, history), history, b.Config)
+	if !b.Config.AllowDonk && len(history) == 0 && isDonkSpot(prior) {
+		actions = withoutBets(actions)
+	}
 
 	// Create decision node
 	node := NewDecisionNode(infoSet, toAct, pot, actions, board, stacks)
@@ -177,10 +355,17 @@ func (b *Builder) buildNode(
 
 		// Update pot and stacks based on action
 		switch action.Type {
-		case notation.Bet, notation.Raise:
+		case notation.Bet:
 			newPot += action.Amount
 			newStacks[toAct] -= action.Amount
 
+		case notation.Raise:
+			// Raise amount is the street total; only the difference goes in now
+			committed := StreetCommitments(history)
+			raiseChips := action.Amount - committed[len(history)%2]
+			newPot += raiseChips
+			newStacks[toAct] -= raiseChips
+
 		case notation.Call:
 			// Figure out how much to call
 			callAmount := b.getCallAmount(history, pot, stacks[toAct])
@@ -199,19 +384,147 @@ func (b *Builder) buildNode(
 		} else if action.Type == notation.Bet || action.Type == notation.Raise {
 			// After a bet/raise, opponent acts
 			nextToAct = 1 - toAct
-		} else if action.Type == notation.Call || action.Type == notation.Fold {
-			// After call/fold, game is over (will be caught by terminal checks)
-			nextToAct = toAct // doesn't matter, will be terminal
+		} else if action.Type == notation.Fold {
+			// After a fold, the fold terminal credits the player left in the hand
+			nextToAct = 1 - toAct
+		} else if action.Type == notation.Call {
+			// After a call, the round is over; the opponent is next in rotation
+			nextToAct = 1 - toAct
 		}
 
 		// Recursively build child node
-		child := b.buildNode(board, newHistory, newPot, newStacks, nextToAct, combos)
+		child := b.buildNode(board, newHistory, newPot, newStacks, nextToAct, combos, prior)
 		node.Children[ActionKey(action)] = child
 	}
 
 	return node
 }
 
+// infoSetKey builds the info set key for the player holding combo, honoring the
+// Bucketer, SuitIsomorphism, and InfoSetFormat settings
+func (b *Builder) infoSetKey(board []cards.Card, prior string, history []notation.Action, playerPos notation.Position, combo notation.Combo) string {
+	// Canonical card order keeps AhAs and AsAh in the same info set
+	playerCombo := combo.Canonical()
+	holeCards := playerCombo.Cards()
+
+	keyBoard, keyHole := board, holeCards
+	bucketID := -1
+	if b.Bucketer != nil {
+		// Use card abstraction: bucket the hand and use bucket ID
+		bucketID = b.Bucketer.BucketCombo(playerCombo)
+	} else if b.SuitIsomorphism {
+		// Collapse suit-isomorphic hands: use canonical board and hole cards
+		keyBoard, keyHole = cards.CanonicalizeHand(board, holeCards)
+	}
+
+	if b.InfoSetFormat == CompactInfoSets {
+		return compactInfoSet(keyBoard, prior, history, playerPos, keyHole, bucketID)
+	}
+
+	var infoSet string
+	if bucketID >= 0 {
+		infoSet = GetInfoSetBucketed(keyBoard, history, playerPos, bucketID)
+	} else {
+		infoSet = GetInfoSet(keyBoard, history, playerPos, keyHole)
+	}
+	if prior != "" {
+		// Earlier streets' actions keep info sets distinct across lines (perfect recall)
+		parts := strings.SplitN(infoSet, "|", 3)
+		infoSet = parts[0] + "|" + prior + parts[1] + "|" + parts[2]
+	}
+	return infoSet
+}
+
+// buildStreetDeal builds a chance node dealing the next board card after a completed
+// betting round. The cards dealt come from Config.Runouts (by default every card not
+// on the board or in either hand) and are equally likely; the player who opened the
+// finished street acts first on the new one. If the runout mode leaves no card to
+// deal, the hand ends in a rollout as it would without MultiStreet.
+func (b *Builder) buildStreetDeal(
+	board []cards.Card,
+	history []notation.Action,
+	pot float64,
+	stacks [2]float64,
+	toAct int,
+	combos [2]notation.Combo,
+	prior string,
+) *TreeNode {
+	node := NewChanceNode(pot, board, stacks)
+
+	// toAct is next in rotation, so after an even number of actions it opened the street
+	firstToAct := toAct
+	if len(history)%2 == 1 {
+		firstToAct = 1 - toAct
+	}
+	streetPrior := prior + HistoryString(history) + "/"
+
+	// Runouts are chosen from the deck less the board so every combo pair
+	// branches on the same cards; the pair's own hole cards are then dropped
+	deck := cards.NewDeckFor(b.Evaluator)
+	deck.Remove(board...)
+	held := cards.NewCardMask(append(combos[0].Cards(), combos[1].Cards()...)...)
+	dealt := b.Config.Runouts.Deal(deck.Cards(), held)
+	if len(dealt) == 0 {
+		return b.rolloutNode(pot, board, stacks, combos, b.rake(board, pot))
+	}
+
+	for _, card := range dealt {
+		newBoard := append(append([]cards.Card{}, board...), card)
+		key := card.String()
+		node.Children[key] = b.buildNode(newBoard, nil, pot, stacks, firstToAct, combos, streetPrior)
+		node.ChanceProbabilities[key] = 1.0 / float64(len(dealt))
+	}
+
+	return node
+}
+
+// effectiveStack returns the most chips toAct can usefully put in: their stack, but no
+// more than it takes to match the opponent's commitment and cover the opponent's stack
+func effectiveStack(stacks [2]float64, toAct int, history []notation.Action) float64 {
+	committed := StreetCommitments(history)
+	toMatch := committed[(len(history)+1)%2] - committed[len(history)%2]
+	return math.Min(stacks[toAct], math.Max(toMatch, 0)+stacks[1-toAct])
+}
+
+// isDonkSpot reports whether the first player on a street would be leading into the
+// previous street's aggressor. prior holds earlier streets, each followed by "/";
+// the first actor is unchanged between streets, so the aggressor is the opponent
+// when the previous street's last bet or raise came at an odd position.
+func isDonkSpot(prior string) bool {
+	streets := strings.Split(prior, "/")
+	if len(streets) < 2 {
+		return false
+	}
+	previous, err := notation.ParseHistory(streets[len(streets)-2])
+	if err != nil {
+		return false
+	}
+	for i := len(previous) - 1; i >= 0; i-- {
+		if previous[i].Type == notation.Bet || previous[i].Type == notation.Raise {
+			return i%2 == 1
+		}
+	}
+	return false
+}
+
+// withoutBets removes leading bets from a list of actions
+func withoutBets(actions []notation.Action) []notation.Action {
+	filtered := make([]notation.Action, 0, len(actions))
+	for _, action := range actions {
+		if action.Type != notation.Bet {
+			filtered = append(filtered, action)
+		}
+	}
+	return filtered
+}
+
+// isAllIn reports whether a player has no chips behind
+// Anything under half a chip counts, so float residue from raise arithmetic
+// can't open a street of betting nobody can bet on.
+func isAllIn(stack float64) bool {
+	return stack < ChipSize/2
+}
+
 // isShowdown returns true if we've reached a showdown
 func (b *Builder) isShowdown(history []notation.Action) bool {
 	if len(history) < 2 {
@@ -234,39 +547,49 @@ func (b *Builder) isShowdown(history []notation.Action) bool {
 	return false
 }
 
-// calculateShowdownPayoffs determines payoffs at showdown
-func (b *Builder) calculateShowdownPayoffs(board []cards.Card, combos [2]notation.Combo, pot float64) [2]float64 {
-	// Evaluate both hands
-	hand0 := append([]cards.Card{combos[0].Card1, combos[0].Card2}, board...)
-	hand1 := append([]cards.Card{combos[1].Card1, combos[1].Card2}, board...)
-
-	rank0 := cards.Evaluate(hand0)
-	rank1 := cards.Evaluate(hand1)
+// rake returns the rake taken from a pot won on board (see ActionConfig.RakePct)
+func (b *Builder) rake(board []cards.Card, pot float64) float64 {
+	if len(board) == 0 && !b.Config.RakePreflop {
+		return 0
+	}
+	return Rake(pot, b.Config.RakePct, b.Config.RakeCap)
+}
 
-	cmp := rank0.Compare(rank1)
+// rolloutNode returns a rollout terminal carrying the tree's rake, dead money,
+// and evaluator, so solvers run the board out under the builder's rules
+func (b *Builder) rolloutNode(pot float64, board []cards.Card, stacks [2]float64, combos [2]notation.Combo, rake float64) *TreeNode {
+	node := NewRolloutNode(pot, board, stacks, combos)
+	node.Rake = rake
+	node.Dead = b.dead
+	node.Evaluator = b.Evaluator
+	return node
+}
 
-	if cmp > 0 {
-		// Player 0 wins
-		return [2]float64{pot, 0}
-	} else if cmp < 0 {
-		// Player 1 wins
-		return [2]float64{0, pot}
-	} else {
-		// Tie (split pot)
-		return [2]float64{pot / 2, pot / 2}
+// calculateShowdownPayoffs determines payoffs at showdown, net of rake
+func (b *Builder) calculateShowdownPayoffs(board []cards.Card, combos [2]notation.Combo, pot float64) [2]float64 {
+	// Evaluate both hands
+	evaluator := b.Evaluator
+	if evaluator == nil {
+		evaluator = cards.DefaultEvaluator
 	}
+	rank0 := evaluator.Evaluate(combos[0].Cards(), board)
+	rank1 := evaluator.Evaluate(combos[1].Cards(), board)
+
+	return DeadShowdownPayoffs(rank0.Compare(rank1), pot, b.dead, b.rake(board, pot))
 }
 
 // getCallAmount calculates how much the current player needs to call
+// This is the last bet/raise total minus what the caller already committed this street
 func (b *Builder) getCallAmount(history []notation.Action, pot float64, stack float64) float64 {
 	if len(history) == 0 {
 		return 0
 	}
 
-	// Find the last bet/raise amount
+	// Find the last bet/raise; the caller is the other player
 	for i := len(history) - 1; i >= 0; i-- {
 		if history[i].Type == notation.Bet || history[i].Type == notation.Raise {
-			callAmount := history[i].Amount
+			committed := StreetCommitments(history)
+			callAmount := committed[i%2] - committed[(i+1)%2]
 			// Cap at remaining stack
 			if callAmount > stack {
 				return stack
@@ -278,32 +601,42 @@ func (b *Builder) getCallAmount(history []notation.Action, pot float64, stack fl
 	return 0
 }
 
+// deckMask returns the cards of the evaluator's deck (see cards.NewDeckFor)
+func (b *Builder) deckMask() cards.CardMask {
+	return cards.NewCardMask(cards.NewDeckFor(b.Evaluator).Cards()...)
+}
+
+// checkInDeck returns an error for the first card that isn't in deck
+// (e.g., a Five in short deck)
+func checkInDeck(deck cards.CardMask, cs []cards.Card) error {
+	for _, card := range cs {
+		if !deck.Has(card) {
+			return fmt.Errorf("card %v is not in the deck", card)
+		}
+	}
+	return nil
+}
+
 // validateCards checks for duplicate cards
+// It runs once per combo pair in BuildRange, so the seen set is a CardMask
+// rather than a map.
 func (b *Builder) validateCards(board []cards.Card, combo0, combo1 notation.Combo) error {
-	allCards := make(map[cards.Card]bool)
+	var seen cards.CardMask
 
 	// Check board cards
 	for _, card := range board {
-		if allCards[card] {
+		if seen.Has(card) {
 			return fmt.Errorf("duplicate card in board: %v", card)
 		}
-		allCards[card] = true
-	}
-
-	// Check combo 0
-	for _, card := range []cards.Card{combo0.Card1, combo0.Card2} {
-		if allCards[card] {
-			return fmt.Errorf("duplicate card: %v", card)
-		}
-		allCards[card] = true
+		seen = seen.With(card)
 	}
 
-	// Check combo 1
-	for _, card := range []cards.Card{combo1.Card1, combo1.Card2} {
-		if allCards[card] {
+	// Check both combos
+	for _, card := range append(combo0.Cards(), combo1.Cards()...) {
+		if seen.Has(card) {
 			return fmt.Errorf("duplicate card: %v", card)
 		}
-		allCards[card] = true
+		seen = seen.With(card)
 	}
 
 	return nil
@@ -1,6 +1,8 @@
 package tree
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -178,3 +180,305 @@ func TestBuilder_BuildRange_MultipleRanges(t *testing.T) {
 		}
 	}
 }
+
+func TestBuilder_BuildWeightedRange_Probabilities(t *testing.T) {
+	board, err := cards.ParseCards("Kh9s4c7d2s")
+	if err != nil {
+		t.Fatalf("Failed to parse board: %v", err)
+	}
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board,
+		ToAct: 0,
+	}
+
+	// BTN: AA at full weight; BB: QQ at 0.5, JJ at full weight
+	range0, weights0, err := notation.ParseWeightedRange("AA")
+	if err != nil {
+		t.Fatalf("Failed to parse range0: %v", err)
+	}
+	range1, weights1, err := notation.ParseWeightedRange("QQ@0.5,JJ")
+	if err != nil {
+		t.Fatalf("Failed to parse range1: %v", err)
+	}
+
+	builder := NewBuilder(DefaultRiverConfig())
+	root, err := builder.BuildWeightedRange(gs, range0, weights0, range1, weights1)
+	if err != nil {
+		t.Fatalf("BuildWeightedRange failed: %v", err)
+	}
+
+	// 6 AA × (6 QQ + 6 JJ) = 72 pairs
+	if len(root.Children) != 72 {
+		t.Fatalf("Expected 72 children, got %d", len(root.Children))
+	}
+
+	totalProb := 0.0
+	qqProb := 0.0
+	jjProb := 0.0
+	for key, prob := range root.ChanceProbabilities {
+		totalProb += prob
+		if key[5] == 'Q' {
+			qqProb += prob
+		} else {
+			jjProb += prob
+		}
+	}
+
+	if totalProb < 0.999 || totalProb > 1.001 {
+		t.Errorf("Probabilities should sum to 1.0, got %.6f", totalProb)
+	}
+
+	// QQ has half the weight of JJ: 1/3 vs 2/3 of the mass
+	if qqProb < 0.333 || qqProb > 0.334 {
+		t.Errorf("Expected QQ pairs to carry 1/3 of probability, got %.4f", qqProb)
+	}
+	if jjProb < 0.666 || jjProb > 0.667 {
+		t.Errorf("Expected JJ pairs to carry 2/3 of probability, got %.4f", jjProb)
+	}
+}
+
+func TestBuilder_BuildWeightedRange_ZeroWeight(t *testing.T) {
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: makeRiverBoard(),
+		ToAct: 0,
+	}
+
+	range0, _ := notation.ParseRange("AA")
+	range1, _ := notation.ParseRange("QQ,JJ")
+
+	// Zero out every QQ combo explicitly
+	weights1 := make([]float64, len(range1))
+	for i, combo := range range1 {
+		if combo.Card1.Rank == cards.Jack {
+			weights1[i] = 1
+		}
+	}
+
+	builder := NewBuilder(DefaultRiverConfig())
+	root, err := builder.BuildWeightedRange(gs, range0, nil, range1, weights1)
+	if err != nil {
+		t.Fatalf("BuildWeightedRange failed: %v", err)
+	}
+
+	if len(root.Children) != 36 {
+		t.Errorf("Expected 36 children (QQ excluded), got %d", len(root.Children))
+	}
+	for key := range root.Children {
+		if key[5] == 'Q' {
+			t.Errorf("zero-weight combo pair %s should be excluded", key)
+		}
+	}
+
+	// Mismatched weight slice is an error
+	if _, err := builder.BuildWeightedRange(gs, range0, []float64{1}, range1, nil); err == nil {
+		t.Error("expected error for mismatched weights length")
+	}
+}
+
+func TestBuilder_SuitIsomorphism(t *testing.T) {
+	board, err := cards.ParseCards("Th9h2c7h")
+	if err != nil {
+		t.Fatalf("Failed to parse board: %v", err)
+	}
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board,
+		ToAct: 0,
+	}
+
+	range0, _ := notation.ParseRange("AKs")
+	range1, _ := notation.ParseRange("QQ")
+
+	rootInfoSets := func(iso bool) map[string]bool {
+		builder := NewBuilder(DefaultRiverConfig())
+		builder.SetSuitIsomorphism(iso)
+		root, err := builder.BuildRange(gs, range0, range1)
+		if err != nil {
+			t.Fatalf("BuildRange failed: %v", err)
+		}
+
+		infoSets := make(map[string]bool)
+		for _, child := range root.Children {
+			infoSets[child.InfoSet] = true
+		}
+		return infoSets
+	}
+
+	// Without isomorphism each AKs combo has its own info set
+	if got := len(rootInfoSets(false)); got != 4 {
+		t.Errorf("Expected 4 root info sets without isomorphism, got %d", got)
+	}
+
+	// Spades and diamonds are absent from the board, so AsKs and AdKd collapse
+	if got := len(rootInfoSets(true)); got != 3 {
+		t.Errorf("Expected 3 root info sets with isomorphism, got %d", got)
+	}
+}
+
+func TestBuilder_BuildRange_ParallelMatchesSerial(t *testing.T) {
+	board, err := cards.ParseCards("Th9h2c5d8s")
+	if err != nil {
+		t.Fatalf("Failed to parse board: %v", err)
+	}
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board,
+		ToAct: 0,
+	}
+
+	// 12 × 12 combos
+	range0, _ := notation.ParseRange("AA,KK")
+	range1, _ := notation.ParseRange("QQ,JJ")
+
+	serialBuilder := NewBuilder(DefaultRiverConfig())
+	serialBuilder.SetWorkers(1)
+	serial, err := serialBuilder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("serial BuildRange failed: %v", err)
+	}
+
+	parallelBuilder := NewBuilder(DefaultRiverConfig())
+	parallelBuilder.SetWorkers(8)
+	parallel, err := parallelBuilder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("parallel BuildRange failed: %v", err)
+	}
+
+	if len(parallel.Children) != 144 || len(serial.Children) != 144 {
+		t.Fatalf("Expected 144 children, got serial=%d parallel=%d", len(serial.Children), len(parallel.Children))
+	}
+
+	for key, prob := range serial.ChanceProbabilities {
+		if parallel.ChanceProbabilities[key] != prob {
+			t.Errorf("Probability for %s: serial=%.6f parallel=%.6f", key, prob, parallel.ChanceProbabilities[key])
+		}
+		if _, ok := parallel.Children[key]; !ok {
+			t.Errorf("Parallel build missing child %s", key)
+		}
+	}
+
+	// Subtrees are identical too
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("Parallel tree differs from serial tree")
+	}
+}
+
+// benchmarkRangeSpot is a 12 × 12 river range spot with no card conflicts
+func benchmarkRangeSpot(b *testing.B) (*notation.GameState, []notation.Combo, []notation.Combo) {
+	b.Helper()
+	board, err := cards.ParseCards("Th9h2c5d8s")
+	if err != nil {
+		b.Fatalf("Failed to parse board: %v", err)
+	}
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board,
+		ToAct: 0,
+	}
+	range0, _ := notation.ParseRange("AA,KK")
+	range1, _ := notation.ParseRange("QQ,JJ")
+	return gs, range0, range1
+}
+
+func BenchmarkBuildRange_12x12(b *testing.B) {
+	gs, range0, range1 := benchmarkRangeSpot(b)
+	builder := NewBuilder(DefaultRiverConfig())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := builder.BuildRange(gs, range0, range1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// validateCardsMap is the map-based check validateCards used before CardMask,
+// kept so BenchmarkValidateCards has a baseline to compare against
+func validateCardsMap(board []cards.Card, combo0, combo1 notation.Combo) error {
+	seen := make(map[cards.Card]bool)
+	for _, card := range append(append([]cards.Card{}, board...), combo0.Card1, combo0.Card2, combo1.Card1, combo1.Card2) {
+		if seen[card] {
+			return fmt.Errorf("duplicate card: %v", card)
+		}
+		seen[card] = true
+	}
+	return nil
+}
+
+// BenchmarkValidateCards runs the card check over every pair of a 12 × 12 range build
+func BenchmarkValidateCards(b *testing.B) {
+	gs, range0, range1 := benchmarkRangeSpot(b)
+	builder := NewBuilder(DefaultRiverConfig())
+
+	b.Run("mask", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, c0 := range range0 {
+				for _, c1 := range range1 {
+					_ = builder.validateCards(gs.Board, c0, c1)
+				}
+			}
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, c0 := range range0 {
+				for _, c1 := range range1 {
+					_ = validateCardsMap(gs.Board, c0, c1)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkBuildRange_InfoSetFormat compares key formats on the 12 × 12 river build
+func BenchmarkBuildRange_InfoSetFormat(b *testing.B) {
+	gs, range0, range1 := benchmarkRangeSpot(b)
+	for _, format := range []struct {
+		name   string
+		format InfoSetFormat
+	}{
+		{"readable", ReadableInfoSets},
+		{"compact", CompactInfoSets},
+	} {
+		b.Run(format.name, func(b *testing.B) {
+			builder := NewBuilder(DefaultRiverConfig())
+			builder.SetInfoSetFormat(format.format)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := builder.BuildRange(gs, range0, range1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package tree
 
 import (
+	"math"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -158,6 +162,39 @@ func TestBuilder_Build_DuplicateCards(t *testing.T) {
 	}
 }
 
+func TestBuilder_ValidateCards(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	combo := func(s string) notation.Combo {
+		c, _ := cards.ParseCards(s)
+		return notation.Combo{Card1: c[0], Card2: c[1]}
+	}
+
+	tests := []struct {
+		name    string
+		board   []cards.Card
+		combo0  string
+		combo1  string
+		wantErr bool
+	}{
+		{"no conflicts", board, "AsAh", "QdJd", false},
+		{"no board", nil, "AsAh", "QdJd", false},
+		{"combo uses board card", board, "KhQh", "QdJd", true},
+		{"combos share a card", board, "AsAh", "AhKd", true},
+		{"paired combo card", board, "AsAs", "QdJd", true},
+		{"duplicate board card", append(append([]cards.Card{}, board[:4]...), board[0]), "AsAh", "QdJd", true},
+	}
+
+	builder := NewBuilder(DefaultRiverConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := builder.validateCards(tt.board, combo(tt.combo0), combo(tt.combo1))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestBuilder_ShowdownPayoffs(t *testing.T) {
 	config := DefaultRiverConfig()
 	builder := NewBuilder(config)
@@ -184,15 +221,14 @@ func TestBuilder_ShowdownPayoffs(t *testing.T) {
 	// Calculate showdown payoffs
 	payoffs := builder.calculateShowdownPayoffs(board, [2]notation.Combo{combo0, combo1}, 100)
 
-	// Verify it's zero-sum (or sums to pot)
+	// Net payoffs are zero-sum
 	sum := payoffs[0] + payoffs[1]
-	if sum != 100 {
-		t.Errorf("payoffs should sum to pot (100), got %.1f", sum)
+	if sum != 0 {
+		t.Errorf("payoffs should be zero-sum, got %.1f", sum)
 	}
 
-	// One player should win
-	if payoffs[0] != 100 && payoffs[1] != 100 && payoffs[0] != 50 {
-		// Either someone wins or it's a split
+	// One player wins half the pot from the other, or it's a split
+	if payoffs[0] != 50 && payoffs[1] != 50 && payoffs[0] != 0 {
 		t.Errorf("unexpected payoffs: [%.1f, %.1f]", payoffs[0], payoffs[1])
 	}
 }
@@ -247,6 +283,40 @@ func TestBuilder_FoldPayoffs(t *testing.T) {
 	}
 }
 
+func TestBuilder_FoldPayoffs_DeadMoney(t *testing.T) {
+	builder := NewBuilder(ActionConfig{BetSizes: []float64{0.5}, AllowCheck: true, AllowCall: true, AllowFold: true})
+	combo0 := notation.Combo{Card1: cards.NewCard(cards.Ace, cards.Diamonds), Card2: cards.NewCard(cards.Ace, cards.Clubs)}
+	combo1 := notation.Combo{Card1: cards.NewCard(cards.Queen, cards.Diamonds), Card2: cards.NewCard(cards.Queen, cards.Hearts)}
+
+	// 12bb pot with 2bb of antes; BTN bets 6 and BB folds. The 6 comes back, the
+	// antes stay in the pot: BB loses only their 5bb stake and BTN nets 7.
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 94},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:       18,
+		DeadMoney: 2,
+		Board:     makeRiverBoard(),
+		ActionHistory: []notation.Action{
+			{Type: notation.Bet, Amount: 6},
+			{Type: notation.Fold},
+		},
+		ToAct: 0,
+	}
+
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	if !root.IsTerminal {
+		t.Fatal("expected terminal node after fold")
+	}
+	if root.Payoff != [2]float64{7, -5} || root.Dead != 2 {
+		t.Errorf("got payoffs %v dead %.1f, want [7 -5] dead 2.0", root.Payoff, root.Dead)
+	}
+}
+
 func TestBuilder_IsShowdown(t *testing.T) {
 	config := DefaultRiverConfig()
 	builder := NewBuilder(config)
@@ -321,6 +391,12 @@ func TestBuilder_GetCallAmount(t *testing.T) {
 			stack:   100,
 			want:    15, // Still the bet amount
 		},
+		{
+			name:    "after bet and raise",
+			history: []notation.Action{{Type: notation.Bet, Amount: 10}, {Type: notation.Raise, Amount: 30}},
+			stack:   90,
+			want:    20, // Raise to 30 minus our 10 already in
+		},
 		{
 			name:    "capped by stack",
 			history: []notation.Action{{Type: notation.Bet, Amount: 50}},
@@ -416,3 +492,917 @@ func makeRiverBoard() []cards.Card {
 		cards.NewCard(cards.Two, cards.Spades),
 	}
 }
+
+func TestBuilder_RaiseFacingBet(t *testing.T) {
+	// BTN bets 10 into 20, BB can fold/call/raise 3× (to 30)
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot: 20,
+		Board: []cards.Card{
+			cards.NewCard(cards.King, cards.Hearts),
+			cards.NewCard(cards.Nine, cards.Spades),
+			cards.NewCard(cards.Four, cards.Clubs),
+			cards.NewCard(cards.Seven, cards.Diamonds),
+			cards.NewCard(cards.Two, cards.Spades),
+		},
+		ToAct:  0,
+		Street: notation.River,
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	config := ActionConfig{
+		BetSizes:            []float64{0.5},
+		AllowCheck:          true,
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{3.0},
+	}
+
+	builder := NewBuilder(config)
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// BTN bets 10 (half pot)
+	facingBet := root.Children["b10.0"]
+	if facingBet == nil {
+		t.Fatalf("expected b10.0 child, got %v", root.Children)
+	}
+	if facingBet.Pot != 30 {
+		t.Errorf("pot after bet should be 30, got %.1f", facingBet.Pot)
+	}
+
+	// Facing the bet: fold, call, raise to 30, raise all-in to 100
+	expected := []notation.Action{
+		{Type: notation.Fold},
+		{Type: notation.Call},
+		{Type: notation.Raise, Amount: 30},
+		{Type: notation.Raise, Amount: 100},
+	}
+	if len(facingBet.Actions) != len(expected) {
+		t.Fatalf("expected %d actions facing bet, got %v", len(expected), facingBet.Actions)
+	}
+	for i, want := range expected {
+		if facingBet.Actions[i] != want {
+			t.Errorf("action %d: expected %v, got %v", i, want, facingBet.Actions[i])
+		}
+	}
+
+	// Raise to 30 puts 30 in: pot 60, BB stack 70
+	raised := facingBet.Children["r30.0"]
+	if raised == nil {
+		t.Fatal("expected r30.0 child")
+	}
+	if raised.Pot != 60 {
+		t.Errorf("pot after raise should be 60, got %.1f", raised.Pot)
+	}
+	if raised.Stacks[1] != 70 {
+		t.Errorf("BB stack after raise should be 70, got %.1f", raised.Stacks[1])
+	}
+
+	// BTN calls 20 more (30 minus the 10 already in): pot 80, both stacks 70
+	called := raised.Children["c"]
+	if called == nil || !called.IsTerminal {
+		t.Fatal("expected terminal call node after raise")
+	}
+	if called.Pot != 80 {
+		t.Errorf("pot after raise-call should be 80, got %.1f", called.Pot)
+	}
+	if called.Stacks[0] != 70 || called.Stacks[1] != 70 {
+		t.Errorf("stacks after raise-call should be 70/70, got %.1f/%.1f", called.Stacks[0], called.Stacks[1])
+	}
+}
+
+func TestBuilder_HistoryRoundTrip(t *testing.T) {
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot: 10,
+		Board: []cards.Card{
+			cards.NewCard(cards.King, cards.Hearts),
+			cards.NewCard(cards.Nine, cards.Spades),
+			cards.NewCard(cards.Four, cards.Clubs),
+			cards.NewCard(cards.Seven, cards.Diamonds),
+			cards.NewCard(cards.Two, cards.Spades),
+		},
+		ToAct:  0,
+		Street: notation.River,
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	config := ActionConfig{
+		BetSizes:            []float64{0.33, 0.75},
+		AllowCheck:          true,
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{2.5},
+	}
+
+	root, err := NewBuilder(config).Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// Walk every path; the concatenated action keys must parse back to the same actions
+	paths := 0
+	var walk func(node *TreeNode, history []notation.Action)
+	walk = func(node *TreeNode, history []notation.Action) {
+		historyStr := HistoryString(history)
+
+		parsed, err := notation.ParseHistory(historyStr)
+		if err != nil {
+			t.Fatalf("ParseHistory(%q) failed: %v", historyStr, err)
+		}
+		if len(parsed) != len(history) {
+			t.Fatalf("ParseHistory(%q) gave %d actions, want %d", historyStr, len(parsed), len(history))
+		}
+		for i := range history {
+			if parsed[i].Type != history[i].Type || ActionKey(parsed[i]) != ActionKey(history[i]) {
+				t.Errorf("ParseHistory(%q) action %d = %v, want %v", historyStr, i, parsed[i], history[i])
+			}
+		}
+		paths++
+
+		// Decision node info sets embed the same history string
+		if !node.IsTerminal && !node.IsChance {
+			parts := strings.Split(node.InfoSet, "|")
+			if len(parts) != 4 || parts[1] != historyStr {
+				t.Errorf("InfoSet %q doesn't embed history %q", node.InfoSet, historyStr)
+			}
+		}
+
+		for _, action := range node.Actions {
+			child := node.Children[ActionKey(action)]
+			walk(child, append(append([]notation.Action{}, history...), action))
+		}
+	}
+	walk(root, nil)
+
+	if paths < 10 {
+		t.Errorf("expected to walk many paths, got %d", paths)
+	}
+}
+
+func TestBuilder_CheckRaiseCallPot(t *testing.T) {
+	// BTN checks, BB bets 5 into 10, BTN raises to 12.5 (2.5×), BB calls 7.5 more
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot: 10,
+		Board: []cards.Card{
+			cards.NewCard(cards.King, cards.Hearts),
+			cards.NewCard(cards.Nine, cards.Spades),
+			cards.NewCard(cards.Four, cards.Clubs),
+			cards.NewCard(cards.Seven, cards.Diamonds),
+			cards.NewCard(cards.Two, cards.Spades),
+		},
+		ToAct:  0,
+		Street: notation.River,
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	config := ActionConfig{
+		BetSizes:            []float64{0.5},
+		AllowCheck:          true,
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{2.5},
+	}
+
+	root, err := NewBuilder(config).Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	history, err := notation.ParseHistory("xb5.0r12.5c")
+	if err != nil {
+		t.Fatalf("ParseHistory failed: %v", err)
+	}
+
+	node := root
+	for _, action := range history {
+		next, ok := node.Children[ActionKey(action)]
+		if !ok {
+			t.Fatalf("missing child %s at %s", ActionKey(action), node.InfoSet)
+		}
+		node = next
+	}
+
+	// 10 + 5 (bet) + 12.5 (raise to) + 7.5 (call the difference) = 35
+	if !node.IsTerminal || node.Pot != 35 {
+		t.Errorf("expected terminal pot 35 after check-raise-call, got terminal=%v pot=%.1f", node.IsTerminal, node.Pot)
+	}
+	if node.Stacks[0] != 87.5 || node.Stacks[1] != 87.5 {
+		t.Errorf("expected stacks 87.5/87.5, got %.1f/%.1f", node.Stacks[0], node.Stacks[1])
+	}
+}
+
+func TestBuilder_ShoveOnlyTreeSize(t *testing.T) {
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 20},
+			{Position: notation.BB, Stack: 20},
+		},
+		Pot: 10,
+		Board: []cards.Card{
+			cards.NewCard(cards.King, cards.Hearts),
+			cards.NewCard(cards.Nine, cards.Spades),
+			cards.NewCard(cards.Four, cards.Clubs),
+			cards.NewCard(cards.Seven, cards.Diamonds),
+			cards.NewCard(cards.Two, cards.Spades),
+		},
+		ToAct:  0,
+		Street: notation.River,
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	root, err := NewBuilder(ActionConfig{BetSizes: []float64{0.5, 1.0}, ShoveOnly: true}).Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// x → {x (showdown), b20 → {f, c}}, b20 → {f, c}
+	// 1 root + 1 check node + 1 showdown + 1 check-shove node + 2 + 1 shove node + 2 = 9
+	var count func(node *TreeNode) int
+	count = func(node *TreeNode) int {
+		n := 1
+		for _, child := range node.Children {
+			n += count(child)
+		}
+		return n
+	}
+	if got := count(root); got != 9 {
+		t.Errorf("expected 9 nodes in shove-only river tree, got %d", got)
+	}
+
+	shove := root.Children["b20.0"]
+	if shove == nil {
+		t.Fatalf("expected all-in child b20.0, got children %v", root.Children)
+	}
+	call := shove.Children["c"]
+	if call == nil || !call.IsTerminal || call.Pot != 50 {
+		t.Errorf("expected terminal pot 50 after shove and call, got %v", call)
+	}
+}
+
+// lowCardEvaluator is a stub where the lowest first hole card wins
+type lowCardEvaluator struct{}
+
+func (lowCardEvaluator) Evaluate(hole []cards.Card, board []cards.Card) cards.HandValue {
+	return cards.HandValue{Rank: cards.HighCard, Values: [5]cards.Rank{cards.Ace - hole[0].Rank}}
+}
+
+func TestBuilder_SetEvaluator(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	combos := [2]notation.Combo{
+		{Card1: cards.NewCard(cards.Ace, cards.Diamonds), Card2: cards.NewCard(cards.Ace, cards.Clubs)},
+		{Card1: cards.NewCard(cards.Queen, cards.Diamonds), Card2: cards.NewCard(cards.Queen, cards.Hearts)},
+	}
+
+	builder := NewBuilder(DefaultRiverConfig())
+	if payoffs := builder.calculateShowdownPayoffs(board, combos, 100); payoffs != [2]float64{50, -50} {
+		t.Errorf("default evaluator: AA should win, got %v", payoffs)
+	}
+
+	builder.SetEvaluator(lowCardEvaluator{})
+	if payoffs := builder.calculateShowdownPayoffs(board, combos, 100); payoffs != [2]float64{-50, 50} {
+		t.Errorf("stub evaluator: QQ should win, got %v", payoffs)
+	}
+
+	// Rollout terminals carry the evaluator for solvers to run the board out with
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board[:4],
+		ToAct: 0,
+	}
+	root, err := builder.Build(gs, combos[0], combos[1])
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	rollout := root.Children["x"].Children["x"]
+	if !rollout.NeedsRollout || rollout.Evaluator != (lowCardEvaluator{}) {
+		t.Errorf("check-check rollout evaluator = %#v, want the stub", rollout.Evaluator)
+	}
+}
+
+func TestBuilder_OmahaAndShortDeck(t *testing.T) {
+	config := ActionConfig{AllowCheck: true}
+
+	// PLO: BTN's As makes a Hold'em flush, but Omaha needs two hole spades, so
+	// BB's 7s6s flush beats BTN's set of kings
+	gs, err := notation.ParsePosition("BTN:AsAhKdKc:S100/BB:7s6sTdTc:S100|P10|2s5s9sJdKh|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	builder := NewBuilder(config)
+	builder.SetEvaluator(cards.OmahaEvaluator{})
+	root, err := builder.BuildRange(gs, gs.Players[0].Range, gs.Players[1].Range)
+	if err != nil {
+		t.Fatalf("BuildRange() error = %v", err)
+	}
+	pair := root.Children["AsAhKdKc:TdTc7s6s"]
+	if pair == nil {
+		t.Fatalf("missing Omaha combo pair, got children %v", root.Children)
+	}
+	if want := "2s5s9sJdKh||>BTN|AsAhKdKc"; pair.InfoSet != want {
+		t.Errorf("Omaha info set = %q, want %q", pair.InfoSet, want)
+	}
+	if payoff := pair.Children["x"].Children["x"].Payoff; payoff != [2]float64{-5, 5} {
+		t.Errorf("Omaha check-check payoff = %v, want BB's flush to win", payoff)
+	}
+
+	// Short deck: the turn deals only from the 36-card deck, and a flush beats
+	// a full house
+	gs, err = notation.ParsePosition("BTN:AhKh:S100/BB:TsTd:S100|P10|6h9hTh/6s|>BTN")
+	if err != nil {
+		t.Fatalf("ParsePosition() error = %v", err)
+	}
+	builder = NewBuilder(config)
+	builder.SetEvaluator(cards.ShortDeckEvaluator{})
+	builder.SetMultiStreet(true)
+	combo0, combo1 := gs.Players[0].Range[0], gs.Players[1].Range[0]
+	root, err = builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	deal := root.Children["x"].Children["x"]
+	if len(deal.Children) != 36-4-4 {
+		t.Errorf("short-deck river deals %d cards, want %d", len(deal.Children), 36-4-4)
+	}
+	for key := range deal.Children {
+		if card, _ := cards.ParseCard(key); card.Rank < cards.Six {
+			t.Errorf("short-deck river dealt %s", key)
+		}
+	}
+	if payoff := deal.Children["7c"].Children["x"].Children["x"].Payoff; payoff[0] <= 0 {
+		t.Errorf("short-deck flush vs full house payoff = %v, want BTN to win", payoff)
+	}
+
+	// A card below Six isn't in the short deck
+	low := notation.Combo{Card1: cards.NewCard(cards.Five, cards.Hearts), Card2: cards.NewCard(cards.Five, cards.Spades)}
+	if _, err := builder.Build(gs, combo0, low); err == nil {
+		t.Error("expected an error for a combo with a Five in short deck")
+	}
+}
+
+func TestBuilder_FoldPayoffsRefundUncalledBet(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.River,
+	}
+	combo0, _ := cards.ParseCards("AdAc")
+	combo1, _ := cards.ParseCards("QdQh")
+
+	config := ActionConfig{
+		BetSizes:            []float64{0.5},
+		RaiseSizesFacingBet: []float64{3.0},
+		AllowCheck:          true,
+		AllowCall:           true,
+		AllowFold:           true,
+	}
+	root, err := NewBuilder(config).Build(gs,
+		notation.Combo{Card1: combo0[0], Card2: combo0[1]},
+		notation.Combo{Card1: combo1[0], Card2: combo1[1]})
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// BTN bets 5 into 10, BB folds: the bet is refunded, so BTN nets the BB's
+	// half of the pre-bet pot rather than the 15bb pot it invested 5+5 in
+	betFold := root.Children["b5.0"].Children["f"]
+	const preBetPot, invested = 10.0, 5.0
+	if want := [2]float64{preBetPot / 2, -preBetPot / 2}; betFold.Payoff != want {
+		t.Errorf("bet-fold payoffs = %v, want %v", betFold.Payoff, want)
+	}
+	if gross := betFold.Pot - invested - preBetPot/2; betFold.Payoff[0] != gross {
+		t.Errorf("bet-fold winner nets %.1f, want pot minus investment %.1f", betFold.Payoff[0], gross)
+	}
+
+	// BTN bets 5, BB raises to 15, BTN folds: 10 of the raise is uncalled,
+	// so BB wins the pre-bet share plus BTN's 5
+	raiseFold := root.Children["b5.0"].Children["r15.0"].Children["f"]
+	if want := [2]float64{-10, 10}; raiseFold.Payoff != want {
+		t.Errorf("bet-raise-fold payoffs = %v, want %v", raiseFold.Payoff, want)
+	}
+
+	// Called bets are on the same scale: AA wins the BB's half plus the call
+	betCall := root.Children["b5.0"].Children["c"]
+	if want := [2]float64{10, -10}; betCall.Payoff != want {
+		t.Errorf("bet-call payoffs = %v, want %v", betCall.Payoff, want)
+	}
+}
+
+func TestBuilder_MultiStreet(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.Flop,
+	}
+	hole0, _ := cards.ParseCards("AdAc")
+	hole1, _ := cards.ParseCards("QdQh")
+	combo0 := notation.Combo{Card1: hole0[0], Card2: hole0[1]}
+	combo1 := notation.Combo{Card1: hole1[0], Card2: hole1[1]}
+
+	config := ActionConfig{
+		BetSizes:   []float64{0.5},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+	}
+
+	// Default: a flop check-check ends in a rollout
+	root, err := NewBuilder(config).Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	if !root.Children["x"].Children["x"].NeedsRollout {
+		t.Errorf("default flop check-check should be a rollout node")
+	}
+
+	builder := NewBuilder(config)
+	builder.SetMultiStreet(true)
+	root, err = builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// Flop check-check deals the turn: 52 - 3 board - 4 hole cards
+	deal := root.Children["x"].Children["x"]
+	if !deal.IsChance {
+		t.Fatalf("flop check-check should be a chance node, got %v", deal)
+	}
+	if len(deal.Children) != 45 {
+		t.Errorf("turn deal has %d children, want 45", len(deal.Children))
+	}
+
+	turn := deal.Children["7d"]
+	if turn == nil || turn.IsTerminal || turn.IsChance {
+		t.Fatalf("turn 7d should be a decision node, got %v", turn)
+	}
+	if prob := deal.ChanceProbabilities["7d"]; math.Abs(prob-1.0/45) > 1e-12 {
+		t.Errorf("turn card probability = %v, want 1/45", prob)
+	}
+	if turn.Player != 0 || len(turn.Board) != 4 || turn.Pot != 10 {
+		t.Errorf("turn node player=%d board=%v pot=%v, want BTN on 4 cards with pot 10", turn.Player, turn.Board, turn.Pot)
+	}
+	if !strings.Contains(turn.InfoSet, "|xx/|") {
+		t.Errorf("turn info set %q should record the flop line", turn.InfoSet)
+	}
+
+	// Bet-call on the turn deals the river; river check-check is a showdown
+	river := turn.Children["b5.0"].Children["c"]
+	if !river.IsChance || len(river.Children) != 44 {
+		t.Fatalf("turn bet-call should deal 44 rivers, got %v", river)
+	}
+	riverNode := river.Children["2s"]
+	if riverNode.Pot != 20 || !strings.Contains(riverNode.InfoSet, "|xx/b5.0c/|") {
+		t.Errorf("river node pot=%v info set=%q", riverNode.Pot, riverNode.InfoSet)
+	}
+	showdown := riverNode.Children["x"].Children["x"]
+	if !showdown.IsTerminal || showdown.NeedsRollout {
+		t.Errorf("river check-check should be an evaluated terminal, got %v", showdown)
+	}
+	if want := [2]float64{10, -10}; showdown.Payoff != want {
+		t.Errorf("river showdown payoffs = %v, want %v", showdown.Payoff, want)
+	}
+}
+
+func TestBuilder_MultiStreet_AllInCall(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 20},
+			{Position: notation.BB, Stack: 20},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.Flop,
+	}
+	hole0, _ := cards.ParseCards("AdAc")
+	hole1, _ := cards.ParseCards("QdQh")
+	combo0 := notation.Combo{Card1: hole0[0], Card2: hole0[1]}
+	combo1 := notation.Combo{Card1: hole1[0], Card2: hole1[1]}
+
+	builder := NewBuilder(ActionConfig{
+		BetSizes:   []float64{0.5, 2.0},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+	})
+	builder.SetMultiStreet(true)
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// A called half-pot bet leaves 15 behind: the turn is dealt and betting goes on
+	called := root.Children["b5.0"].Children["c"]
+	if !called.IsChance || len(called.Children) != 45 {
+		t.Fatalf("flop bet-call with chips behind should deal the turn, got %v", called)
+	}
+	if turn := called.Children["7d"]; turn.IsTerminal || turn.IsChance || len(turn.Actions) < 2 {
+		t.Errorf("turn after a non-all-in call should be a betting decision, got %v", turn)
+	}
+
+	// A called shove closes the action: no more betting, the board runs out
+	shove := root.Children["b20.0"].Children["c"]
+	if !shove.IsTerminal || !shove.NeedsRollout {
+		t.Fatalf("flop all-in call should end in a rollout, got %v", shove)
+	}
+	if shove.Stacks != [2]float64{0, 0} || shove.Pot != 50 {
+		t.Errorf("all-in rollout stacks=%v pot=%v, want [0 0] and 50", shove.Stacks, shove.Pot)
+	}
+}
+
+func TestBuilder_FixedRunouts(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.Flop,
+	}
+	hole0, _ := cards.ParseCards("AdAc")
+	hole1, _ := cards.ParseCards("QdQh")
+	combo0 := notation.Combo{Card1: hole0[0], Card2: hole0[1]}
+	combo1 := notation.Combo{Card1: hole1[0], Card2: hole1[1]}
+
+	// Ad is in BTN's hand, so only 7d and 2s can come
+	runout, _ := cards.ParseCards("7d2sAd")
+	builder := NewBuilder(ActionConfig{
+		BetSizes:   []float64{0.5},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+		Runouts:    FixedRunouts(runout...),
+	})
+	builder.SetMultiStreet(true)
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	turnDeal := root.Children["x"].Children["x"]
+	if !turnDeal.IsChance {
+		t.Fatalf("flop check-check should deal the turn, got %v", turnDeal)
+	}
+	if !reflect.DeepEqual(sortedKeys(turnDeal.Children), []string{"2s", "7d"}) {
+		t.Errorf("turn cards = %v, want [2s 7d]", sortedKeys(turnDeal.Children))
+	}
+	if prob := turnDeal.ChanceProbabilities["7d"]; prob != 0.5 {
+		t.Errorf("turn 7d probability = %v, want 0.5", prob)
+	}
+
+	// The river can only be the fixed card that didn't come on the turn
+	riverDeal := turnDeal.Children["7d"].Children["x"].Children["x"]
+	if !riverDeal.IsChance {
+		t.Fatalf("turn check-check should deal the river, got %v", riverDeal)
+	}
+	if !reflect.DeepEqual(sortedKeys(riverDeal.Children), []string{"2s"}) {
+		t.Errorf("river cards = %v, want [2s]", sortedKeys(riverDeal.Children))
+	}
+	if prob := riverDeal.ChanceProbabilities["2s"]; prob != 1 {
+		t.Errorf("river 2s probability = %v, want 1", prob)
+	}
+}
+
+func TestBuilder_SampledRunoutsSharedAcrossPairs(t *testing.T) {
+	board, _ := cards.ParseCards("2c3d7h")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.Flop,
+	}
+	range0, _ := notation.ParseRange("AA,KK")
+	range1, _ := notation.ParseRange("QQ,44")
+
+	runouts := SampledRunouts(6, 3)
+	builder := NewBuilder(ActionConfig{AllowCheck: true, AllowCall: true, AllowFold: true, Runouts: runouts})
+	builder.SetMultiStreet(true)
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange() failed: %v", err)
+	}
+
+	// Every pair is dealt the same sample, less the cards in its own hands
+	deck := cards.NewDeck()
+	deck.Remove(board...)
+	sample := runouts.Deal(deck.Cards(), 0)
+	blocked := 0
+	for key, pair := range root.Children {
+		turnDeal := pair.Children["x"].Children["x"]
+		if !turnDeal.IsChance {
+			t.Fatalf("%s: flop check-check should deal the turn", key)
+		}
+		holeCards, err := cards.ParseCards(strings.Replace(key, ":", "", 1))
+		if err != nil || len(holeCards) != 4 {
+			t.Fatalf("%s: can't parse combo pair", key)
+		}
+		held := cards.NewCardMask(holeCards...)
+		if held.Overlaps(cards.NewCardMask(sample...)) {
+			blocked++
+		}
+		var want []string
+		for _, card := range sample {
+			if !held.Has(card) {
+				want = append(want, card.String())
+			}
+		}
+		sort.Strings(want)
+		if got := sortedKeys(turnDeal.Children); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: turn cards %v, want %v", key, got, want)
+		}
+	}
+	if blocked == 0 {
+		t.Errorf("no combo pair holds a card from sample %v", sample)
+	}
+}
+
+// sortedKeys returns a node's child keys in sorted order
+func sortedKeys(children map[string]*TreeNode) []string {
+	keys := make([]string, 0, len(children))
+	for key := range children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestBuilder_AllowDonk(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.Flop,
+	}
+	hole0, _ := cards.ParseCards("AdAc")
+	hole1, _ := cards.ParseCards("QdQh")
+	combo0 := notation.Combo{Card1: hole0[0], Card2: hole0[1]}
+	combo1 := notation.Combo{Card1: hole1[0], Card2: hole1[1]}
+
+	build := func(allowDonk bool) *TreeNode {
+		builder := NewBuilder(ActionConfig{
+			BetSizes:   []float64{0.5},
+			AllowCheck: true,
+			AllowCall:  true,
+			AllowFold:  true,
+			AllowDonk:  allowDonk,
+		})
+		builder.SetMultiStreet(true)
+		root, err := builder.Build(gs, combo0, combo1)
+		if err != nil {
+			t.Fatalf("Build() failed: %v", err)
+		}
+		return root
+	}
+
+	canBet := func(node *TreeNode) bool {
+		for _, action := range node.Actions {
+			if action.Type == notation.Bet {
+				return true
+			}
+		}
+		return false
+	}
+
+	// BTN checks, BB bets, BTN calls: BTN leads the turn into the flop aggressor
+	root := build(false)
+	if !canBet(root) {
+		t.Errorf("flop lead should still allow a bet, got %v", root.Actions)
+	}
+	donk := root.Children["x"].Children["b5.0"].Children["c"].Children["7d"]
+	if donk.Player != 0 || len(donk.Actions) != 1 || donk.Actions[0].Type != notation.Check {
+		t.Errorf("donk node for player %d offers %v, want only check", donk.Player, donk.Actions)
+	}
+
+	// The aggressor can still bet after the check, and BTN may fold or call it
+	facing := donk.Children["x"].Children["b10.0"]
+	if facing == nil {
+		t.Fatalf("aggressor should be able to bet after the check, got %v", donk.Children["x"].Actions)
+	}
+	for _, action := range facing.Actions {
+		if action.Type != notation.Fold && action.Type != notation.Call {
+			t.Errorf("facing a bet should offer fold/call, got %v", facing.Actions)
+		}
+	}
+
+	// When BTN took the initiative, leading the turn is a continuation bet
+	cbet := root.Children["b5.0"].Children["c"].Children["7d"]
+	if !canBet(cbet) {
+		t.Errorf("previous aggressor leading should allow a bet, got %v", cbet.Actions)
+	}
+
+	// AllowDonk restores the leading bet
+	donk = build(true).Children["x"].Children["b5.0"].Children["c"].Children["7d"]
+	if !canBet(donk) {
+		t.Errorf("AllowDonk node offers %v, want a bet", donk.Actions)
+	}
+}
+
+func TestBuilder_CanonicalHoleCards(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2d")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.River,
+	}
+	asAh, _ := cards.ParseCards("AsAh")
+	qq, _ := cards.ParseCards("QdQh")
+	opponent := notation.Combo{Card1: qq[0], Card2: qq[1]}
+
+	builder := NewBuilder(DefaultRiverConfig())
+	forward, err := builder.Build(gs, notation.Combo{Card1: asAh[0], Card2: asAh[1]}, opponent)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	reversed, err := builder.Build(gs, notation.Combo{Card1: asAh[1], Card2: asAh[0]}, opponent)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	if forward.InfoSet != reversed.InfoSet {
+		t.Errorf("AsAh info set %q, AhAs info set %q, want identical", forward.InfoSet, reversed.InfoSet)
+	}
+	if !strings.HasSuffix(forward.InfoSet, "|AsAh") {
+		t.Errorf("info set %q should use canonical hole cards AsAh", forward.InfoSet)
+	}
+}
+
+func TestBuilder_Rake(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.River,
+	}
+	aa, _ := cards.ParseCards("AdAc")
+	qq, _ := cards.ParseCards("QdQh")
+	combo0 := notation.Combo{Card1: aa[0], Card2: aa[1]}
+	combo1 := notation.Combo{Card1: qq[0], Card2: qq[1]}
+
+	build := func(pct, rakeCap float64) *TreeNode {
+		config := DefaultRiverConfig()
+		config.BetSizes = []float64{1.0}
+		config.RakePct = pct
+		config.RakeCap = rakeCap
+		root, err := NewBuilder(config).Build(gs, combo0, combo1)
+		if err != nil {
+			t.Fatalf("Build() failed: %v", err)
+		}
+		return root
+	}
+
+	unraked := build(0, 0)
+	raked := build(0.05, 0)
+	capped := build(0.05, 1)
+
+	// Check-check: 5% of the 10bb pot comes out of AA's win; the loser is unaffected
+	plain := unraked.Children["x"].Children["x"].Payoff
+	showdown := raked.Children["x"].Children["x"]
+	if want := [2]float64{plain[0] - 0.5, plain[1]}; showdown.Payoff != want || showdown.Rake != 0.5 {
+		t.Errorf("raked check-check payoffs = %v (rake %v), want %v (rake 0.5)", showdown.Payoff, showdown.Rake, want)
+	}
+
+	// Bet-call: 5% of 30bb is 1.5, bounded by a 1bb cap
+	called := raked.Children["b10.0"].Children["c"]
+	if called.Payoff[0] != 15-1.5 {
+		t.Errorf("raked bet-call winner nets %v, want 13.5", called.Payoff[0])
+	}
+	cappedCall := capped.Children["b10.0"].Children["c"]
+	if cappedCall.Payoff[0] != 15-1 || cappedCall.Rake != 1 {
+		t.Errorf("capped bet-call winner nets %v (rake %v), want 14 (rake 1)", cappedCall.Payoff[0], cappedCall.Rake)
+	}
+
+	// Postflop folds are raked on the contested pot, not the uncalled bet
+	fold := raked.Children["b10.0"].Children["f"]
+	if want := [2]float64{5 - 0.5, -5}; fold.Payoff != want {
+		t.Errorf("raked bet-fold payoffs = %v, want %v", fold.Payoff, want)
+	}
+}
+
+func TestBuilder_BetsCappedAtEffectiveStack(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 40},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.River,
+	}
+	aa, _ := cards.ParseCards("AdAc")
+	qq, _ := cards.ParseCards("QdQh")
+
+	config := DefaultRiverConfig()
+	config.RaiseSizesFacingBet = []float64{3.0}
+	root, err := NewBuilder(config).Build(gs,
+		notation.Combo{Card1: aa[0], Card2: aa[1]},
+		notation.Combo{Card1: qq[0], Card2: qq[1]})
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// The deep stack can't put in more than the short stack can call
+	var walk func(node *TreeNode, history []notation.Action)
+	walk = func(node *TreeNode, history []notation.Action) {
+		if node.IsTerminal || node.IsChance {
+			return
+		}
+		for _, action := range node.Actions {
+			next := append(append([]notation.Action{}, history...), action)
+			if committed := StreetCommitments(next); committed[0] > 40+1e-9 || committed[1] > 40+1e-9 {
+				t.Errorf("line %s commits %v, more than the 40bb effective stack", HistoryString(next), committed)
+			}
+			walk(node.Children[ActionKey(action)], next)
+		}
+	}
+	walk(root, nil)
+
+	if _, ok := root.Children["b40.0"]; !ok {
+		t.Errorf("expected an all-in bet of the 40bb effective stack, got %v", root.Actions)
+	}
+}
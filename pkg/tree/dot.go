@@ -0,0 +1,71 @@
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// WriteDOT writes the tree rooted at n as a Graphviz DOT digraph
+// Nodes are numbered n0, n1, ... in depth-first order with children visited in
+// sorted key order, so the output is stable. Decision nodes are labeled with
+// their info set and pot, chance nodes with their pot and outcome count, and
+// terminals with their pot and payoffs; edges carry the action or chance key.
+// Render with e.g. `dot -Tsvg tree.dot -o tree.svg`.
+func (n *TreeNode) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph tree {")
+	fmt.Fprintln(bw, "  node [fontname=\"monospace\"];")
+
+	next := 0
+	var walk func(node *TreeNode) int
+	walk = func(node *TreeNode) int {
+		id := next
+		next++
+		fmt.Fprintf(bw, "  n%d [%s];\n", id, dotAttributes(node))
+
+		keys := make([]string, 0, len(node.Children))
+		for key := range node.Children {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childID := walk(node.Children[key])
+			label := key
+			if node.IsChance {
+				if p, ok := node.ChanceProbabilities[key]; ok {
+					label = fmt.Sprintf("%s (%.3f)", key, p)
+				}
+			}
+			fmt.Fprintf(bw, "  n%d -> n%d [label=%s];\n", id, childID, strconv.Quote(label))
+		}
+		return id
+	}
+	walk(n)
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotAttributes returns the shape and label attributes for one node
+func dotAttributes(node *TreeNode) string {
+	var shape, label string
+	switch {
+	case node.IsTerminal:
+		shape = "box"
+		label = fmt.Sprintf("pot=%.1f\npayoffs=[%.1f, %.1f]", node.Pot, node.Payoff[0], node.Payoff[1])
+		if node.NeedsRollout {
+			label += "\nrollout"
+		}
+	case node.IsChance:
+		shape = "diamond"
+		label = fmt.Sprintf("chance\npot=%.1f\noutcomes=%d", node.Pot, len(node.Children))
+	default:
+		shape = "ellipse"
+		label = fmt.Sprintf("%s\nP%d pot=%.1f", node.InfoSet, node.Player, node.Pot)
+	}
+	return fmt.Sprintf("shape=%s, label=%s", shape, strconv.Quote(label))
+}
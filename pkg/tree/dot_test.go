@@ -0,0 +1,73 @@
+package tree
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestWriteDOT_RiverTree(t *testing.T) {
+	board, err := cards.ParseCards("Kh9s4c7d2s")
+	if err != nil {
+		t.Fatalf("ParseCards() failed: %v", err)
+	}
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.River,
+	}
+	combo0 := notation.Combo{Card1: cards.NewCard(cards.Ace, cards.Diamonds), Card2: cards.NewCard(cards.Ace, cards.Clubs)}
+	combo1 := notation.Combo{Card1: cards.NewCard(cards.Queen, cards.Diamonds), Card2: cards.NewCard(cards.Queen, cards.Hearts)}
+
+	builder := NewBuilder(ActionConfig{BetSizes: []float64{1.0}, AllowCheck: true, AllowCall: true, AllowFold: true})
+	var root *TreeNode
+	root, err = builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := root.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT() failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "digraph tree {\n") || !strings.HasSuffix(out, "}\n") {
+		t.Fatalf("output is not a digraph:\n%s", out)
+	}
+
+	nodeLine := regexp.MustCompile(`^  n\d+ \[shape=\w+, label=".*"\];$`)
+	edgeLine := regexp.MustCompile(`^  n\d+ -> n\d+ \[label=".*"\];$`)
+	nodes, edges := 0, 0
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n")[2:] {
+		switch {
+		case line == "}":
+		case edgeLine.MatchString(line):
+			edges++
+		case nodeLine.MatchString(line):
+			nodes++
+		default:
+			t.Errorf("unexpected line %q", line)
+		}
+	}
+
+	total := root.Stats().TotalNodes()
+	if nodes != total {
+		t.Errorf("got %d nodes, want %d", nodes, total)
+	}
+	if edges != total-1 {
+		t.Errorf("got %d edges, want %d", edges, total-1)
+	}
+	if !strings.Contains(out, root.InfoSet) {
+		t.Errorf("root info set %q missing from output", root.InfoSet)
+	}
+}
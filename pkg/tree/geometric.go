@@ -19,6 +19,14 @@ type GeometricSizing struct {
 
 	// AllIn stack size (in BB) - maximum bet is capped at this
 	AllIn float64
+
+	// Optional: both players' stacks and chips already invested this street when
+	// the sizing was created at a pot of StartPot (see NewGeometricSizingForStacks).
+	// When Stacks is set, the cap at each later pot is the effective stack left
+	// after the matched bets that grew the pot, instead of the fixed AllIn.
+	Stacks   [2]float64
+	Invested [2]float64
+	StartPot float64
 }
 
 // NewGeometricSizing creates a geometric sizing calculator
@@ -30,6 +38,37 @@ func NewGeometricSizing(targetPot float64, numStreets int, allIn float64) *Geome
 	}
 }
 
+// NewGeometricSizingForStacks creates a geometric sizing calculator for unequal stacks
+// pot is the current pot (including invested chips), stacks are the players' remaining
+// stacks, and invested is what each has already put in this street. AllIn is set to
+// the effective stack: the most new chips both players can still match.
+func NewGeometricSizingForStacks(targetPot float64, numStreets int, pot float64, stacks, invested [2]float64) *GeometricSizing {
+	g := &GeometricSizing{
+		TargetPot:  targetPot,
+		NumStreets: numStreets,
+		Stacks:     stacks,
+		Invested:   invested,
+		StartPot:   pot,
+	}
+	g.AllIn = g.EffectiveStack(pot)
+	return g
+}
+
+// EffectiveStack returns the most either player can bet at currentPot and still be called
+// Without Stacks this is AllIn. With Stacks, bet sizes are only generated once bets
+// are matched, so the pot growth since StartPot was split evenly between the players:
+// each has matched up to M = (currentPot - StartPot + Invested[0] + Invested[1]) / 2,
+// and the shorter total stack has min(Stacks[i]+Invested[i]) - M behind.
+func (g *GeometricSizing) EffectiveStack(currentPot float64) float64 {
+	if g.Stacks == [2]float64{} {
+		return g.AllIn
+	}
+
+	matched := (currentPot - g.StartPot + g.Invested[0] + g.Invested[1]) / 2
+	effective := math.Min(g.Stacks[0]+g.Invested[0], g.Stacks[1]+g.Invested[1]) - matched
+	return math.Max(effective, 0)
+}
+
 // CalculateBetSize calculates the bet size needed to achieve geometric growth
 // Returns the bet size as a fraction of the current pot
 //
@@ -46,10 +85,18 @@ func (g *GeometricSizing) CalculateBetSize(currentPot float64) float64 {
 		return 0
 	}
 
+	allIn := g.EffectiveStack(currentPot)
+
+	// A target beyond the effective stack is unreachable: aim to get all-in instead
+	targetPot := g.TargetPot
+	if g.Stacks != [2]float64{} && targetPot > currentPot+2*allIn {
+		targetPot = currentPot + 2*allIn
+	}
+
 	// Calculate growth factor needed per street
 	// targetPot = currentPot × G^numStreets
 	// G = (targetPot / currentPot)^(1/numStreets)
-	growthFactor := math.Pow(g.TargetPot/currentPot, 1.0/float64(g.NumStreets))
+	growthFactor := math.Pow(targetPot/currentPot, 1.0/float64(g.NumStreets))
 
 	// Bet size fraction that achieves this growth (assuming opponent calls)
 	// After bet+call: pot = currentPot × (1 + 2×fraction) = currentPot × growthFactor
@@ -58,8 +105,8 @@ func (g *GeometricSizing) CalculateBetSize(currentPot float64) float64 {
 	betFraction := (growthFactor - 1.0) / 2.0
 
 	// Cap at all-in
-	if betFraction*currentPot > g.AllIn {
-		betFraction = g.AllIn / currentPot
+	if betFraction*currentPot > allIn {
+		betFraction = allIn / currentPot
 	}
 
 	return betFraction
@@ -103,9 +150,10 @@ func (g *GeometricSizing) CalculateBetSizes(currentPot float64, numSizes int) []
 	}
 
 	// Cap all sizes at all-in
+	allIn := g.EffectiveStack(currentPot)
 	for i := range sizes {
-		if sizes[i]*currentPot > g.AllIn {
-			sizes[i] = g.AllIn / currentPot
+		if sizes[i]*currentPot > allIn {
+			sizes[i] = allIn / currentPot
 		}
 	}
 
@@ -285,3 +285,48 @@ func TestGeometricSizing_RealWorldScenario(t *testing.T) {
 			finalPot, targetPot, tolerance)
 	}
 }
+
+func TestGeometricSizing_UnequalStacks(t *testing.T) {
+	// 100bb vs 40bb on the flop: a 300bb target is out of reach for the short stack
+	stacks := [2]float64{100, 40}
+	pot := 10.0
+	g := NewGeometricSizingForStacks(300, 3, pot, stacks, [2]float64{})
+
+	if g.AllIn != 40 {
+		t.Errorf("AllIn = %v, want the 40bb effective stack", g.AllIn)
+	}
+
+	short := stacks[1]
+	for streetsLeft := 3; streetsLeft >= 1; streetsLeft-- {
+		g.NumStreets = streetsLeft
+		for _, fraction := range g.CalculateBetSizes(pot, 3) {
+			if bet := fraction * pot; bet > short+1e-9 {
+				t.Errorf("%d streets left: bet %.2f exceeds short stack's %.2f", streetsLeft, bet, short)
+			}
+		}
+
+		// Bet the geometric size and get called
+		bet := g.CalculateBetSize(pot) * pot
+		pot += 2 * bet
+		short -= bet
+	}
+
+	// Geometric growth toward the capped target gets the short stack all-in by the river
+	if math.Abs(short) > 1e-9 {
+		t.Errorf("short stack has %.4f left after three geometric bets, want 0", short)
+	}
+
+	// One player already committed: BB has called 10 of BTN's 10bb bet into 10
+	committed := NewGeometricSizingForStacks(300, 2, 30, [2]float64{90, 30}, [2]float64{10, 10})
+	if committed.AllIn != 30 {
+		t.Errorf("committed AllIn = %v, want 30", committed.AllIn)
+	}
+	if bet := committed.CalculateBetSize(30) * 30; bet > 30+1e-9 {
+		t.Errorf("committed bet %.2f exceeds the 30bb behind", bet)
+	}
+
+	// Without stacks, the fixed AllIn cap is unchanged
+	if got := NewGeometricSizing(300, 1, 40).EffectiveStack(500); got != 40 {
+		t.Errorf("EffectiveStack without stacks = %v, want AllIn 40", got)
+	}
+}
@@ -0,0 +1,240 @@
+package tree
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// InfoSetFormat selects how a Builder writes info set keys
+type InfoSetFormat int
+
+const (
+	// ReadableInfoSets writes "board|history|>POS|cards" keys (the default)
+	ReadableInfoSets InfoSetFormat = iota
+
+	// CompactInfoSets byte-packs keys: one byte per card, one opcode byte per
+	// action plus a varint for bet sizes. Every byte is below 0x80, so keys are
+	// valid UTF-8 and survive JSON. Keys are built with a single allocation;
+	// ReadableInfoSet turns them back into the readable form.
+	CompactInfoSets
+)
+
+// compactInfoSetTag starts every compact key; readable keys never begin with it
+const compactInfoSetTag = 0x00
+
+// Compact key sections after the board and before the player
+const (
+	compactHoleCards = 'h'
+	compactBucket    = 'k'
+	compactEnd       = '|'
+)
+
+// compactUintMaxLen is the most bytes appendCompactUint writes for a uint64
+const compactUintMaxLen = 11
+
+// compactUintMore marks a compact varint byte that isn't the last
+const compactUintMore = 0x40
+
+// compactInfoSet encodes the same information as GetInfoSet (or GetInfoSetBucketed
+// when bucketID >= 0) with prior streets' history, as the builder passes it,
+// ahead of history. Layout: tag, board length, board cards, history opcodes,
+// '|', position length, position, then 'h' and two cards or 'k' and a varint.
+func compactInfoSet(board []cards.Card, prior string, history []notation.Action, actingPlayer notation.Position, holeCards []cards.Card, bucketID int) string {
+	buf := make([]byte, 0, 2+len(board)+len(prior)+4*len(history)+2+len(actingPlayer)+compactUintMaxLen)
+	buf = append(buf, compactInfoSetTag, byte(len(board)))
+	for _, card := range board {
+		buf = append(buf, compactCard(card))
+	}
+
+	buf = appendCompactHistoryText(buf, prior)
+	for _, action := range history {
+		buf = appendCompactAction(buf, action.Type, action.Amount)
+	}
+	buf = append(buf, compactEnd, byte(len(actingPlayer)))
+	buf = append(buf, actingPlayer...)
+
+	if bucketID >= 0 {
+		buf = append(buf, compactBucket)
+		buf = appendCompactUint(buf, uint64(bucketID))
+	} else {
+		buf = append(buf, compactHoleCards)
+		for _, card := range holeCards {
+			buf = append(buf, compactCard(card))
+		}
+	}
+	return string(buf)
+}
+
+// compactCard packs a card into one byte (rank*4 + suit)
+func compactCard(card cards.Card) byte {
+	return byte(card.Rank)*4 + byte(card.Suit)
+}
+
+// appendCompactAction appends an action's opcode (its ActionKey letter) and, for
+// bets and raises, the amount in tenths as ActionKey rounds it
+func appendCompactAction(buf []byte, actionType notation.ActionType, amount float64) []byte {
+	switch actionType {
+	case notation.Bet, notation.Raise:
+		op := byte('b')
+		if actionType == notation.Raise {
+			op = 'r'
+		}
+		buf = append(buf, op)
+		return appendCompactUint(buf, uint64(math.Round(notation.RoundAmount(amount)*10)))
+	case notation.Check:
+		return append(buf, 'x')
+	case notation.Call:
+		return append(buf, 'c')
+	case notation.Fold:
+		return append(buf, 'f')
+	default:
+		return append(buf, '?')
+	}
+}
+
+// appendCompactHistoryText encodes a readable history such as "xb5.0c/" action by
+// action; '/' street separators are kept as is
+func appendCompactHistoryText(buf []byte, text string) []byte {
+	for i := 0; i < len(text); i++ {
+		switch op := text[i]; op {
+		case 'b', 'r':
+			j := i + 1
+			for j < len(text) && (text[j] == '.' || (text[j] >= '0' && text[j] <= '9')) {
+				j++
+			}
+			buf = append(buf, op)
+			buf = appendCompactUint(buf, tenths([]byte(text[i+1:j])))
+			i = j - 1
+		default:
+			buf = append(buf, op)
+		}
+	}
+	return buf
+}
+
+// appendCompactUint appends v six bits at a time, low bits first
+// Unlike encoding/binary varints every byte stays below 0x80, which keeps keys
+// valid UTF-8 (encoding/json replaces invalid bytes with U+FFFD).
+func appendCompactUint(buf []byte, v uint64) []byte {
+	for v >= compactUintMore {
+		buf = append(buf, compactUintMore|byte(v&0x3f))
+		v >>= 6
+	}
+	return append(buf, byte(v))
+}
+
+// readCompactUint decodes an appendCompactUint value from the start of s
+// Returns the value and bytes read, or size <= 0 if s is truncated or malformed.
+func readCompactUint(s string) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(s) && i < compactUintMaxLen; i++ {
+		b := s[i]
+		if b >= 0x80 {
+			return 0, -1
+		}
+		v |= uint64(b&0x3f) << (6 * uint(i))
+		if b&compactUintMore == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// tenths reads a decimal with one fractional digit ("12.5") as an integer (125)
+func tenths(digits []byte) uint64 {
+	var n uint64
+	for _, d := range digits {
+		if d >= '0' && d <= '9' {
+			n = n*10 + uint64(d-'0')
+		}
+	}
+	return n
+}
+
+// ReadableInfoSet returns the "board|history|>POS|cards" form of an info set key
+// Readable keys are returned unchanged, so it is safe on keys of either format
+// (e.g. before printing a profile or parsing a key's parts).
+func ReadableInfoSet(key string) (string, error) {
+	if len(key) == 0 || key[0] != compactInfoSetTag {
+		return key, nil
+	}
+
+	bad := func() (string, error) {
+		return "", fmt.Errorf("malformed compact info set %q", key)
+	}
+	if len(key) < 2 {
+		return bad()
+	}
+	var sb strings.Builder
+
+	n := int(key[1])
+	pos := 2
+	if len(key) < pos+n {
+		return bad()
+	}
+	for i := 0; i < n; i++ {
+		sb.WriteString(readableCard(key[pos+i]))
+	}
+	pos += n
+	sb.WriteByte('|')
+
+	for {
+		if pos >= len(key) {
+			return bad()
+		}
+		op := key[pos]
+		pos++
+		if op == compactEnd {
+			break
+		}
+		sb.WriteByte(op)
+		if op == 'b' || op == 'r' {
+			amount, size := readCompactUint(key[pos:])
+			if size <= 0 {
+				return bad()
+			}
+			pos += size
+			sb.WriteString(strconv.FormatUint(amount/10, 10))
+			sb.WriteByte('.')
+			sb.WriteString(strconv.FormatUint(amount%10, 10))
+		}
+	}
+
+	if pos >= len(key) || pos+1+int(key[pos]) > len(key) {
+		return bad()
+	}
+	n = int(key[pos])
+	sb.WriteString("|>")
+	sb.WriteString(key[pos+1 : pos+1+n])
+	sb.WriteByte('|')
+	pos += 1 + n
+
+	if pos >= len(key) {
+		return bad()
+	}
+	switch key[pos] {
+	case compactHoleCards:
+		for _, c := range []byte(key[pos+1:]) {
+			sb.WriteString(readableCard(c))
+		}
+	case compactBucket:
+		bucket, size := readCompactUint(key[pos+1:])
+		if size <= 0 {
+			return bad()
+		}
+		fmt.Fprintf(&sb, "BUCKET_%d", bucket)
+	default:
+		return bad()
+	}
+	return sb.String(), nil
+}
+
+// readableCard unpacks a compactCard byte
+func readableCard(c byte) string {
+	return cards.Card{Rank: cards.Rank(c / 4), Suit: cards.Suit(c % 4)}.String()
+}
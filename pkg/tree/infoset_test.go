@@ -0,0 +1,132 @@
+package tree
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestCompactInfoSet_DecodesToReadable(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c")
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:    10,
+		Board:  board,
+		ToAct:  0,
+		Street: notation.Flop,
+	}
+	hole0, _ := cards.ParseCards("AdAc")
+	hole1, _ := cards.ParseCards("QdQh")
+	combo0 := notation.Combo{Card1: hole0[0], Card2: hole0[1]}
+	combo1 := notation.Combo{Card1: hole1[0], Card2: hole1[1]}
+	runout, _ := cards.ParseCards("7d2s")
+	config := ActionConfig{
+		BetSizes:   []float64{0.33, 1.25},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+		Runouts:    FixedRunouts(runout...),
+	}
+
+	build := func(format InfoSetFormat) *TreeNode {
+		builder := NewBuilder(config)
+		builder.SetMultiStreet(true)
+		builder.SetInfoSetFormat(format)
+		root, err := builder.Build(gs, combo0, combo1)
+		if err != nil {
+			t.Fatalf("Build() failed: %v", err)
+		}
+		return root
+	}
+	readable, compact := build(ReadableInfoSets), build(CompactInfoSets)
+
+	// Walk both trees together; every compact key must decode to the readable one
+	checked := 0
+	var walk func(r, c *TreeNode)
+	walk = func(r, c *TreeNode) {
+		if !r.IsTerminal && !r.IsChance {
+			if len(c.InfoSet) >= len(r.InfoSet) {
+				t.Errorf("compact key for %q is %d bytes, not shorter", r.InfoSet, len(c.InfoSet))
+			}
+			decoded, err := ReadableInfoSet(c.InfoSet)
+			if err != nil {
+				t.Fatalf("ReadableInfoSet(%q) failed: %v", c.InfoSet, err)
+			}
+			if decoded != r.InfoSet {
+				t.Errorf("decoded %q, want %q", decoded, r.InfoSet)
+			}
+			checked++
+		}
+		for key, child := range r.Children {
+			walk(child, c.Children[key])
+		}
+	}
+	walk(readable, compact)
+	if checked < 20 {
+		t.Errorf("only checked %d info sets", checked)
+	}
+
+	// Readable keys pass through unchanged
+	if got, err := ReadableInfoSet(readable.InfoSet); err != nil || got != readable.InfoSet {
+		t.Errorf("ReadableInfoSet(readable) = %q, %v", got, err)
+	}
+}
+
+func TestCompactInfoSet_Bucketed(t *testing.T) {
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	history := []notation.Action{{Type: notation.Check}, {Type: notation.Bet, Amount: 7.5}}
+
+	key := compactInfoSet(board, "", history, notation.BTN, nil, 300)
+	decoded, err := ReadableInfoSet(key)
+	if err != nil {
+		t.Fatalf("ReadableInfoSet failed: %v", err)
+	}
+	if want := GetInfoSetBucketed(board, history, notation.BTN, 300); decoded != want {
+		t.Errorf("decoded %q, want %q", decoded, want)
+	}
+
+	if _, err := ReadableInfoSet(key[:len(key)-3]); err == nil {
+		t.Error("expected an error for a truncated key")
+	}
+}
+
+func TestCompactUint_RoundTripAndUTF8(t *testing.T) {
+	for _, v := range []uint64{0, 1, 63, 64, 127, 128, 1000, 4095, 4096, 1 << 40, math.MaxUint64} {
+		buf := appendCompactUint(nil, v)
+		for _, b := range buf {
+			if b >= 0x80 {
+				t.Errorf("%d encodes to byte %#x", v, b)
+			}
+		}
+		got, size := readCompactUint(string(buf) + "x")
+		if got != v || size != len(buf) {
+			t.Errorf("%d decoded as %d (%d of %d bytes)", v, got, size, len(buf))
+		}
+	}
+
+	// Truncated and non-ASCII input is rejected
+	if _, size := readCompactUint(string(appendCompactUint(nil, 1000))[:1]); size > 0 {
+		t.Error("truncated value decoded")
+	}
+	if _, size := readCompactUint("\x80"); size > 0 {
+		t.Error("byte >= 0x80 decoded")
+	}
+
+	// Bet sizes and buckets above 0x7f used to leave invalid UTF-8 in keys
+	board, _ := cards.ParseCards("Kh9s4c7d2s")
+	history := []notation.Action{{Type: notation.Bet, Amount: 150}, {Type: notation.Raise, Amount: 480.5}}
+	key := compactInfoSet(board, "b12.5c/", history, notation.BB, nil, 300)
+	if !utf8.ValidString(key) {
+		t.Errorf("compact key %q is not valid UTF-8", key)
+	}
+	readable, err := ReadableInfoSet(key)
+	if want := "Kh9s4c7d2s|b12.5c/b150.0r480.5|>BB|BUCKET_300"; err != nil || readable != want {
+		t.Errorf("ReadableInfoSet = %q, %v; want %q", readable, err, want)
+	}
+}
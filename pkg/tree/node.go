@@ -2,6 +2,7 @@ package tree
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/behrlich/poker-solver/pkg/cards"
@@ -34,11 +35,14 @@ type TreeNode struct {
 
 	// Terminal node flags
 	IsTerminal bool       // True if this is a terminal node (showdown or fold)
-	Payoff     [2]float64 // Payoffs for each player at terminal nodes
+	Payoff     [2]float64 // Net chips won (+) or lost (-) by each player; see ShowdownPayoffs/FoldPayoffs
+	Rake       float64    // Chips taken from the pot as rake (already in Payoff; rollouts apply it)
+	Dead       float64    // Dead money in Pot that neither player put in (already in Payoff; rollouts apply it)
 
 	// Rollout support (for turn→river, flop→turn→river)
 	NeedsRollout bool              // True if this terminal needs future card rollout
 	PlayerCombos [2]notation.Combo // Player combos (for rollout evaluation)
+	Evaluator    cards.Evaluator   // Rollout showdown evaluator (nil = cards.DefaultEvaluator)
 
 	// Game state information
 	Board  []cards.Card // Community cards
@@ -50,6 +54,16 @@ func ActionKey(action notation.Action) string {
 	return action.String()
 }
 
+// HistoryString concatenates action keys into a history string (e.g., "xb5.0r15.0")
+// The result parses back with notation.ParseHistory
+func HistoryString(history []notation.Action) string {
+	var sb strings.Builder
+	for _, action := range history {
+		sb.WriteString(ActionKey(action))
+	}
+	return sb.String()
+}
+
 // GetInfoSet generates the information set key for a game state and specific hole cards
 // InfoSet format: "board|action_history|>acting_player|hole_cards"
 // This represents what a single player knows at a decision point
@@ -64,11 +78,7 @@ func GetInfoSet(board []cards.Card, history []notation.Action, actingPlayer nota
 	parts = append(parts, boardStr)
 
 	// Action history (empty string if no actions)
-	historyStr := ""
-	for _, action := range history {
-		historyStr += action.String()
-	}
-	parts = append(parts, historyStr)
+	parts = append(parts, HistoryString(history))
 
 	// Acting player indicator
 	parts = append(parts, ">"+string(actingPlayer))
@@ -97,11 +107,7 @@ func GetInfoSetBucketed(board []cards.Card, history []notation.Action, actingPla
 	parts = append(parts, boardStr)
 
 	// Action history (empty string if no actions)
-	historyStr := ""
-	for _, action := range history {
-		historyStr += action.String()
-	}
-	parts = append(parts, historyStr)
+	parts = append(parts, HistoryString(history))
 
 	// Acting player indicator
 	parts = append(parts, ">"+string(actingPlayer))
@@ -210,3 +216,28 @@ func (n *TreeNode) IsShowdown() bool {
 func (n *TreeNode) NumChildren() int {
 	return len(n.Children)
 }
+
+// FindByInfoSet returns the first decision node with the given info set key
+// The search is depth-first with children in sorted key order, so the result
+// is stable. Terminal payoffs are absolute, so the returned node can be passed
+// to a solver as a root to re-solve that subtree on its own. In range trees the
+// same info set sits under every compatible opponent combo, and only the first
+// of those subtrees is returned.
+func (n *TreeNode) FindByInfoSet(infoSet string) (*TreeNode, bool) {
+	if !n.IsTerminal && !n.IsChance && n.InfoSet == infoSet {
+		return n, true
+	}
+
+	keys := make([]string, 0, len(n.Children))
+	for key := range n.Children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if found, ok := n.Children[key].FindByInfoSet(infoSet); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
@@ -165,6 +165,11 @@ func TestActionKey(t *testing.T) {
 			action: notation.Action{Type: notation.Fold},
 			want:   "f",
 		},
+		{
+			name:   "half rounds up",
+			action: notation.Action{Type: notation.Raise, Amount: 0.25},
+			want:   "r0.3",
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +182,28 @@ func TestActionKey(t *testing.T) {
 	}
 }
 
+// TestActionKey_FloatError checks that amounts a hair apart share a key, even
+// around a rounding half, and that keys parse back to themselves
+func TestActionKey_FloatError(t *testing.T) {
+	for _, amount := range []float64{10, 9.75, 0.25, 0.75 * 13, 33.35} {
+		for _, delta := range []float64{-1e-9, 1e-9} {
+			a := ActionKey(notation.Action{Type: notation.Bet, Amount: amount})
+			b := ActionKey(notation.Action{Type: notation.Bet, Amount: amount + delta})
+			if a != b {
+				t.Errorf("bets of %v and %v have keys %q and %q", amount, amount+delta, a, b)
+			}
+
+			parsed, err := notation.ParseHistory(b)
+			if err != nil {
+				t.Fatalf("ParseHistory(%q) failed: %v", b, err)
+			}
+			if got := HistoryString(parsed); got != b {
+				t.Errorf("key %q parses and formats back as %q", b, got)
+			}
+		}
+	}
+}
+
 func TestNodeIsShowdown(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -255,3 +282,21 @@ func TestNodeNumChildren(t *testing.T) {
 		t.Errorf("expected 2 children, got %d", node.NumChildren())
 	}
 }
+
+func TestNodeFindByInfoSet(t *testing.T) {
+	root := NewDecisionNode("|>BTN", 0, 10, nil, nil, [2]float64{100, 100})
+	facing := NewDecisionNode("b5.0|>BB", 1, 15, nil, nil, [2]float64{95, 100})
+	root.Children["x"] = NewTerminalNode(10, [2]float64{5, -5}, nil, [2]float64{100, 100})
+	root.Children["b5.0"] = facing
+	facing.Children["c"] = NewTerminalNode(20, [2]float64{-10, 10}, nil, [2]float64{95, 95})
+
+	if got, ok := root.FindByInfoSet("b5.0|>BB"); !ok || got != facing {
+		t.Errorf("FindByInfoSet(b5.0|>BB) = %v, %v; want the facing-bet node", got, ok)
+	}
+	if got, ok := root.FindByInfoSet("|>BTN"); !ok || got != root {
+		t.Errorf("FindByInfoSet(|>BTN) = %v, %v; want the root", got, ok)
+	}
+	if _, ok := root.FindByInfoSet("xb5.0|>BTN"); ok {
+		t.Error("expected no match for a missing info set")
+	}
+}
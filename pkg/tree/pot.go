@@ -0,0 +1,120 @@
+package tree
+
+import "math"
+
+// ChipSize is the smallest unit a pot is divided into, in BB
+const ChipSize = 0.01
+
+// SplitPot divides pot among winners in whole chips (see ChipSize)
+// Shares are returned in seat order; chips that don't divide evenly go one each
+// to the first seats, and any sub-chip remainder goes to the first seat, so the
+// shares always sum to pot. Returns nil if winners < 1.
+func SplitPot(pot float64, winners int) []float64 {
+	if winners < 1 {
+		return nil
+	}
+
+	// Small epsilon keeps float noise (e.g., 7.01/0.01 = 700.9999) from dropping a chip
+	chips := math.Floor(pot/ChipSize + 1e-9)
+	base := math.Floor(chips / float64(winners))
+	oddChips := int(chips - base*float64(winners))
+
+	shares := make([]float64, winners)
+	total := 0.0
+	for i := range shares {
+		shares[i] = base * ChipSize
+		if i < oddChips {
+			shares[i] += ChipSize
+		}
+		total += shares[i]
+	}
+	shares[0] += pot - total
+
+	return shares
+}
+
+// ShowdownPayoffs returns net payoffs for a heads-up showdown of pot
+// cmp is player 0's hand compared to player 1's (see HandValue.Compare). Bets are
+// matched at showdown, so each player has half the pot at stake: the winner nets
+// +pot/2 and the loser -pot/2. Ties split the pot with SplitPot, so an odd chip
+// leaves seat 0 slightly ahead.
+func ShowdownPayoffs(cmp int, pot float64) [2]float64 {
+	return RakedShowdownPayoffs(cmp, pot, 0)
+}
+
+// RakedShowdownPayoffs is ShowdownPayoffs with rake taken from the pot first
+// The winner nets pot/2-rake while the loser still loses pot/2; on a tie the
+// raked pot is split, so each player loses about rake/2.
+func RakedShowdownPayoffs(cmp int, pot, rake float64) [2]float64 {
+	return DeadShowdownPayoffs(cmp, pot, 0, rake)
+}
+
+// DeadShowdownPayoffs is RakedShowdownPayoffs for a pot holding dead money
+// dead is the part of pot neither player put in (antes), so each player has only
+// (pot-dead)/2 at stake and the winner nets that plus dead. Payoffs sum to
+// dead-rake rather than zero.
+func DeadShowdownPayoffs(cmp int, pot, dead, rake float64) [2]float64 {
+	stake := (pot - dead) / 2
+	switch {
+	case cmp > 0:
+		return [2]float64{stake + dead - rake, -stake}
+	case cmp < 0:
+		return [2]float64{-stake, stake + dead - rake}
+	default:
+		shares := SplitPot(pot-rake, 2)
+		return [2]float64{shares[0] - stake, shares[1] - stake}
+	}
+}
+
+// FoldPayoffs returns net payoffs when the player other than winner folds
+// uncalled is the part of the winner's last bet or raise the folder never matched.
+// It goes back to the winner rather than being won, so each player has
+// (pot-uncalled)/2 at stake and the result is zero-sum like ShowdownPayoffs.
+func FoldPayoffs(winner int, pot, uncalled float64) [2]float64 {
+	return RakedFoldPayoffs(winner, pot, uncalled, 0)
+}
+
+// RakedFoldPayoffs is FoldPayoffs with rake taken from the winner's take
+func RakedFoldPayoffs(winner int, pot, uncalled, rake float64) [2]float64 {
+	return DeadFoldPayoffs(winner, pot, uncalled, 0, rake)
+}
+
+// DeadFoldPayoffs is RakedFoldPayoffs for a pot holding dead money
+// The folder loses only their (pot-uncalled-dead)/2 stake; the dead money stays
+// in the pot and goes to the winner along with it.
+func DeadFoldPayoffs(winner int, pot, uncalled, dead, rake float64) [2]float64 {
+	stake := (pot - uncalled - dead) / 2
+	var payoffs [2]float64
+	payoffs[winner] = stake + dead - rake
+	payoffs[1-winner] = -stake
+	return payoffs
+}
+
+// Rake returns the rake taken from pot: pot*pct, capped at rakeCap (0 = no cap)
+func Rake(pot, pct, rakeCap float64) float64 {
+	rake := pot * pct
+	if rakeCap > 0 && rake > rakeCap {
+		rake = rakeCap
+	}
+	return rake
+}
+
+// MinDefenseFrequency returns how often a player must continue against a bet so the
+// bettor's bluffs don't profit automatically: 1 - bet/(pot+bet)
+// potBeforeBet excludes the bet itself; e.g., a half-pot bet gives 2/3, a pot bet 1/2.
+// Returns 1 if the pot and bet are both zero.
+func MinDefenseFrequency(potBeforeBet, betSize float64) float64 {
+	if potBeforeBet+betSize <= 0 {
+		return 1
+	}
+	return 1 - betSize/(potBeforeBet+betSize)
+}
+
+// PotOdds returns the share of the final pot a call contributes: callAmount/potAfterCall
+// This is the equity a call needs to break even. Returns 0 if potAfterCall is zero.
+func PotOdds(callAmount, potAfterCall float64) float64 {
+	if potAfterCall <= 0 {
+		return 0
+	}
+	return callAmount / potAfterCall
+}
@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitPot(t *testing.T) {
+	tests := []struct {
+		name    string
+		pot     float64
+		winners int
+		want    []float64
+	}{
+		{"even pot two ways", 10, 2, []float64{5, 5}},
+		{"half-BB shares", 35, 2, []float64{17.5, 17.5}},
+		{"odd chip two ways", 0.03, 2, []float64{0.02, 0.01}},
+		{"odd pot two ways", 7.01, 2, []float64{3.51, 3.50}},
+		{"three ways", 0.1, 3, []float64{0.04, 0.03, 0.03}},
+		{"single winner", 12.34, 1, []float64{12.34}},
+		{"sub-chip remainder to first seat", 1.005, 2, []float64{0.505, 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitPot(tt.pot, tt.winners)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SplitPot(%v, %d) = %v, want %v", tt.pot, tt.winners, got, tt.want)
+			}
+
+			sum := 0.0
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("share %d = %v, want %v", i, got[i], tt.want[i])
+				}
+				sum += got[i]
+			}
+			if math.Abs(sum-tt.pot) > 1e-9 {
+				t.Errorf("shares sum to %v, want %v", sum, tt.pot)
+			}
+		})
+	}
+
+	if got := SplitPot(10, 0); got != nil {
+		t.Errorf("SplitPot with no winners = %v, want nil", got)
+	}
+}
+
+func TestMinDefenseFrequency(t *testing.T) {
+	tests := []struct {
+		name string
+		pot  float64
+		bet  float64
+		want float64
+	}{
+		{"half pot", 10, 5, 2.0 / 3},
+		{"pot sized", 10, 10, 0.5},
+		{"2x pot overbet", 10, 20, 1.0 / 3},
+		{"no bet", 10, 0, 1},
+		{"empty pot", 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinDefenseFrequency(tt.pot, tt.bet); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MinDefenseFrequency(%v, %v) = %v, want %v", tt.pot, tt.bet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPotOdds(t *testing.T) {
+	// Facing a half-pot bet (5 into 10): call 5 to win a 20bb pot
+	if got := PotOdds(5, 20); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("PotOdds(5, 20) = %v, want 0.25", got)
+	}
+	// Facing a pot bet (10 into 10): call 10 to win a 30bb pot
+	if got := PotOdds(10, 30); math.Abs(got-1.0/3) > 1e-9 {
+		t.Errorf("PotOdds(10, 30) = %v, want 1/3", got)
+	}
+	if got := PotOdds(5, 0); got != 0 {
+		t.Errorf("PotOdds with empty pot = %v, want 0", got)
+	}
+}
+
+func TestShowdownAndFoldPayoffs(t *testing.T) {
+	if got := ShowdownPayoffs(1, 20); got != [2]float64{10, -10} {
+		t.Errorf("P0 wins: got %v", got)
+	}
+	if got := ShowdownPayoffs(-1, 20); got != [2]float64{-10, 10} {
+		t.Errorf("P1 wins: got %v", got)
+	}
+	if got := ShowdownPayoffs(0, 20); got != [2]float64{0, 0} {
+		t.Errorf("tie: got %v", got)
+	}
+
+	// Odd chip on a tie goes to seat 0
+	got := ShowdownPayoffs(0, 0.03)
+	if math.Abs(got[0]-0.005) > 1e-9 || math.Abs(got[0]+got[1]) > 1e-9 {
+		t.Errorf("odd-chip tie: got %v", got)
+	}
+
+	// 5bb bet into 10 folded to: 5 uncalled comes back
+	if got := FoldPayoffs(1, 15, 5); got != [2]float64{-5, 5} {
+		t.Errorf("fold: got %v", got)
+	}
+}
+
+func TestRakedPayoffs(t *testing.T) {
+	if got := Rake(20, 0.05, 0); got != 1 {
+		t.Errorf("Rake(20, 5%%, no cap) = %v, want 1", got)
+	}
+	if got := Rake(200, 0.05, 3); got != 3 {
+		t.Errorf("Rake(200, 5%%, cap 3) = %v, want 3", got)
+	}
+
+	if got := RakedShowdownPayoffs(1, 20, 1); got != [2]float64{9, -10} {
+		t.Errorf("raked P0 win: got %v", got)
+	}
+	if got := RakedShowdownPayoffs(-1, 20, 1); got != [2]float64{-10, 9} {
+		t.Errorf("raked P1 win: got %v", got)
+	}
+	if got := RakedShowdownPayoffs(0, 20, 1); got != [2]float64{-0.5, -0.5} {
+		t.Errorf("raked tie: got %v", got)
+	}
+	if got := RakedFoldPayoffs(0, 15, 5, 0.5); got != [2]float64{4.5, -5} {
+		t.Errorf("raked fold: got %v", got)
+	}
+}
+
+func TestDeadPayoffs(t *testing.T) {
+	// 12bb pot with 2bb of antes: each player has 5bb at stake
+	if got := DeadShowdownPayoffs(1, 12, 2, 0); got != [2]float64{7, -5} {
+		t.Errorf("dead P0 win: got %v", got)
+	}
+	if got := DeadShowdownPayoffs(0, 12, 2, 0); got != [2]float64{1, 1} {
+		t.Errorf("dead tie: got %v", got)
+	}
+
+	// 6bb bet into 12 (2 dead) folded to: the folder loses 5, the winner nets 7
+	if got := DeadFoldPayoffs(0, 18, 6, 2, 0); got != [2]float64{7, -5} {
+		t.Errorf("dead fold: got %v", got)
+	}
+	if got := DeadFoldPayoffs(1, 18, 6, 0, 0); got != FoldPayoffs(1, 18, 6) {
+		t.Errorf("no dead money should match FoldPayoffs: got %v", got)
+	}
+}
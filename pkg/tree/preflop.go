@@ -0,0 +1,153 @@
+package tree
+
+import (
+	"math"
+
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// blinds returns the small and big blind for preflop trees (see ActionConfig.SmallBlind)
+func (b *Builder) blinds() [2]float64 {
+	sb, bb := b.Config.SmallBlind, b.Config.BigBlind
+	if sb <= 0 {
+		sb = 0.5
+	}
+	if bb <= 0 {
+		bb = 1
+	}
+	return [2]float64{sb, bb}
+}
+
+// postBlinds moves each player's blind from their stack into the pot
+// A stack shorter than its blind posts what it has.
+func (b *Builder) postBlinds(pot float64, stacks [2]float64) (float64, [2]float64) {
+	for p, blind := range b.blinds() {
+		posted := math.Min(blind, stacks[p])
+		pot += posted
+		stacks[p] -= posted
+	}
+	return pot, stacks
+}
+
+// preflopCommitments returns the chips each player has put in preflop, blinds included
+// Player 0 is the small blind and acts first, so history alternates 0, 1, 0, ...
+func (b *Builder) preflopCommitments(history []notation.Action) [2]float64 {
+	committed := b.blinds()
+	for i, action := range history {
+		p := i % 2
+		switch action.Type {
+		case notation.Raise:
+			committed[p] = action.Amount
+		case notation.Call:
+			committed[p] = committed[1-p]
+		}
+	}
+	return committed
+}
+
+// isPreflopClosed reports whether the preflop betting round is over: a check
+// (the big blind's option after a limp) or a call of a raise. The small blind's
+// opening limp is a call that leaves the round open.
+func isPreflopClosed(history []notation.Action) bool {
+	if len(history) < 2 {
+		return false
+	}
+	last := history[len(history)-1].Type
+	return last == notation.Check || last == notation.Call
+}
+
+// buildPreflopNode builds a node of a preflop tree
+// Blinds count toward the players' commitments, so the small blind opens facing
+// the big blind and the big blind keeps the option to raise after a limp.
+func (b *Builder) buildPreflopNode(
+	history []notation.Action,
+	pot float64,
+	stacks [2]float64,
+	toAct int,
+	combos [2]notation.Combo,
+) *TreeNode {
+	committed := b.preflopCommitments(history)
+	lastAction := GetLastAction(history)
+
+	// Terminal: fold (no flop, so rake only with RakePreflop)
+	if lastAction != nil && lastAction.Type == notation.Fold {
+		uncalled := math.Abs(committed[0] - committed[1])
+		rake := b.rake(nil, pot-uncalled)
+		node := NewTerminalNode(pot, DeadFoldPayoffs(toAct, pot, uncalled, b.dead, rake), nil, stacks)
+		node.Rake = rake
+		node.Dead = b.dead
+		return node
+	}
+
+	// Terminal: the round closed, so all five board cards are dealt. A flop
+	// comes, so the pot is raked as any postflop pot would be.
+	if isPreflopClosed(history) {
+		return b.rolloutNode(pot, nil, stacks, combos, Rake(pot, b.Config.RakePct, b.Config.RakeCap))
+	}
+
+	playerPos := []notation.Position{notation.SB, notation.BB}[toAct]
+	infoSet := b.infoSetKey(nil, "", history, playerPos, combos[toAct])
+	actions := b.preflopActions(history, committed, stacks, toAct)
+	node := NewDecisionNode(infoSet, toAct, pot, actions, nil, stacks)
+
+	for _, action := range actions {
+		newHistory := append([]notation.Action{}, history...)
+		newHistory = append(newHistory, action)
+		newPot := pot
+		newStacks := stacks
+
+		var chips float64
+		switch action.Type {
+		case notation.Raise:
+			chips = action.Amount - committed[toAct]
+		case notation.Call:
+			chips = math.Min(committed[1-toAct]-committed[toAct], stacks[toAct])
+		}
+		newPot += chips
+		newStacks[toAct] -= chips
+
+		node.Children[ActionKey(action)] = b.buildPreflopNode(newHistory, newPot, newStacks, 1-toAct, combos)
+	}
+
+	return node
+}
+
+// preflopActions returns the legal preflop actions for toAct
+// Facing a bigger commitment (the big blind or a raise) the player may fold,
+// call, or raise; the big blind after a limp may check or raise. Raises are
+// capped at what the opponent can call. ShoveOnly is push/fold for the small blind.
+func (b *Builder) preflopActions(history []notation.Action, committed, stacks [2]float64, toAct int) []notation.Action {
+	own, facing := committed[toAct], committed[1-toAct]
+	stack := math.Min(stacks[toAct], math.Max(facing-own, 0)+stacks[1-toAct])
+
+	if b.Config.ShoveOnly {
+		// The small blind folds or shoves; a shove can only be folded to or called
+		if len(history) > 0 {
+			return []notation.Action{{Type: notation.Fold}, {Type: notation.Call}}
+		}
+		actions := []notation.Action{{Type: notation.Fold}}
+		if own+stack > facing+0.01 {
+			actions = append(actions, notation.Action{Type: notation.Raise, Amount: own + stack})
+		}
+		return actions
+	}
+
+	var actions []notation.Action
+	if facing > own {
+		if b.Config.AllowFold {
+			actions = append(actions, notation.Action{Type: notation.Fold})
+		}
+		if b.Config.AllowCall {
+			actions = append(actions, notation.Action{Type: notation.Call})
+		}
+	} else if b.Config.AllowCheck {
+		actions = append(actions, notation.Action{Type: notation.Check})
+	}
+
+	if b.Config.MaxRaises > 0 && countAggressive(history) >= b.Config.MaxRaises {
+		return actions
+	}
+	blinds := b.blinds()
+	minRaiseTo := facing + minRaiseIncrement(blinds, blinds[1], history)
+	return append(actions, generateRaises(own, facing, stack, minRaiseTo, b.Config.RaiseSizesFacingBet)...)
+}
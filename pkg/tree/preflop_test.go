@@ -0,0 +1,102 @@
+package tree
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestBuilder_Preflop(t *testing.T) {
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.SB, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Street: notation.Preflop,
+	}
+	aa, _ := cards.ParseCards("AdAc")
+	kq, _ := cards.ParseCards("KsQs")
+	combo0 := notation.Combo{Card1: aa[0], Card2: aa[1]}
+	combo1 := notation.Combo{Card1: kq[0], Card2: kq[1]}
+
+	config := ActionConfig{
+		AllowCheck:          true,
+		AllowCall:           true,
+		AllowFold:           true,
+		RaiseSizesFacingBet: []float64{3.0},
+		MaxRaises:           3,
+		Preflop:             true,
+	}
+	root, err := NewBuilder(config).Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// Blinds are posted before the small blind opens facing the big blind
+	if root.Pot != 1.5 || root.Stacks != [2]float64{99.5, 99} {
+		t.Errorf("root pot %v stacks %v, want 1.5 and [99.5 99]", root.Pot, root.Stacks)
+	}
+	if root.InfoSet != "||>SB|AdAc" {
+		t.Errorf("root info set = %q, want \"||>SB|AdAc\"", root.InfoSet)
+	}
+	if got, want := sortedKeys(root.Children), []string{"c", "f", "r100.0", "r3.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("small blind actions = %v, want %v", got, want)
+	}
+
+	// Folding the small blind loses it
+	if fold := root.Children["f"]; !fold.IsTerminal || fold.Payoff != [2]float64{-0.5, 0.5} {
+		t.Errorf("fold payoffs = %v, want [-0.5 0.5]", fold.Payoff)
+	}
+
+	// A limp completes to 1bb and leaves the big blind its option
+	limp := root.Children["c"]
+	if limp.IsTerminal || limp.Player != 1 || limp.Pot != 2 {
+		t.Fatalf("limp should give the big blind a decision in a 2bb pot, got %+v", limp)
+	}
+	if got, want := sortedKeys(limp.Children), []string{"r100.0", "r3.0", "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("big blind actions after a limp = %v, want %v", got, want)
+	}
+
+	// Checking the option and calling a raise both deal a full board
+	limpCheck := limp.Children["x"]
+	if !limpCheck.NeedsRollout || len(limpCheck.Board) != 0 || limpCheck.Pot != 2 {
+		t.Errorf("limp-check should be a 2bb rollout with no board, got rollout=%v board=%v pot=%v",
+			limpCheck.NeedsRollout, limpCheck.Board, limpCheck.Pot)
+	}
+	raiseCall := root.Children["r3.0"].Children["c"]
+	if !raiseCall.NeedsRollout || raiseCall.Pot != 6 || raiseCall.Stacks != [2]float64{97, 97} {
+		t.Errorf("raise-call should be a 6bb rollout with 97bb behind, got rollout=%v pot=%v stacks=%v",
+			raiseCall.NeedsRollout, raiseCall.Pot, raiseCall.Stacks)
+	}
+
+	// A 3bet is a raise to 3× the open
+	if _, ok := root.Children["r3.0"].Children["r9.0"]; !ok {
+		t.Errorf("missing 3bet to 9bb, got %v", sortedKeys(root.Children["r3.0"].Children))
+	}
+
+	// Every showdown is a rollout from an empty board
+	var walk func(node *TreeNode)
+	walk = func(node *TreeNode) {
+		if node.IsTerminal {
+			if len(node.Board) != 0 {
+				t.Errorf("terminal has board %v, want none", node.Board)
+			}
+			if node.NeedsRollout != (node.Payoff == [2]float64{}) {
+				t.Errorf("terminal rollout=%v with payoffs %v", node.NeedsRollout, node.Payoff)
+			}
+			return
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	// Preflop trees need an empty board
+	gs.Board, _ = cards.ParseCards("Kh9s4c")
+	if _, err := NewBuilder(config).Build(gs, combo0, combo1); err == nil {
+		t.Error("expected error building a preflop tree with a flop")
+	}
+}
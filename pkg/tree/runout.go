@@ -0,0 +1,106 @@
+package tree
+
+import (
+	"math/rand"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+)
+
+// RunoutKind selects how a multi-street builder branches when it deals a card
+type RunoutKind int
+
+const (
+	// RunoutFull deals every card left in the deck (the default)
+	RunoutFull RunoutKind = iota
+
+	// RunoutSampled deals a fixed-size random subset of the remaining cards
+	RunoutSampled
+
+	// RunoutFixed deals only the listed cards that are still in the deck
+	RunoutFixed
+)
+
+// RunoutMode controls the chance nodes between streets in a multi-street tree
+// The zero value is full enumeration. Dealt cards are equally likely, so a
+// restricted runout solves the game as if only those cards could come.
+type RunoutMode struct {
+	Kind RunoutKind
+
+	// Samples is the number of cards sampled per chance node (RunoutSampled);
+	// a combo pair holding some of them is dealt the rest
+	Samples int
+
+	// Seed makes sampled runouts reproducible; the same seed and board always
+	// give the same cards, so every combo pair sees consistent boards (less any
+	// card in the pair's hands)
+	Seed int64
+
+	// Cards are the only turn and river cards considered (RunoutFixed)
+	Cards []cards.Card
+}
+
+// FullRunouts deals every remaining card at each street
+func FullRunouts() RunoutMode {
+	return RunoutMode{Kind: RunoutFull}
+}
+
+// SampledRunouts deals n cards chosen at random (by seed) from those remaining
+func SampledRunouts(n int, seed int64) RunoutMode {
+	return RunoutMode{Kind: RunoutSampled, Samples: n, Seed: seed}
+}
+
+// FixedRunouts deals only the given cards, e.g. FixedRunouts(7d, 2s) branches the
+// turn on 7d and 2s, and the river on whichever of them is left
+func FixedRunouts(dealt ...cards.Card) RunoutMode {
+	return RunoutMode{Kind: RunoutFixed, Cards: dealt}
+}
+
+// Deal returns the cards a chance node branches on
+// deck is every card not on the board, in deck order; held are the players'
+// hole cards. Cards are chosen from deck alone, so every combo pair at the same
+// board gets the same sample, and then any card in held is dropped. The result
+// may be empty when every chosen card is held.
+func (r RunoutMode) Deal(deck []cards.Card, held cards.CardMask) []cards.Card {
+	return withoutHeld(r.choose(deck), held)
+}
+
+// choose picks the cards to branch on from deck, ignoring hole cards
+func (r RunoutMode) choose(deck []cards.Card) []cards.Card {
+	switch r.Kind {
+	case RunoutSampled:
+		if r.Samples <= 0 || r.Samples >= len(deck) {
+			return deck
+		}
+		shuffled := append([]cards.Card{}, deck...)
+		rng := rand.New(rand.NewSource(r.Seed))
+		rng.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		return shuffled[:r.Samples]
+
+	case RunoutFixed:
+		available := cards.NewCardMask(deck...)
+		var dealt []cards.Card
+		for _, card := range r.Cards {
+			if available.Has(card) {
+				dealt = append(dealt, card)
+				available &^= cards.NewCardMask(card)
+			}
+		}
+		return dealt
+
+	default:
+		return deck
+	}
+}
+
+// withoutHeld returns the cards not in held
+func withoutHeld(dealt []cards.Card, held cards.CardMask) []cards.Card {
+	live := make([]cards.Card, 0, len(dealt))
+	for _, card := range dealt {
+		if !held.Has(card) {
+			live = append(live, card)
+		}
+	}
+	return live
+}
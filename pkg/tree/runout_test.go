@@ -0,0 +1,54 @@
+package tree
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+)
+
+func TestRunoutMode_Deal(t *testing.T) {
+	deck := cards.NewDeck()
+	remaining := deck.Cards()
+
+	if got := FullRunouts().Deal(remaining, 0); len(got) != 52 {
+		t.Errorf("full runout dealt %d cards, want 52", len(got))
+	}
+
+	// Sampling is reproducible for a seed and picks distinct cards
+	sampled := SampledRunouts(5, 7).Deal(remaining, 0)
+	if len(sampled) != 5 || cards.NewCardMask(sampled...).Count() != 5 {
+		t.Errorf("sampled runout = %v, want 5 distinct cards", sampled)
+	}
+	if again := SampledRunouts(5, 7).Deal(remaining, 0); !reflect.DeepEqual(again, sampled) {
+		t.Errorf("same seed dealt %v, then %v", sampled, again)
+	}
+	if got := SampledRunouts(60, 7).Deal(remaining, 0); len(got) != 52 {
+		t.Errorf("oversized sample dealt %d cards, want all 52", len(got))
+	}
+
+	// Fixed cards are dealt only if still in the deck, without duplicates
+	fixed, _ := cards.ParseCards("7d2s7dAh")
+	deck.Remove(fixed[3])
+	got := FixedRunouts(fixed...).Deal(deck.Cards(), 0)
+	if want := fixed[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("fixed runout = %v, want %v", got, want)
+	}
+}
+
+func TestRunoutMode_DealDropsHeld(t *testing.T) {
+	deck := cards.NewDeck().Cards()
+	sampled := SampledRunouts(5, 7).Deal(deck, 0)
+
+	// Holding a sampled card removes it without changing the rest of the sample
+	held := cards.NewCardMask(sampled[1], sampled[3])
+	got := SampledRunouts(5, 7).Deal(deck, held)
+	want := []cards.Card{sampled[0], sampled[2], sampled[4]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sample with %v %v held = %v, want %v", sampled[1], sampled[3], got, want)
+	}
+
+	if got := FullRunouts().Deal(deck, held); len(got) != 50 || cards.NewCardMask(got...).Overlaps(held) {
+		t.Errorf("full runout with 2 held dealt %d cards (%v)", len(got), got)
+	}
+}
@@ -0,0 +1,280 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// treeFormatVersion identifies the on-disk tree format
+// Version 2 added terminal rake, dead money, and rollout evaluators; version 1
+// files are rejected because their rollouts would silently lose them.
+const treeFormatVersion = 2
+
+// serializableTree is the top-level JSON document for a saved tree
+type serializableTree struct {
+	Version int               `json:"version"`
+	Root    *serializableNode `json:"root"`
+}
+
+// serializableNode is a JSON-friendly representation of a TreeNode
+// Cards and combos are stored in standard notation (e.g., "AsKh")
+type serializableNode struct {
+	InfoSet             string                       `json:"infoset,omitempty"`
+	Player              int                          `json:"player"`
+	Pot                 float64                      `json:"pot"`
+	Actions             []serializableAction         `json:"actions,omitempty"`
+	Children            map[string]*serializableNode `json:"children,omitempty"`
+	IsChance            bool                         `json:"chance,omitempty"`
+	ChanceProbabilities map[string]float64           `json:"chance_probs,omitempty"`
+	IsTerminal          bool                         `json:"terminal,omitempty"`
+	Payoff              [2]float64                   `json:"payoff"`
+	Rake                float64                      `json:"rake,omitempty"`
+	Dead                float64                      `json:"dead,omitempty"`
+	NeedsRollout        bool                         `json:"rollout,omitempty"`
+	PlayerCombos        [2]string                    `json:"combos"`
+	Evaluator           string                       `json:"evaluator,omitempty"`
+	Board               string                       `json:"board,omitempty"`
+	Stacks              [2]float64                   `json:"stacks"`
+}
+
+// serializableAction is a JSON-friendly representation of an Action
+// Amounts are stored at full precision (ActionKey rounds to 0.1bb)
+type serializableAction struct {
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount,omitempty"`
+}
+
+// savedEvaluators are the evaluators a saved tree can name on its rollout nodes
+var savedEvaluators = map[string]cards.Evaluator{
+	"holdem":    cards.HoldemEvaluator{},
+	"omaha":     cards.OmahaEvaluator{},
+	"shortdeck": cards.ShortDeckEvaluator{},
+}
+
+// ToJSON serializes the tree rooted at this node to JSON bytes
+// Rollout evaluators are saved by name; a tree using any other evaluator is an error.
+func (n *TreeNode) ToJSON() ([]byte, error) {
+	root, err := toSerializableNode(n)
+	if err != nil {
+		return nil, err
+	}
+	doc := serializableTree{
+		Version: treeFormatVersion,
+		Root:    root,
+	}
+	return json.Marshal(doc)
+}
+
+// TreeFromJSON deserializes JSON bytes produced by ToJSON into a tree
+func TreeFromJSON(data []byte) (*TreeNode, error) {
+	var doc serializableTree
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Version != treeFormatVersion {
+		return nil, fmt.Errorf("unsupported tree format version %d", doc.Version)
+	}
+	if doc.Root == nil {
+		return nil, fmt.Errorf("tree has no root node")
+	}
+
+	return fromSerializableNode(doc.Root)
+}
+
+// Save writes the tree rooted at this node to a JSON file
+func (n *TreeNode) Save(path string) error {
+	data, err := n.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadTree reads a tree previously written by Save
+func LoadTree(path string) (*TreeNode, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return TreeFromJSON(data)
+}
+
+// evaluatorName returns the savedEvaluators name of e ("" for nil)
+func evaluatorName(e cards.Evaluator) (string, error) {
+	switch e.(type) {
+	case nil:
+		return "", nil
+	case cards.HoldemEvaluator:
+		return "holdem", nil
+	case cards.OmahaEvaluator:
+		return "omaha", nil
+	case cards.ShortDeckEvaluator:
+		return "shortdeck", nil
+	}
+	return "", fmt.Errorf("evaluator %T can't be saved", e)
+}
+
+// toSerializableNode recursively converts a TreeNode
+func toSerializableNode(n *TreeNode) (*serializableNode, error) {
+	evaluator, err := evaluatorName(n.Evaluator)
+	if err != nil {
+		return nil, err
+	}
+
+	sn := &serializableNode{
+		InfoSet:      n.InfoSet,
+		Player:       n.Player,
+		Pot:          n.Pot,
+		IsChance:     n.IsChance,
+		IsTerminal:   n.IsTerminal,
+		Payoff:       n.Payoff,
+		Rake:         n.Rake,
+		Dead:         n.Dead,
+		NeedsRollout: n.NeedsRollout,
+		PlayerCombos: [2]string{n.PlayerCombos[0].String(), n.PlayerCombos[1].String()},
+		Evaluator:    evaluator,
+		Board:        cardsToString(n.Board),
+		Stacks:       n.Stacks,
+	}
+
+	if len(n.Actions) > 0 {
+		sn.Actions = make([]serializableAction, len(n.Actions))
+		for i, action := range n.Actions {
+			sn.Actions[i] = serializableAction{
+				Type:   action.Type.String(),
+				Amount: action.Amount,
+			}
+		}
+	}
+
+	if len(n.Children) > 0 {
+		sn.Children = make(map[string]*serializableNode, len(n.Children))
+		for key, child := range n.Children {
+			sc, err := toSerializableNode(child)
+			if err != nil {
+				return nil, err
+			}
+			sn.Children[key] = sc
+		}
+	}
+
+	if len(n.ChanceProbabilities) > 0 {
+		sn.ChanceProbabilities = make(map[string]float64, len(n.ChanceProbabilities))
+		for key, prob := range n.ChanceProbabilities {
+			sn.ChanceProbabilities[key] = prob
+		}
+	}
+
+	return sn, nil
+}
+
+// fromSerializableNode recursively rebuilds a TreeNode
+func fromSerializableNode(sn *serializableNode) (*TreeNode, error) {
+	board, err := cards.ParseCards(sn.Board)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing board %q: %w", sn.Board, err)
+	}
+	if len(board) == 0 {
+		board = nil
+	}
+
+	var combos [2]notation.Combo
+	for i, comboStr := range sn.PlayerCombos {
+		comboCards, err := cards.ParseCards(comboStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid player combo %q", comboStr)
+		}
+		switch len(comboCards) {
+		case 2:
+			combos[i] = notation.Combo{Card1: comboCards[0], Card2: comboCards[1]}
+		case 4:
+			combos[i] = notation.Combo{Card1: comboCards[0], Card2: comboCards[1], Card3: comboCards[2], Card4: comboCards[3], Omaha: true}
+		default:
+			return nil, fmt.Errorf("invalid player combo %q", comboStr)
+		}
+	}
+
+	var evaluator cards.Evaluator
+	if sn.Evaluator != "" {
+		var ok bool
+		if evaluator, ok = savedEvaluators[sn.Evaluator]; !ok {
+			return nil, fmt.Errorf("unknown evaluator %q", sn.Evaluator)
+		}
+	}
+
+	var actions []notation.Action
+	if len(sn.Actions) > 0 {
+		actions = make([]notation.Action, len(sn.Actions))
+		for i, sa := range sn.Actions {
+			actionType, err := parseActionType(sa.Type)
+			if err != nil {
+				return nil, err
+			}
+			actions[i] = notation.Action{Type: actionType, Amount: sa.Amount}
+		}
+	}
+
+	n := &TreeNode{
+		InfoSet:      sn.InfoSet,
+		Player:       sn.Player,
+		Pot:          sn.Pot,
+		Actions:      actions,
+		IsChance:     sn.IsChance,
+		IsTerminal:   sn.IsTerminal,
+		Payoff:       sn.Payoff,
+		Rake:         sn.Rake,
+		Dead:         sn.Dead,
+		NeedsRollout: sn.NeedsRollout,
+		PlayerCombos: combos,
+		Evaluator:    evaluator,
+		Board:        board,
+		Stacks:       sn.Stacks,
+	}
+
+	// Match the constructors: decision and chance nodes always have a children map
+	if !n.IsTerminal {
+		n.Children = make(map[string]*TreeNode, len(sn.Children))
+	}
+	for key, sc := range sn.Children {
+		child, err := fromSerializableNode(sc)
+		if err != nil {
+			return nil, err
+		}
+		n.Children[key] = child
+	}
+
+	if n.IsChance {
+		n.ChanceProbabilities = make(map[string]float64, len(sn.ChanceProbabilities))
+		for key, prob := range sn.ChanceProbabilities {
+			n.ChanceProbabilities[key] = prob
+		}
+	}
+
+	return n, nil
+}
+
+// parseActionType converts an ActionType name back to its value
+func parseActionType(s string) (notation.ActionType, error) {
+	for _, t := range []notation.ActionType{notation.Check, notation.Call, notation.Bet, notation.Raise, notation.Fold} {
+		if t.String() == s {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action type %q", s)
+}
+
+// cardsToString joins cards in standard notation (e.g., "Kh9s4c")
+func cardsToString(cardList []cards.Card) string {
+	s := ""
+	for _, card := range cardList {
+		s += card.String()
+	}
+	return s
+}
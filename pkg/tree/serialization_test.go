@@ -0,0 +1,211 @@
+package tree
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+func TestTree_SaveAndLoad_River(t *testing.T) {
+	config := ActionConfig{
+		BetSizes:   []float64{0.33, 1.0},
+		AllowCheck: true,
+		AllowCall:  true,
+		AllowFold:  true,
+	}
+	builder := NewBuilder(config)
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: makeRiverBoard(),
+		ToAct: 0,
+	}
+
+	range0, _ := notation.ParseRange("AA")
+	range1, _ := notation.ParseRange("QQ")
+
+	root, err := builder.BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tree.json")
+	if err := root.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadTree(path)
+	if err != nil {
+		t.Fatalf("LoadTree failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(root, loaded) {
+		t.Error("loaded tree does not match original")
+	}
+
+	// Raked terminals keep their rake and dead money, which rollouts and river
+	// solves apply
+	config.RakePct, config.RakeCap = 0.05, 3
+	gs.DeadMoney = 2
+	raked, err := NewBuilder(config).BuildRange(gs, range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange failed: %v", err)
+	}
+	data, err := raked.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	loaded, err = TreeFromJSON(data)
+	if err != nil {
+		t.Fatalf("TreeFromJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(raked, loaded) {
+		t.Error("loaded raked tree does not match original")
+	}
+	for key, pair := range loaded.Children {
+		checkCheck := pair.Children["x"].Children["x"]
+		if checkCheck.Rake != 0.5 || checkCheck.Dead != 2 {
+			t.Errorf("%s check-check rake %v dead %v, want 0.5 (5%% of 10) and 2", key, checkCheck.Rake, checkCheck.Dead)
+		}
+	}
+}
+
+func TestTree_SaveAndLoad_RolloutNodes(t *testing.T) {
+	builder := NewBuilder(DefaultRiverConfig())
+
+	board, err := cards.ParseCards("Kh9s4c7d")
+	if err != nil {
+		t.Fatalf("Failed to parse board: %v", err)
+	}
+
+	gs := &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 100},
+			{Position: notation.BB, Stack: 100},
+		},
+		Pot:   10,
+		Board: board,
+		ToAct: 0,
+	}
+
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	root, err := builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	data, err := root.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	loaded, err := TreeFromJSON(data)
+	if err != nil {
+		t.Fatalf("TreeFromJSON failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(root, loaded) {
+		t.Fatal("loaded tree does not match original")
+	}
+
+	// Rollout nodes must keep their combos and board for evaluation
+	checkCheck := loaded.Children["x"].Children["x"]
+	if !checkCheck.NeedsRollout {
+		t.Fatal("expected check-check node to need rollout")
+	}
+	if checkCheck.PlayerCombos[0] != combo0 || checkCheck.PlayerCombos[1] != combo1 {
+		t.Errorf("rollout combos not preserved: got %v", checkCheck.PlayerCombos)
+	}
+	if len(checkCheck.Board) != 4 {
+		t.Errorf("rollout board not preserved: got %v", checkCheck.Board)
+	}
+
+	// Named evaluators survive a round trip; any other can't be saved
+	builder.SetEvaluator(cards.HoldemEvaluator{})
+	root, err = builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	data, err = root.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON with Hold'em evaluator failed: %v", err)
+	}
+	if loaded, err = TreeFromJSON(data); err != nil {
+		t.Fatalf("TreeFromJSON failed: %v", err)
+	}
+	if got := loaded.Children["x"].Children["x"].Evaluator; got != (cards.HoldemEvaluator{}) {
+		t.Errorf("rollout evaluator = %#v, want HoldemEvaluator", got)
+	}
+
+	// Omaha trees keep their four-card combos too
+	omaha0, _ := notation.ParseRange("AdAcKdKc")
+	omaha1, _ := notation.ParseRange("QdQhJdJh")
+	builder.SetEvaluator(cards.OmahaEvaluator{})
+	root, err = builder.Build(gs, omaha0[0], omaha1[0])
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	data, err = root.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON with Omaha evaluator failed: %v", err)
+	}
+	if loaded, err = TreeFromJSON(data); err != nil {
+		t.Fatalf("TreeFromJSON failed: %v", err)
+	}
+	if !reflect.DeepEqual(root, loaded) {
+		t.Error("loaded Omaha tree does not match original")
+	}
+
+	builder.SetEvaluator(lowCardEvaluator{})
+	root, err = builder.Build(gs, combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, err := root.ToJSON(); err == nil {
+		t.Error("expected ToJSON error for an unnamed evaluator")
+	}
+}
+
+func TestTreeFromJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"invalid json", "{not json"},
+		{"unknown version", `{"version": 99, "root": {}}`},
+		{"pre-rake version", `{"version": 1, "root": {"combos": ["2s2s", "2s2s"]}}`},
+		{"missing root", `{"version": 2}`},
+		{"bad board", `{"version": 2, "root": {"board": "Zz", "combos": ["2s2s", "2s2s"]}}`},
+		{"bad action", `{"version": 2, "root": {"combos": ["2s2s", "2s2s"], "actions": [{"type": "jump"}]}}`},
+		{"unknown evaluator", `{"version": 2, "root": {"terminal": true, "rollout": true, "combos": ["2s2s", "2s2s"], "evaluator": "razz"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TreeFromJSON([]byte(tt.data)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadTree_NonExistent(t *testing.T) {
+	if _, err := LoadTree("/nonexistent/tree.json"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
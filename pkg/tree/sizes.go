@@ -0,0 +1,49 @@
+package tree
+
+import "math"
+
+// sizePrecision is the rounding applied to generated sizes, so float steps like
+// 0.1 land on 0.3 rather than 0.30000000000000004
+const sizePrecision = 1e9
+
+// LinearSizes returns evenly spaced pot fractions from min to max inclusive,
+// for use as ActionConfig.BetSizes: LinearSizes(0.25, 1.0, 0.25) is
+// [0.25 0.5 0.75 1]. max is included when it falls on a step. Returns nil for
+// a non-positive step or max below min.
+func LinearSizes(min, max, step float64) []float64 {
+	if step <= 0 || max < min {
+		return nil
+	}
+
+	var sizes []float64
+	for i := 0; ; i++ {
+		size := math.Round((min+float64(i)*step)*sizePrecision) / sizePrecision
+		if size > max+1/sizePrecision {
+			break
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+// LogSizes returns n pot fractions from min to max inclusive with a constant
+// ratio between neighbors, for use as ActionConfig.BetSizes. Geometric spacing
+// puts more sizes where small differences matter: LogSizes(0.25, 4, 5) is
+// [0.25 0.5 1 2 4]. n == 1 returns [min]. Returns nil for n <= 0, a
+// non-positive min, or max below min.
+func LogSizes(min, max float64, n int) []float64 {
+	if n <= 0 || min <= 0 || max < min {
+		return nil
+	}
+	if n == 1 {
+		return []float64{min}
+	}
+
+	ratio := math.Pow(max/min, 1/float64(n-1))
+	sizes := make([]float64, n)
+	for i := range sizes {
+		sizes[i] = math.Round(min*math.Pow(ratio, float64(i))*sizePrecision) / sizePrecision
+	}
+	sizes[n-1] = max
+	return sizes
+}
@@ -0,0 +1,55 @@
+package tree
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLinearSizes(t *testing.T) {
+	tests := []struct {
+		name           string
+		min, max, step float64
+		want           []float64
+	}{
+		{"quarters", 0.25, 1.0, 0.25, []float64{0.25, 0.5, 0.75, 1.0}},
+		{"tenths", 0.1, 0.5, 0.1, []float64{0.1, 0.2, 0.3, 0.4, 0.5}},
+		{"max off step", 0.5, 1.2, 0.5, []float64{0.5, 1.0}},
+		{"single", 0.75, 0.75, 0.25, []float64{0.75}},
+		{"zero step", 0.25, 1.0, 0, nil},
+		{"max below min", 1.0, 0.5, 0.25, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LinearSizes(tt.min, tt.max, tt.step); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LinearSizes(%v, %v, %v) = %v, want %v", tt.min, tt.max, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogSizes(t *testing.T) {
+	sizes := LogSizes(0.25, 4, 5)
+	if want := []float64{0.25, 0.5, 1, 2, 4}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("LogSizes(0.25, 4, 5) = %v, want %v", sizes, want)
+	}
+
+	// Neighbors keep a constant ratio
+	sizes = LogSizes(0.33, 1.5, 4)
+	if len(sizes) != 4 || sizes[0] != 0.33 || sizes[3] != 1.5 {
+		t.Fatalf("LogSizes(0.33, 1.5, 4) = %v, want 4 sizes from 0.33 to 1.5", sizes)
+	}
+	ratio := sizes[1] / sizes[0]
+	for i := 2; i < len(sizes); i++ {
+		if r := sizes[i] / sizes[i-1]; math.Abs(r-ratio) > 1e-6 {
+			t.Errorf("ratio %d = %v, want %v (sizes %v)", i, r, ratio, sizes)
+		}
+	}
+
+	if got := LogSizes(0.5, 2, 1); !reflect.DeepEqual(got, []float64{0.5}) {
+		t.Errorf("LogSizes with n = 1 = %v, want [0.5]", got)
+	}
+	if LogSizes(0, 2, 3) != nil || LogSizes(0.5, 2, 0) != nil || LogSizes(2, 0.5, 3) != nil {
+		t.Error("expected nil for invalid arguments")
+	}
+}
@@ -0,0 +1,69 @@
+package tree
+
+import "fmt"
+
+// Rough per-object memory costs used by TreeStats.EstimatedBytes
+// These are ballpark figures (struct, map buckets, slices) rather than exact sizes.
+const (
+	bytesPerNode    = 300 // TreeNode struct plus children map overhead
+	bytesPerInfoSet = 250 // Regret/strategy slices and map entry in the solver profile
+)
+
+// TreeStats summarizes the size of a game tree
+type TreeStats struct {
+	DecisionNodes  int // Nodes where a player acts
+	TerminalNodes  int // Showdown, fold, and rollout leaves
+	ChanceNodes    int // Nodes that deal hands or cards
+	ChanceOutcomes int // Children across all chance nodes
+	InfoSets       int // Distinct information set keys at decision nodes
+	MaxDepth       int // Edges on the longest root-to-leaf path
+}
+
+// Stats walks the tree and counts its nodes
+func (n *TreeNode) Stats() TreeStats {
+	var stats TreeStats
+	infoSets := make(map[string]bool)
+
+	var walk func(node *TreeNode, depth int)
+	walk = func(node *TreeNode, depth int) {
+		if depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+
+		switch {
+		case node.IsTerminal:
+			stats.TerminalNodes++
+		case node.IsChance:
+			stats.ChanceNodes++
+			stats.ChanceOutcomes += len(node.Children)
+		default:
+			stats.DecisionNodes++
+			infoSets[node.InfoSet] = true
+		}
+
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(n, 0)
+
+	stats.InfoSets = len(infoSets)
+	return stats
+}
+
+// TotalNodes returns the number of nodes of every kind
+func (s TreeStats) TotalNodes() int {
+	return s.DecisionNodes + s.TerminalNodes + s.ChanceNodes
+}
+
+// EstimatedBytes approximates the memory needed to hold the tree and solve it
+func (s TreeStats) EstimatedBytes() int64 {
+	return int64(s.TotalNodes())*bytesPerNode + int64(s.InfoSets)*bytesPerInfoSet
+}
+
+// String returns a one-line summary (e.g., "1234 nodes (...), ~1.2 MB")
+func (s TreeStats) String() string {
+	return fmt.Sprintf("%d nodes (%d decision, %d terminal, %d chance with %d outcomes), %d info sets, depth %d, ~%.1f MB",
+		s.TotalNodes(), s.DecisionNodes, s.TerminalNodes, s.ChanceNodes, s.ChanceOutcomes,
+		s.InfoSets, s.MaxDepth, float64(s.EstimatedBytes())/(1024*1024))
+}
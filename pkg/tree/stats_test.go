@@ -0,0 +1,95 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/behrlich/poker-solver/pkg/cards"
+	"github.com/behrlich/poker-solver/pkg/notation"
+)
+
+// shoveOnlyRiverState is a 20bb-deep river spot used for hand-countable trees
+func shoveOnlyRiverState() *notation.GameState {
+	return &notation.GameState{
+		Players: []notation.PlayerRange{
+			{Position: notation.BTN, Stack: 20},
+			{Position: notation.BB, Stack: 20},
+		},
+		Pot: 10,
+		Board: []cards.Card{
+			cards.NewCard(cards.King, cards.Hearts),
+			cards.NewCard(cards.Nine, cards.Spades),
+			cards.NewCard(cards.Four, cards.Clubs),
+			cards.NewCard(cards.Seven, cards.Diamonds),
+			cards.NewCard(cards.Two, cards.Spades),
+		},
+		ToAct:  0,
+		Street: notation.River,
+	}
+}
+
+func TestTreeNode_Stats_River(t *testing.T) {
+	combo0 := notation.Combo{
+		Card1: cards.NewCard(cards.Ace, cards.Diamonds),
+		Card2: cards.NewCard(cards.Ace, cards.Clubs),
+	}
+	combo1 := notation.Combo{
+		Card1: cards.NewCard(cards.Queen, cards.Diamonds),
+		Card2: cards.NewCard(cards.Queen, cards.Hearts),
+	}
+
+	root, err := NewBuilder(ActionConfig{ShoveOnly: true}).Build(shoveOnlyRiverState(), combo0, combo1)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	// Decisions: root, x, xb20, b20. Terminals: xx, xb20f, xb20c, b20f, b20c.
+	want := TreeStats{
+		DecisionNodes: 4,
+		TerminalNodes: 5,
+		InfoSets:      4,
+		MaxDepth:      3,
+	}
+	got := root.Stats()
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if got.TotalNodes() != 9 {
+		t.Errorf("TotalNodes() = %d, want 9", got.TotalNodes())
+	}
+	if got.EstimatedBytes() <= 0 {
+		t.Errorf("EstimatedBytes() = %d, want > 0", got.EstimatedBytes())
+	}
+	if !strings.Contains(got.String(), "9 nodes") {
+		t.Errorf("String() = %q, expected node count", got.String())
+	}
+}
+
+func TestTreeNode_Stats_RangeChance(t *testing.T) {
+	combo := func(c1, c2 string) notation.Combo {
+		a, _ := cards.ParseCard(c1)
+		b, _ := cards.ParseCard(c2)
+		return notation.Combo{Card1: a, Card2: b}
+	}
+	range0 := []notation.Combo{combo("Ad", "Ac"), combo("Ah", "As")}
+	range1 := []notation.Combo{combo("Qd", "Qh"), combo("Jd", "Jh")}
+
+	root, err := NewBuilder(ActionConfig{ShoveOnly: true}).BuildRange(shoveOnlyRiverState(), range0, range1)
+	if err != nil {
+		t.Fatalf("BuildRange() failed: %v", err)
+	}
+
+	// One chance node dealing 2×2 combo pairs, each followed by the 9-node subtree
+	// Info sets: each player has 2 decision points × 2 combos
+	want := TreeStats{
+		DecisionNodes:  16,
+		TerminalNodes:  20,
+		ChanceNodes:    1,
+		ChanceOutcomes: 4,
+		InfoSets:       8,
+		MaxDepth:       4,
+	}
+	if got := root.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}